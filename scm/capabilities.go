@@ -0,0 +1,90 @@
+package scm
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Feature identifies a method of one of the services of
+// the client by the name of the service field and the
+// method, e.g. "PullRequests.Merge".
+type Feature string
+
+// NewFeature returns the feature of the method of the
+// service, e.g. NewFeature("PullRequests", "Merge").
+func NewFeature(service, method string) Feature {
+	return Feature(service + "." + method)
+}
+
+// Service returns the name of the service of the feature.
+func (f Feature) Service() string {
+	i := strings.Index(string(f), ".")
+	if i < 0 {
+		return string(f)
+	}
+	return string(f)[:i]
+}
+
+// Method returns the name of the method of the feature.
+func (f Feature) Method() string {
+	i := strings.Index(string(f), ".")
+	if i < 0 {
+		return ""
+	}
+	return string(f)[i+1:]
+}
+
+// Features returns every feature of the services of the
+// client sorted by name, whether a driver supports them
+// or not.
+func Features() []Feature {
+	var features []Feature
+	t := reflect.TypeOf(Client{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type.Kind() != reflect.Interface {
+			continue
+		}
+		for j := 0; j < field.Type.NumMethod(); j++ {
+			features = append(features, NewFeature(field.Name, field.Type.Method(j).Name))
+		}
+	}
+	sort.Slice(features, func(i, j int) bool {
+		return features[i] < features[j]
+	})
+	return features
+}
+
+// Capabilities returns the features supported by the
+// client sorted by name. A feature is supported if the
+// driver provides its service and does not declare it
+// as unsupported.
+func (c *Client) Capabilities() []Feature {
+	var features []Feature
+	for _, f := range Features() {
+		if c.Supports(f) {
+			features = append(features, f)
+		}
+	}
+	return features
+}
+
+// Supports returns true if the client supports the feature
+// so that callers can hide unsupported actions up front
+// rather than waiting for an ErrNotSupported error.
+func (c *Client) Supports(feature Feature) bool {
+	field := reflect.ValueOf(c).Elem().FieldByName(feature.Service())
+	if !field.IsValid() || field.Kind() != reflect.Interface || field.IsNil() {
+		return false
+	}
+	if _, ok := field.Type().MethodByName(feature.Method()); !ok {
+		return false
+	}
+	for _, f := range c.Unsupported {
+		if f == feature {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package scm
+
+import "testing"
+
+type searchServiceStub struct {
+	SearchService
+}
+
+func TestSupports(t *testing.T) {
+	client := &Client{
+		Search:      searchServiceStub{},
+		Unsupported: []Feature{"Search.Code"},
+	}
+	tests := []struct {
+		feature Feature
+		want    bool
+	}{
+		{"Search.Repositories", true},
+		{"Search.Code", false},
+		{"Search.Unknown", false},
+		{"Deployments.List", false},
+		{"Unknown.List", false},
+		{"Driver", false},
+	}
+	for _, test := range tests {
+		if got := client.Supports(test.feature); got != test.want {
+			t.Errorf("Want supports %s %t, got %t", test.feature, test.want, got)
+		}
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	client := &Client{
+		Search:      searchServiceStub{},
+		Unsupported: []Feature{"Search.Code"},
+	}
+	want := []Feature{"Search.Commits", "Search.Issues", "Search.PullRequests", "Search.Repositories"}
+	got := client.Capabilities()
+	if len(got) != len(want) {
+		t.Fatalf("Want capabilities %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Want capabilities %v, got %v", want, got)
+			break
+		}
+	}
+}
+
+func TestFeatures(t *testing.T) {
+	features := Features()
+	for i, f := range features {
+		if f.Service() == "" || f.Method() == "" {
+			t.Errorf("Want feature in the form Service.Method, got %q", f)
+		}
+		if i > 0 && features[i-1] >= f {
+			t.Errorf("Want features sorted, got %q before %q", features[i-1], f)
+		}
+	}
+	if got, want := NewFeature("PullRequests", "Merge"), Feature("PullRequests.Merge"); got != want {
+		t.Errorf("Want feature %q, got %q", want, got)
+	}
+}
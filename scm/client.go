@@ -118,10 +118,16 @@ type (
 		PullRequests  PullRequestService
 		Repositories  RepositoryService
 		Reviews       ReviewService
+		Search        SearchService
 		Users         UserService
 		Webhooks      WebhookService
 		Commits       CommitService
 
+		// Unsupported declares the features of the services
+		// above which the driver does not support and which
+		// return ErrNotSupported.
+		Unsupported []Feature
+
 		// DumpResponse optionally specifies a function to
 		// dump the the response body for debugging purposes.
 		// This can be set to httputil.DumpResponse.
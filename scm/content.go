@@ -12,16 +12,37 @@ type (
 		Path string
 		Data []byte
 		Sha  string
+
+		// Size is the size of the file in bytes and Encoding
+		// the encoding in which the provider returned it.
+		Size     int
+		Encoding string
+
+		// Link is the link to the file in the web interface
+		// and DownloadLink the link to its raw contents.
+		Link         string
+		DownloadLink string
+
+		// LastCommitSha is the sha of the last commit which
+		// changed the file. It is empty with providers which
+		// do not return it with the content, for which it is
+		// found with GitService.ListCommits by the path.
+		LastCommitSha string
 	}
 
-	// ContentParams provide parameters for creating and
-	// updating repository content.
+	// ContentParams provide parameters for creating, updating
+	// and deleting repository content.
 	ContentParams struct {
 		Ref     string
 		Branch  string
 		Message string
 		Data    []byte
 		Sha     string
+
+		// Author and Committer optionally set the identity of
+		// the commit which otherwise is the authenticated user.
+		Author    *Signature
+		Committer *Signature
 	}
 
 	// FileEntry returns the details of a file
@@ -34,6 +55,19 @@ type (
 		Link string
 	}
 
+	// BlameRange is a range of lines of a file which were
+	// last changed by the same commit.
+	BlameRange struct {
+		// StartLine and EndLine are the first and last
+		// line numbers of the range, starting at 1.
+		StartLine int
+		EndLine   int
+
+		// Commit is the commit which last changed the
+		// lines including its sha, author and date.
+		Commit Commit
+	}
+
 	// ContentService provides access to repositroy content.
 	ContentService interface {
 		// Find returns the repository file content by path.
@@ -48,7 +82,13 @@ type (
 		// Update updates a repository file.
 		Update(ctx context.Context, repo, path string, params *ContentParams) (*Response, error)
 
-		// Delete deletes a reository file.
-		Delete(ctx context.Context, repo, path, ref string) (*Response, error)
+		// Delete deletes a reository file from the branch of the
+		// params. Providers which require the sha of the file only
+		// delete it while it matches the sha of the params.
+		Delete(ctx context.Context, repo, path string, params *ContentParams) (*Response, error)
+
+		// Blame returns the commit which last changed each
+		// range of lines of the repository file at the ref.
+		Blame(ctx context.Context, repo, path, ref string) ([]*BlameRange, *Response, error)
 	}
 )
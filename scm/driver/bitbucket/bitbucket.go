@@ -46,6 +46,7 @@ func New(uri string) (*scm.Client, error) {
 	client.Reviews = &reviewService{client}
 	client.Users = &userService{client}
 	client.Webhooks = &webhookService{client}
+	client.Unsupported = unsupported
 	return client.Client, nil
 }
 
@@ -71,7 +72,12 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	}
 	// if we are posting or putting data, we need to
 	// write it to the body of the request.
-	if in != nil {
+	if form, ok := in.(url.Values); ok {
+		req.Header = map[string][]string{
+			"Content-Type": {"application/x-www-form-urlencoded"},
+		}
+		req.Body = strings.NewReader(form.Encode())
+	} else if in != nil {
 		buf := new(bytes.Buffer)
 		json.NewEncoder(buf).Encode(in) // #nosec
 		req.Header = map[string][]string{
@@ -0,0 +1,58 @@
+package bitbucket
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// unsupported lists the features of the services of the
+// client which return scm.ErrNotSupported.
+var unsupported = []scm.Feature{
+	"Contents.Blame",
+	"Git.CreateRef",
+	"Git.DeleteRef",
+	"Issues.AssignIssue",
+	"Issues.ClearMilestone",
+	"Issues.ListEvents",
+	"Issues.Lock",
+	"Issues.Search",
+	"Issues.SetMilestone",
+	"Issues.UnassignIssue",
+	"Issues.Unlock",
+	"Milestones.Create",
+	"Milestones.Delete",
+	"Milestones.Find",
+	"Milestones.List",
+	"Milestones.Update",
+	"Organizations.AcceptOrganizationInvitation",
+	"Organizations.Create",
+	"Organizations.Delete",
+	"Organizations.ListPendingInvitations",
+	"Organizations.ListTeamMembers",
+	"PullRequests.AssignIssue",
+	"PullRequests.ClearMilestone",
+	"PullRequests.DisableAutoMerge",
+	"PullRequests.EnableAutoMerge",
+	"PullRequests.Reopen",
+	"PullRequests.SetMilestone",
+	"PullRequests.UnassignIssue",
+	"PullRequests.UpdateBranch",
+	"Repositories.Delete",
+	"Repositories.Fork",
+	"Repositories.ListUser",
+	"Repositories.UpdateHook",
+	"Reviews.Create",
+	"Reviews.CreateComment",
+	"Reviews.Delete",
+	"Reviews.Dismiss",
+	"Reviews.Find",
+	"Reviews.List",
+	"Reviews.ListComments",
+	"Reviews.ListThreads",
+	"Reviews.ResolveThread",
+	"Reviews.Submit",
+	"Reviews.UnresolveThread",
+	"Reviews.Update",
+	"Users.AcceptInvitation",
+	"Users.CreateToken",
+	"Users.DeleteToken",
+	"Users.FindEmail",
+	"Users.ListInvitations",
+}
@@ -8,6 +8,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/url"
+	"path"
+	"strings"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -27,17 +30,91 @@ func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm
 }
 
 func (s *contentService) List(ctx context.Context, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	// directories are listed when the path has a trailing slash
+	dir := strings.Trim(path, "/")
+	if dir != "" {
+		dir += "/"
+	}
+	endpoint := fmt.Sprintf("2.0/repositories/%s/src/%s/%s?pagelen=100", repo, ref, dir)
+	var answer []*scm.FileEntry
+	var res *scm.Response
+	for endpoint != "" {
+		out := new(contents)
+		var err error
+		res, err = s.client.do(ctx, "GET", endpoint, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		answer = append(answer, convertContentList(out)...)
+		endpoint = out.Next
+	}
+	return answer, res, nil
 }
 
 func (s *contentService) Create(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return s.commit(ctx, repo, params.Branch, params.Message, url.Values{path: {string(params.Data)}})
 }
 
 func (s *contentService) Update(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return s.commit(ctx, repo, params.Branch, params.Message, url.Values{path: {string(params.Data)}})
+}
+
+func (s *contentService) Delete(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	return s.commit(ctx, repo, params.Branch, params.Message, url.Values{"files": {path}})
+}
+
+// commit creates a commit on the branch which writes or removes
+// the files in the form.
+func (s *contentService) commit(ctx context.Context, repo, branch, message string, form url.Values) (*scm.Response, error) {
+	endpoint := fmt.Sprintf("2.0/repositories/%s/src", repo)
+	if branch != "" {
+		form.Set("branch", branch)
+	}
+	if message != "" {
+		form.Set("message", message)
+	}
+	return s.client.do(ctx, "POST", endpoint, form, nil)
+}
+
+func (s *contentService) Blame(ctx context.Context, repo, path, ref string) ([]*scm.BlameRange, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+type contents struct {
+	pagination
+	Values []*content `json:"values"`
+}
+
+type content struct {
+	Path   string `json:"path"`
+	Type   string `json:"type"`
+	Size   int    `json:"size"`
+	Commit struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
+	Links struct {
+		Self link `json:"self"`
+	} `json:"links"`
+}
+
+func convertContentList(from *contents) []*scm.FileEntry {
+	to := []*scm.FileEntry{}
+	for _, v := range from.Values {
+		to = append(to, convertContent(v))
+	}
+	return to
 }
 
-func (s *contentService) Delete(ctx context.Context, repo, path, ref string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+func convertContent(from *content) *scm.FileEntry {
+	to := &scm.FileEntry{
+		Name: path.Base(from.Path),
+		Path: from.Path,
+		Type: "file",
+		Size: from.Size,
+		Link: from.Links.Self.Href,
+	}
+	if from.Type == "commit_directory" {
+		to.Type = "dir"
+	}
+	return to
 }
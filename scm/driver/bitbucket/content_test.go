@@ -41,25 +41,109 @@ func TestContentFind(t *testing.T) {
 	}
 }
 
+func TestContentList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/src/master/docs/").
+		MatchParam("page", "2").
+		Reply(200).
+		Type("application/json").
+		File("testdata/content_list_2.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/src/master/docs/").
+		Reply(200).
+		Type("application/json").
+		File("testdata/content_list.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Contents.List(context.Background(), "atlassian/atlaskit", "docs", "master")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.FileEntry{}
+	raw, _ := ioutil.ReadFile("testdata/content_list.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestContentCreate(t *testing.T) {
-	content := new(contentService)
-	_, err := content.Create(context.Background(), "atlassian/atlaskit", "README", nil)
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/atlaskit/src").
+		MatchHeader("Content-Type", "application/x-www-form-urlencoded").
+		BodyString("branch=master&docs%2Finstall.md=%23+Installation&message=document+the+installation").
+		Reply(201)
+
+	client, _ := New("https://api.bitbucket.org")
+	params := &scm.ContentParams{
+		Branch:  "master",
+		Message: "document the installation",
+		Data:    []byte("# Installation"),
+	}
+	_, err := client.Contents.Create(context.Background(), "atlassian/atlaskit", "docs/install.md", params)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the file to be committed")
 	}
 }
 
 func TestContentUpdate(t *testing.T) {
-	content := new(contentService)
-	_, err := content.Update(context.Background(), "atlassian/atlaskit", "README", nil)
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/atlaskit/src").
+		MatchHeader("Content-Type", "application/x-www-form-urlencoded").
+		BodyString("README=%23+Hello+World&branch=master&message=update+the+readme").
+		Reply(201)
+
+	client, _ := New("https://api.bitbucket.org")
+	params := &scm.ContentParams{
+		Branch:  "master",
+		Message: "update the readme",
+		Data:    []byte("# Hello World"),
+	}
+	_, err := client.Contents.Update(context.Background(), "atlassian/atlaskit", "README", params)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the file to be committed")
 	}
 }
 
 func TestContentDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/atlaskit/src").
+		MatchHeader("Content-Type", "application/x-www-form-urlencoded").
+		BodyString("branch=master&files=README&message=Delete+README").
+		Reply(201)
+
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.Contents.Delete(context.Background(), "atlassian/atlaskit", "README", &scm.ContentParams{Branch: "master", Message: "Delete README"})
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the file to be deleted")
+	}
+}
+
+func TestContentBlame(t *testing.T) {
 	content := new(contentService)
-	_, err := content.Delete(context.Background(), "atlassian/atlaskit", "README", "master")
+	_, _, err := content.Blame(context.Background(), "atlassian/atlaskit", "README", "master")
 	if err != scm.ErrNotSupported {
 		t.Errorf("Expect Not Supported error")
 	}
@@ -7,6 +7,7 @@ package bitbucket
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -113,7 +114,7 @@ func (s *gitService) ListCommits(ctx context.Context, repo string, opts scm.Comm
 		return nil, res, err
 	}
 	err = copyPagination(out.pagination, res)
-	return convertCommitList(out), res, err
+	return filterCommits(convertCommitList(out), opts), res, err
 }
 
 func (s *gitService) ListTags(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
@@ -139,14 +140,72 @@ func (s *gitService) ListChanges(ctx context.Context, repo, ref string, opts scm
 }
 
 func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
-	path := fmt.Sprintf("2.0/repositories/%s/diffstat/%s..%s?%s", repo, ref1, ref2, encodeListOptions(opts))
+	out, res, err := s.Compare(ctx, repo, ref1, ref2, opts)
+	if err != nil {
+		return nil, res, err
+	}
+	return out.Changes, res, nil
+}
+
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, opts scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
+	// bitbucket diffs the first commit of the revspec against its merge base with the second
+	path := fmt.Sprintf("2.0/repositories/%s/diffstat/%s..%s?%s", repo, head, base, encodeListOptions(opts))
 	out := new(diffstats)
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	if err != nil {
 		return nil, res, err
 	}
-	err = copyPagination(out.pagination, res)
-	return convertDiffstats(out), res, err
+	if err = copyPagination(out.pagination, res); err != nil {
+		return nil, res, err
+	}
+	ahead, aheadRes, err := s.listCommitsBetween(ctx, repo, head, base)
+	if err != nil {
+		return nil, aheadRes, err
+	}
+	behind, behindRes, err := s.listCommitsBetween(ctx, repo, base, head)
+	if err != nil {
+		return nil, behindRes, err
+	}
+	path = fmt.Sprintf("2.0/repositories/%s/merge-base/%s..%s", repo, base, head)
+	mergeBase := new(commit)
+	if mergeBaseRes, err := s.client.do(ctx, "GET", path, nil, mergeBase); err != nil {
+		return nil, mergeBaseRes, err
+	}
+	return &scm.Comparison{
+		MergeBase: mergeBase.Hash,
+		Ahead:     len(ahead.Values),
+		Behind:    len(behind.Values),
+		Commits:   convertCommitList(ahead),
+		Changes:   convertDiffstats(out),
+	}, res, nil
+}
+
+// listCommitsBetween returns all the commits reachable from the include ref
+// which are not reachable from the exclude ref, oldest first.
+func (s *gitService) listCommitsBetween(ctx context.Context, repo, include, exclude string) (*commits, *scm.Response, error) {
+	params := url.Values{}
+	params.Set("include", include)
+	params.Set("exclude", exclude)
+	params.Set("pagelen", "100")
+	path := fmt.Sprintf("2.0/repositories/%s/commits?%s", repo, params.Encode())
+	answer := new(commits)
+	var res *scm.Response
+	for path != "" {
+		out := new(commits)
+		var err error
+		res, err = s.client.do(ctx, "GET", path, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		answer.Values = append(answer.Values, out.Values...)
+		path = out.Next
+	}
+	// bitbucket lists the commits newest first
+	values := answer.Values
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+	return answer, res, nil
 }
 
 type branch struct {
@@ -300,6 +359,33 @@ func convertCommit(from *commit) *scm.Commit {
 	}
 }
 
+// filterCommits filters the commits by the author, since and until of the
+// options which are not supported by the bitbucket API
+func filterCommits(commits []*scm.Commit, opts scm.CommitListOptions) []*scm.Commit {
+	if opts.Author == "" && opts.Since.IsZero() && opts.Until.IsZero() {
+		return commits
+	}
+	dst := []*scm.Commit{}
+	for _, c := range commits {
+		switch {
+		case opts.Author != "" && !matchSignature(c.Author, opts.Author):
+		case !opts.Since.IsZero() && c.Author.Date.Before(opts.Since):
+		case !opts.Until.IsZero() && c.Author.Date.After(opts.Until):
+		default:
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
+
+// matchSignature returns true if the author is the login, name or email
+// of the signature
+func matchSignature(sig scm.Signature, author string) bool {
+	return strings.EqualFold(sig.Login, author) ||
+		strings.EqualFold(sig.Name, author) ||
+		strings.EqualFold(sig.Email, author)
+}
+
 func convertBranchList(from *branches) []*scm.Reference {
 	to := []*scm.Reference{}
 	for _, v := range from.Values {
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 
@@ -120,6 +121,43 @@ func TestGitListCommits(t *testing.T) {
 	t.Run("Page", testPage(res))
 }
 
+func TestGitListCommits_Filters(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/commits/master").
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		File("testdata/commits.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	opts := scm.CommitListOptions{
+		Ref:    "master",
+		Author: "aahmed",
+		Since:  time.Date(2015, time.August, 1, 0, 0, 0, 0, time.UTC),
+	}
+	got, _, err := client.Git.ListCommits(context.Background(), "atlassian/stash-example-plugin", opts)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(got) != 1 {
+		t.Errorf("Want the commit by the author since the time, got %d commits", len(got))
+	}
+
+	opts = scm.CommitListOptions{
+		Ref:   "master",
+		Until: time.Date(2015, time.August, 1, 0, 0, 0, 0, time.UTC),
+	}
+	got, _, err = client.Git.ListCommits(context.Background(), "atlassian/stash-example-plugin", opts)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Want no commits until the time, got %d commits", len(got))
+	}
+}
+
 func TestGitListBranches(t *testing.T) {
 	defer gock.Off()
 
@@ -209,13 +247,15 @@ func TestGitCompareCommits(t *testing.T) {
 	defer gock.Off()
 
 	gock.New("https://api.bitbucket.org").
-		Get("/2.0/repositories/atlassian/atlaskit/diffstat/anarbitraryshabutnotatallarbitrarylength..425863f9dbe56d70c8dcdbf2e4e0805e85591fcc").
+		Get("/2.0/repositories/atlassian/atlaskit/diffstat/425863f9dbe56d70c8dcdbf2e4e0805e85591fcc..anarbitraryshabutnotatallarbitrarylength").
 		MatchParam("page", "1").
 		MatchParam("pagelen", "30").
 		Reply(200).
 		Type("application/json").
 		File("testdata/diffstat.json")
 
+	mockCompareCommits()
+
 	client, _ := New("https://api.bitbucket.org")
 	got, _, err := client.Git.CompareCommits(context.Background(), "atlassian/atlaskit", "anarbitraryshabutnotatallarbitrarylength", "425863f9dbe56d70c8dcdbf2e4e0805e85591fcc", scm.ListOptions{Page: 1, Size: 30})
 	if err != nil {
@@ -231,3 +271,59 @@ func TestGitCompareCommits(t *testing.T) {
 		t.Log(diff)
 	}
 }
+
+// TestGitCompare asserts the changes are those of the head against the
+// base, bitbucket diffing the first commit of a revspec against the second
+func TestGitCompare(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/diffstat/425863f9dbe56d70c8dcdbf2e4e0805e85591fcc..anarbitraryshabutnotatallarbitrarylength").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare_diffstat.json")
+
+	mockCompareCommits()
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Git.Compare(context.Background(), "atlassian/atlaskit", "anarbitraryshabutnotatallarbitrarylength", "425863f9dbe56d70c8dcdbf2e4e0805e85591fcc", scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := ioutil.ReadFile("testdata/compare.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+// mockCompareCommits mocks the commits and merge base requested when
+// comparing the commits of the atlassian/atlaskit repository
+func mockCompareCommits() {
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/commits").
+		MatchParam("include", "425863f9dbe56d70c8dcdbf2e4e0805e85591fcc").
+		MatchParam("exclude", "anarbitraryshabutnotatallarbitrarylength").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare_ahead.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/commits").
+		MatchParam("include", "anarbitraryshabutnotatallarbitrarylength").
+		MatchParam("exclude", "425863f9dbe56d70c8dcdbf2e4e0805e85591fcc").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare_behind.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/merge-base/anarbitraryshabutnotatallarbitrarylength..425863f9dbe56d70c8dcdbf2e4e0805e85591fcc").
+		Reply(200).
+		Type("application/json").
+		File("testdata/merge_base.json")
+}
@@ -58,15 +58,33 @@ func (s *issueService) DeleteLabel(ctx context.Context, repo string, number int,
 }
 
 func (s *issueService) Find(ctx context.Context, repo string, number int) (*scm.Issue, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("2.0/repositories/%s/issues/%d", repo, number)
+	out := new(issue)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertIssue(out), res, err
 }
 
 func (s *issueService) FindComment(ctx context.Context, repo string, index, id int) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	if ok, res, err := s.isIssue(ctx, repo, index); err != nil {
+		return nil, res, err
+	} else if !ok {
+		return s.client.PullRequests.FindComment(ctx, repo, index, id)
+	}
+	path := fmt.Sprintf("2.0/repositories/%s/issues/%d/comments/%d", repo, index, id)
+	out := new(issueComment)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertIssueComment(out), res, err
 }
 
 func (s *issueService) List(ctx context.Context, repo string, opts scm.IssueListOptions) ([]*scm.Issue, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("2.0/repositories/%s/issues?%s", repo, encodeIssueListOptions(opts))
+	out := new(issues)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	err = copyPagination(out.pagination, res)
+	return convertIssueList(out), res, err
 }
 
 func convertIssueCommentList(from []*issueComment) []*scm.Comment {
@@ -78,14 +96,111 @@ func convertIssueCommentList(from []*issueComment) []*scm.Comment {
 }
 
 func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
-	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments?%s", repo, index, encodeListOptions(opts))
-	out := []*issueComment{}
-	res, err := s.client.do(ctx, "GET", path, nil, &out)
-	return convertIssueCommentList(out), res, err
+	if ok, res, err := s.isIssue(ctx, repo, index); err != nil {
+		return nil, res, err
+	} else if !ok {
+		return s.client.PullRequests.ListComments(ctx, repo, index, opts)
+	}
+	path := fmt.Sprintf("2.0/repositories/%s/issues/%d/comments?%s", repo, index, encodeListOptions(opts))
+	out := new(issueComments)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	err = copyPagination(out.pagination, res)
+	return convertIssueCommentList(out.Values), res, err
 }
 
 func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("2.0/repositories/%s/issues", repo)
+	in := &issueInput{Title: input.Title}
+	in.Content.Raw = input.Body
+	out := new(issue)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertIssue(out), res, err
+}
+
+type issues struct {
+	pagination
+	Values []*issue `json:"values"`
+}
+
+type issue struct {
+	ID       int    `json:"id"`
+	Title    string `json:"title"`
+	State    string `json:"state"`
+	Kind     string `json:"kind"`
+	Priority string `json:"priority"`
+	Reporter *user  `json:"reporter"`
+	Assignee *user  `json:"assignee"`
+	Content  struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Links struct {
+		HTML link `json:"html"`
+	} `json:"links"`
+	CreatedOn time.Time `json:"created_on"`
+	UpdatedOn time.Time `json:"updated_on"`
+}
+
+type issueInput struct {
+	Title   string `json:"title,omitempty"`
+	Content struct {
+		Raw string `json:"raw,omitempty"`
+	} `json:"content"`
+}
+
+type issueStateInput struct {
+	State string `json:"state"`
+}
+
+type issueComments struct {
+	pagination
+	Values []*issueComment `json:"values"`
+}
+
+// closedStates are the states of the issue tracker in which
+// an issue is no longer open.
+var closedStates = []string{"resolved", "invalid", "duplicate", "wontfix", "closed"}
+
+func isClosedState(state string) bool {
+	for _, s := range closedStates {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+func convertIssueList(from *issues) []*scm.Issue {
+	to := []*scm.Issue{}
+	for _, v := range from.Values {
+		to = append(to, convertIssue(v))
+	}
+	return to
+}
+
+func convertIssue(from *issue) *scm.Issue {
+	to := &scm.Issue{
+		Number:  from.ID,
+		Title:   from.Title,
+		Body:    from.Content.Raw,
+		Link:    from.Links.HTML.Href,
+		State:   "open",
+		Closed:  isClosedState(from.State),
+		Created: from.CreatedOn,
+		Updated: from.UpdatedOn,
+	}
+	if to.Closed {
+		to.State = "closed"
+	}
+	if from.Reporter != nil {
+		to.Author = *convertUser(from.Reporter)
+	}
+	if from.Assignee != nil {
+		to.Assignees = []scm.User{*convertUser(from.Assignee)}
+	}
+	return to
 }
 
 type issueCommentInput struct {
@@ -132,7 +247,12 @@ func convertIssueComment(from *issueComment) *scm.Comment {
 }
 
 func (s *issueService) CreateComment(ctx context.Context, repo string, number int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments", repo, number)
+	if ok, res, err := s.isIssue(ctx, repo, number); err != nil {
+		return nil, res, err
+	} else if !ok {
+		return s.client.PullRequests.CreateComment(ctx, repo, number, input)
+	}
+	path := fmt.Sprintf("2.0/repositories/%s/issues/%d/comments", repo, number)
 	in := new(issueCommentInput)
 	in.Content.Raw = input.Body
 	out := new(issueComment)
@@ -141,20 +261,66 @@ func (s *issueService) CreateComment(ctx context.Context, repo string, number in
 }
 
 func (s *issueService) DeleteComment(ctx context.Context, repo string, number, id int) (*scm.Response, error) {
-	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments/%d", repo, number, id)
+	if ok, res, err := s.isIssue(ctx, repo, number); err != nil {
+		return res, err
+	} else if !ok {
+		return s.client.PullRequests.DeleteComment(ctx, repo, number, id)
+	}
+	path := fmt.Sprintf("2.0/repositories/%s/issues/%d/comments/%d", repo, number, id)
 	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
 func (s *issueService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	if ok, res, err := s.isIssue(ctx, repo, number); err != nil {
+		return nil, res, err
+	} else if !ok {
+		return s.client.PullRequests.EditComment(ctx, repo, number, id, input)
+	}
+	path := fmt.Sprintf("2.0/repositories/%s/issues/%d/comments/%d", repo, number, id)
+	in := new(issueCommentInput)
+	in.Content.Raw = input.Body
+	out := new(issueComment)
+	res, err := s.client.do(ctx, "PUT", path, in, out)
+	return convertIssueComment(out), res, err
+}
+
+// isIssue resolves whether the number names an issue of the issue tracker
+// rather than a pull request. Callers comment on pull requests through the
+// issue service like on other providers, and pull requests and issues are
+// numbered apart, so the comments of pull requests stay the default: the
+// issue tracker is only used when there is no pull request with the number
+// and the issue tracker has an issue with it.
+func (s *issueService) isIssue(ctx context.Context, repo string, number int) (bool, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d", repo, number)
+	res, err := s.client.do(ctx, "GET", path, nil, nil)
+	if !isMissing(res, err) {
+		return false, res, err
+	}
+	path = fmt.Sprintf("2.0/repositories/%s/issues/%d", repo, number)
+	res, err = s.client.do(ctx, "GET", path, nil, nil)
+	if isMissing(res, err) {
+		return false, nil, nil
+	}
+	return err == nil, res, err
+}
+
+// isMissing returns true if the resource was not found.
+func isMissing(res *scm.Response, err error) bool {
+	return err != nil && res != nil && res.Status == 404
 }
 
 func (s *issueService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return s.setState(ctx, repo, number, "closed")
 }
 
 func (s *issueService) Reopen(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return s.setState(ctx, repo, number, "open")
+}
+
+func (s *issueService) setState(ctx context.Context, repo string, number int, state string) (*scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/issues/%d", repo, number)
+	in := &issueStateInput{State: state}
+	return s.client.do(ctx, "PUT", path, in, nil)
 }
 
 func (s *issueService) Lock(ctx context.Context, repo string, number int) (*scm.Response, error) {
@@ -6,55 +6,387 @@ package bitbucket
 
 import (
 	"context"
+	"encoding/json"
+	"io/ioutil"
 	"testing"
 
 	"github.com/jenkins-x/go-scm/scm"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestIssueFind(t *testing.T) {
-	_, _, err := NewDefault().Issues.Find(context.Background(), "", 0)
-	if err != nil && err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/issues/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Issues.Find(context.Background(), "atlassian/atlaskit", 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Issue)
+	raw, _ := ioutil.ReadFile("testdata/issue.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
 
 func TestIssueCommentFind(t *testing.T) {
-	_, _, err := NewDefault().Issues.FindComment(context.Background(), "", 0, 0)
-	if err != nil && err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		Reply(404).
+		Type("application/json").
+		File("testdata/error.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/issues/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/issues/1/comments/48190541").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue_comment.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Issues.FindComment(context.Background(), "atlassian/atlaskit", 1, 48190541)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comment)
+	raw, _ := ioutil.ReadFile("testdata/issue_comment.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
 
 func TestIssueList(t *testing.T) {
-	_, _, err := NewDefault().Issues.List(context.Background(), "", scm.IssueListOptions{})
-	if err != nil && err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/issues").
+		MatchParam("page", "1").
+		MatchParam("pagelen", "2").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issues.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, res, err := client.Issues.List(context.Background(), "atlassian/atlaskit", scm.IssueListOptions{Page: 1, Size: 2, Open: true, Closed: true})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Issue{}
+	raw, _ := ioutil.ReadFile("testdata/issues.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if got, want := res.Page.Next, 2; got != want {
+		t.Errorf("Want next page %d, got %d", want, got)
 	}
 }
 
 func TestIssueListComments(t *testing.T) {
-	// TODO
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		Reply(404).
+		Type("application/json").
+		File("testdata/error.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/issues/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/issues/1/comments").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue_comments.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Issues.ListComments(context.Background(), "atlassian/atlaskit", 1, scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Comment{}
+	raw, _ := ioutil.ReadFile("testdata/issue_comments.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueListComments_PullRequest(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_reviewers.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/1/comments").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_comments.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Issues.ListComments(context.Background(), "atlassian/atlaskit", 1, scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Comment{}
+	raw, _ := ioutil.ReadFile("testdata/pr_comments.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
 }
 
 func TestIssueCreate(t *testing.T) {
-	_, _, err := NewDefault().Issues.Create(context.Background(), "", &scm.IssueInput{})
-	if err != nil && err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/atlaskit/issues").
+		MatchType("json").
+		JSON(map[string]interface{}{"title": "Found a bug", "content": map[string]string{"raw": "I'm having a problem with this."}}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	input := &scm.IssueInput{
+		Title: "Found a bug",
+		Body:  "I'm having a problem with this.",
+	}
+	got, _, err := client.Issues.Create(context.Background(), "atlassian/atlaskit", input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Issue)
+	raw, _ := ioutil.ReadFile("testdata/issue.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
 
 func TestIssueCreateComment(t *testing.T) {
-	// TODO
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		Reply(404).
+		Type("application/json").
+		File("testdata/error.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/issues/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/atlaskit/issues/1/comments").
+		MatchType("json").
+		JSON(map[string]interface{}{"content": map[string]string{"raw": "Me too"}}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/issue_comment.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Issues.CreateComment(context.Background(), "atlassian/atlaskit", 1, &scm.CommentInput{Body: "Me too"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comment)
+	raw, _ := ioutil.ReadFile("testdata/issue_comment.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueCreateComment_PullRequest(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_reviewers.json")
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/atlaskit/pullrequests/1/comments").
+		MatchType("json").
+		JSON(map[string]interface{}{"content": map[string]string{"raw": "Me too"}}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Issues.CreateComment(context.Background(), "atlassian/atlaskit", 1, &scm.CommentInput{Body: "Me too"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comment)
+	raw, _ := ioutil.ReadFile("testdata/pr_comment.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueCommentEdit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		Reply(404).
+		Type("application/json").
+		File("testdata/error.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/issues/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	gock.New("https://api.bitbucket.org").
+		Put("/2.0/repositories/atlassian/atlaskit/issues/1/comments/48190541").
+		MatchType("json").
+		JSON(map[string]interface{}{"content": map[string]string{"raw": "Me too"}}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue_comment.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Issues.EditComment(context.Background(), "atlassian/atlaskit", 1, 48190541, &scm.CommentInput{Body: "Me too"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comment)
+	raw, _ := ioutil.ReadFile("testdata/issue_comment.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
 }
 
 func TestIssueCommentDelete(t *testing.T) {
-	// TODO
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		Reply(404).
+		Type("application/json").
+		File("testdata/error.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/issues/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	gock.New("https://api.bitbucket.org").
+		Delete("/2.0/repositories/atlassian/atlaskit/issues/1/comments/48190541").
+		Reply(204)
+
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.Issues.DeleteComment(context.Background(), "atlassian/atlaskit", 1, 48190541)
+	if err != nil {
+		t.Error(err)
+	}
 }
 
 func TestIssueClose(t *testing.T) {
-	_, err := NewDefault().Issues.Close(context.Background(), "", 0)
-	if err != nil && err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Put("/2.0/repositories/atlassian/atlaskit/issues/1").
+		MatchType("json").
+		JSON(map[string]string{"state": "closed"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.Issues.Close(context.Background(), "atlassian/atlaskit", 1)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIssueReopen(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Put("/2.0/repositories/atlassian/atlaskit/issues/1").
+		MatchType("json").
+		JSON(map[string]string{"state": "open"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.Issues.Reopen(context.Background(), "atlassian/atlaskit", 1)
+	if err != nil {
+		t.Error(err)
 	}
 }
 
@@ -71,3 +403,42 @@ func TestIssueUnlock(t *testing.T) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
+
+func TestIssueCommentDelete_PullRequest(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_reviewers.json")
+
+	gock.New("https://api.bitbucket.org").
+		Delete("/2.0/repositories/atlassian/atlaskit/pullrequests/1/comments/114295012").
+		Reply(204)
+
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.Issues.DeleteComment(context.Background(), "atlassian/atlaskit", 1, 114295012)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the pull request comment to be deleted")
+	}
+}
+
+func TestIssueCreateComment_Unresolved(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		Reply(500).
+		Type("application/json").
+		File("testdata/error.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	_, _, err := client.Issues.CreateComment(context.Background(), "atlassian/atlaskit", 1, &scm.CommentInput{Body: "Me too"})
+	if err == nil {
+		t.Errorf("Expect the comment not to be created when the number cannot be resolved")
+	}
+}
@@ -23,42 +23,55 @@ func (s *organizationService) Delete(context.Context, string) (*scm.Response, er
 	return nil, scm.ErrNotSupported
 }
 
+// IsMember returns true if the user has any permission on the workspace.
 func (s *organizationService) IsMember(ctx context.Context, org string, user string) (bool, *scm.Response, error) {
+	permission, res, err := s.findPermission(ctx, org, user)
+	return permission != "", res, err
+}
+
+// IsAdmin returns true if the user owns the workspace.
+func (s *organizationService) IsAdmin(ctx context.Context, org string, user string) (bool, *scm.Response, error) {
+	permission, res, err := s.findPermission(ctx, org, user)
+	return isWorkspaceAdmin(permission), res, err
+}
+
+// findPermission returns the permission of the user on the workspace
+// or an empty string if the user is not a member.
+func (s *organizationService) findPermission(ctx context.Context, org string, user string) (string, *scm.Response, error) {
 	path := fmt.Sprintf("2.0/workspaces/%s/permissions?q=user.account_id=\"%s\"", org, user)
 	result := new(organizationMemberships)
 	res, err := s.client.do(ctx, "GET", path, nil, result)
-
-	if err != nil {
-		return false, res, err
-	}
-
-	if len(result.Values) == 0 {
-		return false, res, nil
-	}
-
-	permission := result.Values[0].Permission
-
-	if permission == "admin" || permission == "owner" {
-		return true, res, nil
+	if err != nil || len(result.Values) == 0 {
+		return "", res, err
 	}
-
-	return false, res, nil
-}
-
-func (s *organizationService) IsAdmin(ctx context.Context, org string, user string) (bool, *scm.Response, error) {
-	return false, nil, scm.ErrNotSupported
+	return result.Values[0].Permission, res, nil
 }
 
-func (s *organizationService) ListTeams(ctx context.Context, org string, ops scm.ListOptions) ([]*scm.Team, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+// ListTeams returns the projects of the workspace.
+func (s *organizationService) ListTeams(ctx context.Context, org string, opts scm.ListOptions) ([]*scm.Team, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/workspaces/%s/projects?%s", org, encodeListOptions(opts))
+	out := new(projects)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	err = copyPagination(out.pagination, res)
+	return convertProjectList(out), res, err
 }
 
 func (s *organizationService) ListTeamMembers(ctx context.Context, id int, role string, ops scm.ListOptions) ([]*scm.TeamMember, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
 
-func (s *organizationService) ListOrgMembers(ctx context.Context, org string, ops scm.ListOptions) ([]*scm.TeamMember, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *organizationService) ListOrgMembers(ctx context.Context, org string, opts scm.ListOptions) ([]*scm.TeamMember, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/workspaces/%s/permissions?%s", org, encodeListOptions(opts))
+	out := new(organizationMemberships)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	err = copyPagination(out.pagination, res)
+	return convertMemberList(out), res, err
 }
 
 func (s *organizationService) Find(ctx context.Context, name string) (*scm.Organization, *scm.Response, error) {
@@ -88,7 +101,14 @@ func (s *organizationService) AcceptOrganizationInvitation(ctx context.Context,
 }
 
 func (s *organizationService) ListMemberships(ctx context.Context, opts scm.ListOptions) ([]*scm.Membership, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("2.0/user/permissions/workspaces?%s", encodeListOptions(opts))
+	out := new(organizationMemberships)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	err = copyPagination(out.pagination, res)
+	return convertMembershipList(out), res, err
 }
 
 func convertOrganizationList(from *organizationList) []*scm.Organization {
@@ -114,7 +134,82 @@ type organization struct {
 }
 
 type orgMemberPermission struct {
-	Permission string `json:"permission"`
+	Permission string    `json:"permission"`
+	User       user      `json:"user"`
+	Workspace  workspace `json:"workspace"`
+}
+
+type workspace struct {
+	UUID string `json:"uuid"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+type projects struct {
+	pagination
+	Values []*project `json:"values"`
+}
+
+type project struct {
+	UUID        string `json:"uuid"`
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsPrivate   bool   `json:"is_private"`
+}
+
+// isWorkspaceAdmin returns true if the workspace permission
+// grants administration of the workspace.
+func isWorkspaceAdmin(permission string) bool {
+	return permission == "owner" || permission == "admin"
+}
+
+func convertMemberList(from *organizationMemberships) []*scm.TeamMember {
+	to := []*scm.TeamMember{}
+	for _, v := range from.Values {
+		to = append(to, &scm.TeamMember{
+			Login:   convertUser(&v.User).Login,
+			IsAdmin: isWorkspaceAdmin(v.Permission),
+		})
+	}
+	return to
+}
+
+func convertMembershipList(from *organizationMemberships) []*scm.Membership {
+	to := []*scm.Membership{}
+	for _, v := range from.Values {
+		role := "member"
+		if isWorkspaceAdmin(v.Permission) {
+			role = "admin"
+		}
+		to = append(to, &scm.Membership{
+			State:            "active",
+			Role:             role,
+			OrganizationName: v.Workspace.Slug,
+		})
+	}
+	return to
+}
+
+func convertProjectList(from *projects) []*scm.Team {
+	to := []*scm.Team{}
+	for _, v := range from.Values {
+		to = append(to, convertProject(v))
+	}
+	return to
+}
+
+func convertProject(from *project) *scm.Team {
+	privacy := "closed"
+	if from.IsPrivate {
+		privacy = "secret"
+	}
+	return &scm.Team{
+		Name:        from.Name,
+		Slug:        from.Key,
+		Description: from.Description,
+		Privacy:     privacy,
+	}
 }
 
 func convertOrganization(from *organization) *scm.Organization {
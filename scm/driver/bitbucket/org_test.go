@@ -67,3 +67,127 @@ func TestOrganizationList(t *testing.T) {
 		t.Log(diff)
 	}
 }
+
+func TestOrganizationIsMember(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/workspaces/atlassian/permissions").
+		MatchParam("q", `user.account_id="557058:2a6349dc-4346-4805-bd84-3abdd0812d17"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/workspace_permission.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Organizations.IsMember(context.Background(), "atlassian", "557058:2a6349dc-4346-4805-bd84-3abdd0812d17")
+	if err != nil {
+		t.Error(err)
+	}
+	if !got {
+		t.Errorf("Expect the user to be a member of the workspace")
+	}
+}
+
+func TestOrganizationIsAdmin(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/workspaces/atlassian/permissions").
+		MatchParam("q", `user.account_id="557058:2a6349dc-4346-4805-bd84-3abdd0812d17"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/workspace_permission.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Organizations.IsAdmin(context.Background(), "atlassian", "557058:2a6349dc-4346-4805-bd84-3abdd0812d17")
+	if err != nil {
+		t.Error(err)
+	}
+	if !got {
+		t.Errorf("Expect the owner to be an admin of the workspace")
+	}
+}
+
+func TestOrganizationListOrgMembers(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/workspaces/atlassian/permissions").
+		MatchParam("pagelen", "2").
+		MatchParam("page", "1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/workspace_permissions.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, res, err := client.Organizations.ListOrgMembers(context.Background(), "atlassian", scm.ListOptions{Size: 2, Page: 1})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.TeamMember{}
+	raw, _ := ioutil.ReadFile("testdata/workspace_permissions.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if got, want := res.Page.Next, 2; got != want {
+		t.Errorf("Want next page %d, got %d", want, got)
+	}
+}
+
+func TestOrganizationListMemberships(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/user/permissions/workspaces").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user_workspaces.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Organizations.ListMemberships(context.Background(), scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Membership{}
+	raw, _ := ioutil.ReadFile("testdata/user_workspaces.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestOrganizationListTeams(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/workspaces/atlassian/projects").
+		Reply(200).
+		Type("application/json").
+		File("testdata/projects.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Organizations.ListTeams(context.Background(), "atlassian", scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Team{}
+	raw, _ := ioutil.ReadFile("testdata/projects.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
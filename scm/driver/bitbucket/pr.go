@@ -32,6 +32,14 @@ func (s *pullService) Find(ctx context.Context, repo string, number int) (*scm.P
 	return responsePR, res, err
 }
 
+func (s *pullService) FindMergeability(ctx context.Context, repo string, number int) (*scm.Mergeability, *scm.Response, error) {
+	pr, res, err := s.Find(ctx, repo, number)
+	if err != nil {
+		return nil, res, err
+	}
+	return scm.FindMergeability(ctx, s.client.Client, repo, pr, nil)
+}
+
 func (s *pullService) List(ctx context.Context, repo string, opts scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
 	path := fmt.Sprintf("2.0/repositories/%s/pullrequests?%s", repo, encodePullRequestListOptions(opts))
 	out := new(pullRequests)
@@ -151,6 +159,22 @@ func (s *pullService) DeleteComment(ctx context.Context, repo string, number, id
 	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
+func (s *pullService) FindComment(ctx context.Context, repo string, number, id int) (*scm.Comment, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments/%d", repo, number, id)
+	out := new(prComment)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertPRComment(out), res, err
+}
+
+func (s *pullService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments/%d", repo, number, id)
+	in := new(prCommentInput)
+	in.Content.Raw = input.Body
+	out := new(prComment)
+	res, err := s.client.do(ctx, "PUT", path, in, out)
+	return convertPRComment(out), res, err
+}
+
 func convertPRCommentList(from *pullRequestComments) []*scm.Comment {
 	to := []*scm.Comment{}
 	for _, v := range from.Values {
@@ -177,6 +201,17 @@ func (s *pullService) ListChanges(ctx context.Context, repo string, number int,
 	return convertDiffstats(out), res, err
 }
 
+func (s *pullService) ListCommits(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Commit, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/commits?%s", repo, number, encodeListOptions(opts))
+	out := new(commits)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	err = copyPagination(out.pagination, res)
+	return convertCommitList(out), res, err
+}
+
 func (s *pullService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
 	// Get all comments, parse out labels (removing and added based off time)
 	cs, res, err := s.ListComments(ctx, repo, number, opts)
@@ -199,8 +234,19 @@ func (s *pullService) DeleteLabel(ctx context.Context, repo string, number int,
 	return res, err
 }
 
-func (s *pullService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+// ListEvents derives the events of the pull request from its activity,
+// including the labels which are added and removed by comments. The
+// activity is paged with a cursor instead of page numbers, so the next
+// page is only known by the NextURL of the response page.
+func (s *pullService) ListEvents(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/activity?%s", repo, number, encodeListOptions(opts))
+	out := new(prActivities)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	err = copyPagination(out.pagination, res)
+	return convertPRActivities(out), res, err
 }
 
 type prMerge struct {
@@ -220,14 +266,73 @@ func (s *pullService) Merge(ctx context.Context, repo string, number int, option
 	return res, err
 }
 
+func (s *pullService) EnableAutoMerge(ctx context.Context, repo string, number int, options *scm.PullRequestMergeOptions) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) DisableAutoMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) UpdateBranch(ctx context.Context, repo string, number int, method string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) MarkReady(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d", repo, number)
+	in := map[string]bool{"draft": false}
+	return s.client.do(ctx, "PUT", path, in, nil)
+}
+
+func (s *pullService) ConvertToDraft(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d", repo, number)
+	in := map[string]bool{"draft": true}
+	return s.client.do(ctx, "PUT", path, in, nil)
+}
+
 func (s *pullService) Update(ctx context.Context, repo string, number int, prInput *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	// pull requests in bitbucket have no labels, assignees or milestones
+	if len(prInput.Labels) > 0 || len(prInput.Assignees) > 0 || prInput.Milestone != 0 {
+		return nil, nil, scm.ErrNotSupported
+	}
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d", repo, number)
+	in := &prUpdateInput{
+		Title:       prInput.Title,
+		Description: prInput.Body,
+	}
+	// bitbucket requires the title with every update
+	if in.Title == "" {
+		current := new(pullRequest)
+		res, err := s.client.do(ctx, "GET", path, nil, current)
+		if err != nil {
+			return nil, res, err
+		}
+		in.Title = current.Title
+	}
+	if prInput.Base != "" {
+		in.Destination = &prPatchBranch{Branch: prPatchName{Name: prInput.Base}}
+	}
+	for _, login := range prInput.Reviewers {
+		in.Reviewers = append(in.Reviewers, convertReviewerInput(login))
+	}
+	out := new(pullRequest)
+	res, err := s.client.do(ctx, "PUT", path, in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	responsePR := convertPullRequest(out)
+	populateMergeableState(ctx, s, out, responsePR)
+	return responsePR, res, nil
 }
 
+// Close declines the pull request.
 func (s *pullService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/decline", repo, number)
+	return s.client.do(ctx, "POST", path, nil, nil)
 }
 
+// Reopen is not supported as declined pull requests in bitbucket
+// cannot be reopened but have to be created again.
 func (s *pullService) Reopen(ctx context.Context, repo string, number int) (*scm.Response, error) {
 	return nil, scm.ErrNotSupported
 }
@@ -248,37 +353,237 @@ type prPatchBranch struct {
 	Branch prPatchName `json:"branch,omitempty"`
 }
 
+type prPatchRepository struct {
+	FullName string `json:"full_name"`
+}
+
+type prSourceBranch struct {
+	Branch     prPatchName        `json:"branch,omitempty"`
+	Repository *prPatchRepository `json:"repository,omitempty"`
+}
+
+type prReviewer struct {
+	UUID      string `json:"uuid,omitempty"`
+	AccountID string `json:"account_id,omitempty"`
+}
+
+type prReviewers struct {
+	Title     string       `json:"title"`
+	Reviewers []prReviewer `json:"reviewers"`
+}
+
+type prUpdateInput struct {
+	Title       string         `json:"title,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Destination *prPatchBranch `json:"destination,omitempty"`
+	Reviewers   []prReviewer   `json:"reviewers,omitempty"`
+}
+
 type prInput struct {
-	Title   string        `json:"title,omitempty"`
-	Source  prPatchBranch `json:"source,omitempty"`
-	Project string
+	Title       string         `json:"title,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Source      prSourceBranch `json:"source,omitempty"`
+	Destination *prPatchBranch `json:"destination,omitempty"`
+	Reviewers   []prReviewer   `json:"reviewers,omitempty"`
+	Draft       bool           `json:"draft,omitempty"`
+	Project     string
 }
 
 func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
+	// pull requests in bitbucket have no assignees or milestones
+	if len(input.Assignees) > 0 || input.Milestone != 0 {
+		return nil, nil, scm.ErrNotSupported
+	}
 	path := fmt.Sprintf("2.0/repositories/%s/pullrequests", repo)
 	in := &prInput{
-		Title: input.Title,
-		Source: prPatchBranch{
+		Title:       input.Title,
+		Description: input.Body,
+		Source: prSourceBranch{
 			Branch: prPatchName{
 				Name: input.Head,
 			},
 		},
+		Draft: input.Draft,
+	}
+	if input.HeadRepo != "" && input.HeadRepo != repo {
+		in.Source.Repository = &prPatchRepository{FullName: input.HeadRepo}
+	}
+	if input.Base != "" {
+		in.Destination = &prPatchBranch{Branch: prPatchName{Name: input.Base}}
+	}
+	for _, login := range input.Reviewers {
+		in.Reviewers = append(in.Reviewers, convertReviewerInput(login))
 	}
 	out := new(pullRequest)
 	res, err := s.client.do(ctx, "POST", path, in, out)
-
+	if err != nil {
+		return convertPullRequest(out), res, err
+	}
 	responsePR := convertPullRequest(out)
+	// labels are stored as comments
+	for _, label := range input.Labels {
+		if res, err := s.AddLabel(ctx, repo, out.ID, label); err != nil {
+			return responsePR, res, err
+		}
+	}
 
 	populateMergeableState(ctx, s, out, responsePR)
-	return responsePR, res, err
+	return responsePR, res, nil
 }
 
 func (s *pullService) RequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return s.updateReviewers(ctx, repo, number, func(reviewers []prReviewer) []prReviewer {
+		for _, login := range logins {
+			if !containsReviewer(reviewers, login) {
+				reviewers = append(reviewers, convertReviewerInput(login))
+			}
+		}
+		return reviewers
+	})
 }
 
 func (s *pullService) UnrequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return s.updateReviewers(ctx, repo, number, func(reviewers []prReviewer) []prReviewer {
+		var remaining []prReviewer
+		for _, reviewer := range reviewers {
+			if !containsLogin(logins, reviewer) {
+				remaining = append(remaining, reviewer)
+			}
+		}
+		return remaining
+	})
+}
+
+// updateReviewers replaces the reviewers of the pull request by the
+// result of the update, as bitbucket only accepts the whole list.
+func (s *pullService) updateReviewers(ctx context.Context, repo string, number int, update func([]prReviewer) []prReviewer) (*scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d", repo, number)
+	out := new(pullRequest)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return res, err
+	}
+	var reviewers []prReviewer
+	for _, v := range out.Reviewers {
+		reviewers = append(reviewers, prReviewer{UUID: v.UUID, AccountID: v.AccountID})
+	}
+	// bitbucket requires the title with every update
+	in := &prReviewers{Title: out.Title, Reviewers: update(reviewers)}
+	if in.Reviewers == nil {
+		in.Reviewers = []prReviewer{}
+	}
+	return s.client.do(ctx, "PUT", path, in, nil)
+}
+
+// convertReviewerInput returns the reviewer of the login which is either
+// the uuid of the user, e.g. "{...}", or its account id.
+func convertReviewerInput(login string) prReviewer {
+	if strings.HasPrefix(login, "{") {
+		return prReviewer{UUID: login}
+	}
+	return prReviewer{AccountID: login}
+}
+
+func containsReviewer(reviewers []prReviewer, login string) bool {
+	for _, reviewer := range reviewers {
+		if reviewer.UUID == login || reviewer.AccountID == login {
+			return true
+		}
+	}
+	return false
+}
+
+func containsLogin(logins []string, reviewer prReviewer) bool {
+	for _, login := range logins {
+		if reviewer.UUID == login || reviewer.AccountID == login {
+			return true
+		}
+	}
+	return false
+}
+
+type prActivities struct {
+	pagination
+	Values []*prActivity `json:"values"`
+}
+
+type prActivity struct {
+	Update *struct {
+		State  string    `json:"state"`
+		Date   time.Time `json:"date"`
+		Author user      `json:"author"`
+	} `json:"update,omitempty"`
+	Approval *struct {
+		Date time.Time `json:"date"`
+		User user      `json:"user"`
+	} `json:"approval,omitempty"`
+	ChangesRequest *struct {
+		Date time.Time `json:"date"`
+		User user      `json:"user"`
+	} `json:"changes_request,omitempty"`
+	Comment *prComment `json:"comment,omitempty"`
+}
+
+func convertPRActivities(from *prActivities) []*scm.ListedIssueEvent {
+	to := []*scm.ListedIssueEvent{}
+	for _, v := range from.Values {
+		if event := convertPRActivity(v); event != nil {
+			to = append(to, event)
+		}
+	}
+	return to
+}
+
+// convertPRActivity converts the activity to an event named like the
+// issue events of GitHub or returns nil if there is no such event.
+func convertPRActivity(from *prActivity) *scm.ListedIssueEvent {
+	switch {
+	case from.Update != nil:
+		event := ""
+		switch from.Update.State {
+		case "MERGED":
+			event = "merged"
+		case "DECLINED":
+			event = "closed"
+		default:
+			return nil
+		}
+		return &scm.ListedIssueEvent{
+			Event:   event,
+			Actor:   convertActivityUser(&from.Update.Author),
+			Created: from.Update.Date,
+		}
+	case from.Approval != nil:
+		return &scm.ListedIssueEvent{
+			Event:   "approved",
+			Actor:   convertActivityUser(&from.Approval.User),
+			Created: from.Approval.Date,
+		}
+	case from.ChangesRequest != nil:
+		return &scm.ListedIssueEvent{
+			Event:   "changes_requested",
+			Actor:   convertActivityUser(&from.ChangesRequest.User),
+			Created: from.ChangesRequest.Date,
+		}
+	case from.Comment != nil:
+		// labels are added and removed by comments
+		comment := convertPRComment(from.Comment)
+		label, removed, ok := labels.ParseLabelComment(comment.Body)
+		if !ok {
+			return nil
+		}
+		event := "labeled"
+		if removed {
+			event = "unlabeled"
+		}
+		return &scm.ListedIssueEvent{
+			Event:   event,
+			Actor:   comment.Author,
+			Label:   scm.Label{Name: label},
+			Created: comment.Created,
+		}
+	}
+	return nil
 }
 
 type prCommit struct {
@@ -320,6 +625,7 @@ type pullRequest struct {
 	Source       prSource      `json:"source"`
 	Destination  prDestination `json:"destination"`
 	Locked       bool          `json:"locked"`
+	Draft        bool          `json:"draft"`
 	Author       user          `json:"author"`
 	Reviewers    []user        `json:"reviewers"`
 	Participants []user        `json:"participants"`
@@ -366,6 +672,7 @@ func convertPullRequest(from *pullRequest) *scm.PullRequest {
 		DiffLink: from.Links.Diff.Href,
 		State:    strings.ToLower(from.State),
 		Closed:   closed,
+		Draft:    from.Draft,
 		Merged:   from.State == "MERGED",
 		Created:  from.CreatedDate,
 		Updated:  from.UpdatedDate,
@@ -425,3 +732,11 @@ func populateMergeableState(ctx context.Context, prsvc *pullService, from *pullR
 
 	return to
 }
+
+func convertActivityUser(from *user) scm.User {
+	return scm.User{
+		Login:  from.GetLogin(),
+		Name:   from.DisplayName,
+		Avatar: from.Links.Avatar.Href,
+	}
+}
@@ -85,14 +85,281 @@ func TestPullMerge(t *testing.T) {
 	t.Skip()
 }
 
+func TestPullEnableAutoMerge(t *testing.T) {
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.PullRequests.EnableAutoMerge(context.Background(), "atlassian/atlaskit", 1, nil)
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
+
+func TestPullDisableAutoMerge(t *testing.T) {
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.PullRequests.DisableAutoMerge(context.Background(), "atlassian/atlaskit", 1)
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
+
+func TestPullUpdateBranch(t *testing.T) {
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.PullRequests.UpdateBranch(context.Background(), "atlassian/atlaskit", 1, "merge")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
+
+func TestPullMarkReady(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Put("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		JSON(map[string]bool{"draft": false}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_create.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.PullRequests.MarkReady(context.Background(), "atlassian/atlaskit", 1)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPullConvertToDraft(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Put("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		JSON(map[string]bool{"draft": true}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_create.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.PullRequests.ConvertToDraft(context.Background(), "atlassian/atlaskit", 1)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestPullClose(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/atlaskit/pullrequests/1/decline").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_create.json")
+
 	client, _ := New("https://api.bitbucket.org")
 	_, err := client.PullRequests.Close(context.Background(), "atlassian/atlaskit", 1)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the pull request to be declined")
+	}
+}
+
+func TestPullReopen(t *testing.T) {
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.PullRequests.Reopen(context.Background(), "atlassian/atlaskit", 1)
 	if err != scm.ErrNotSupported {
 		t.Errorf("Expect Not Supported error")
 	}
 }
 
+func TestPullUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Put("/2.0/repositories/octocat/hello-world/pullrequests/2").
+		JSON(map[string]interface{}{
+			"title":       "Amazing new feature",
+			"description": "Please pull these awesome changes in!",
+			"destination": map[string]interface{}{
+				"branch": map[string]string{"name": "master"},
+			},
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_create.json")
+
+	input := &scm.PullRequestInput{
+		Title: "Amazing new feature",
+		Body:  "Please pull these awesome changes in!",
+		Base:  "master",
+	}
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.PullRequests.Update(context.Background(), "octocat/hello-world", 2, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.PullRequest)
+	raw, _ := ioutil.ReadFile("testdata/pr_create.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullUpdate_KeepTitle(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_reviewers.json")
+
+	gock.New("https://api.bitbucket.org").
+		Put("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		JSON(map[string]interface{}{
+			"title":       "Amazing new feature",
+			"description": "Please pull these awesome changes in!",
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_create.json")
+
+	input := &scm.PullRequestInput{
+		Body: "Please pull these awesome changes in!",
+	}
+
+	client, _ := New("https://api.bitbucket.org")
+	_, _, err := client.PullRequests.Update(context.Background(), "atlassian/atlaskit", 1, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the title of the pull request to be kept")
+	}
+}
+
+func TestPullUpdate_Error(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Put("/2.0/repositories/octocat/hello-world/pullrequests/2").
+		Reply(400).
+		Type("application/json").
+		File("testdata/error.json")
+
+	input := &scm.PullRequestInput{
+		Title: "Amazing new feature",
+	}
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.PullRequests.Update(context.Background(), "octocat/hello-world", 2, input)
+	if err == nil {
+		t.Fatalf("Expect an error")
+	}
+	if got != nil {
+		t.Errorf("Expect no pull request, got %+v", got)
+	}
+}
+
+func TestPullRequestReview(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_reviewers.json")
+
+	gock.New("https://api.bitbucket.org").
+		Put("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		JSON(map[string]interface{}{
+			"title": "Amazing new feature",
+			"reviewers": []map[string]string{
+				{"uuid": "{c9b8a8b5-ffbf-4e4a-ae15-8be8b5f8d1c1}", "account_id": "557058:a2e4b4c6-0f3e-4d7e-9b1a-2f3c4d5e6f70"},
+				{"account_id": "557058:c0b72ad0-1cb5-4018-9cdc-0cde8492c443"},
+			},
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_reviewers.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	logins := []string{"{c9b8a8b5-ffbf-4e4a-ae15-8be8b5f8d1c1}", "557058:c0b72ad0-1cb5-4018-9cdc-0cde8492c443"}
+	_, err := client.PullRequests.RequestReview(context.Background(), "atlassian/atlaskit", 1, logins)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the reviewers to be updated")
+	}
+}
+
+func TestPullUnrequestReview(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_reviewers.json")
+
+	gock.New("https://api.bitbucket.org").
+		Put("/2.0/repositories/atlassian/atlaskit/pullrequests/1").
+		JSON(map[string]interface{}{
+			"title":     "Amazing new feature",
+			"reviewers": []map[string]string{},
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_reviewers.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.PullRequests.UnrequestReview(context.Background(), "atlassian/atlaskit", 1, []string{"557058:a2e4b4c6-0f3e-4d7e-9b1a-2f3c4d5e6f70"})
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the reviewers to be updated")
+	}
+}
+
+func TestPullListEvents(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/1/activity").
+		MatchParam("page", "1").
+		MatchParam("pagelen", "30").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_activity.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, res, err := client.PullRequests.ListEvents(context.Background(), "atlassian/atlaskit", 1, scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.ListedIssueEvent{}
+	raw, _ := ioutil.ReadFile("testdata/pr_activity.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if got, want := res.Page.NextURL, "https://api.bitbucket.org/2.0/repositories/atlassian/atlaskit/pullrequests/1/activity?pagelen=30&ctx=X2NvbW1lbnRfMTE0Mjk1MDEy"; got != want {
+		t.Errorf("Want next url %q, got %q", want, got)
+	}
+	if res.Page.Next != 0 {
+		t.Errorf("Want no next page number, got %d", res.Page.Next)
+	}
+}
+
 func TestPullCreate(t *testing.T) {
 	defer gock.Off()
 
@@ -132,3 +399,113 @@ func TestPullCreate(t *testing.T) {
 		}
 	}
 }
+
+func TestPullListCommits(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/commits").
+		MatchParam("page", "1").
+		MatchParam("pagelen", "30").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_commits.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, res, err := client.PullRequests.ListCommits(context.Background(), "atlassian/stash-example-plugin", 1, scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*scm.Commit{}
+	raw, _ := ioutil.ReadFile("testdata/pr_commits.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Page", testPage(res))
+}
+
+func TestPullCreate_Extended(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Post("2.0/repositories/octocat/hello-world/pullrequests").
+		JSON(map[string]interface{}{
+			"title":       "Amazing new feature",
+			"description": "Please pull these awesome changes in!",
+			"source": map[string]interface{}{
+				"branch":     map[string]string{"name": "new-feature"},
+				"repository": map[string]string{"full_name": "jcitizen/hello-world"},
+			},
+			"destination": map[string]interface{}{
+				"branch": map[string]string{"name": "master"},
+			},
+			"reviewers": []map[string]string{
+				{"uuid": "{c9b8a8b5-ffbf-4e4a-ae15-8be8b5f8d1c1}"},
+				{"account_id": "557058:c0b72ad0-1cb5-4018-9cdc-0cde8492c443"},
+			},
+			"draft":   true,
+			"Project": "",
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_create.json")
+
+	input := &scm.PullRequestInput{
+		Title:     "Amazing new feature",
+		Body:      "Please pull these awesome changes in!",
+		Head:      "new-feature",
+		HeadRepo:  "jcitizen/hello-world",
+		Base:      "master",
+		Draft:     true,
+		Reviewers: []string{"{c9b8a8b5-ffbf-4e4a-ae15-8be8b5f8d1c1}", "557058:c0b72ad0-1cb5-4018-9cdc-0cde8492c443"},
+	}
+
+	client, _ := New("https://api.bitbucket.org")
+	if _, _, err := client.PullRequests.Create(context.Background(), "octocat/hello-world", input); err != nil {
+		t.Fatal(err)
+	}
+
+	input.Assignees = []string{"jcitizen"}
+	if _, _, err := client.PullRequests.Create(context.Background(), "octocat/hello-world", input); err != scm.ErrNotSupported {
+		t.Errorf("Want ErrNotSupported creating a pull request with assignees, got %v", err)
+	}
+}
+
+func TestPullCreate_Labels(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Post("2.0/repositories/octocat/hello-world/pullrequests").
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_create.json")
+
+	gock.New("https://api.bitbucket.org").
+		Post("2.0/repositories/octocat/hello-world/pullrequests/2/comments").
+		BodyString(`/jx-label bug`).
+		Reply(500)
+
+	input := &scm.PullRequestInput{
+		Title:  "Amazing new feature",
+		Head:   "new-feature",
+		Base:   "master",
+		Labels: []string{"bug"},
+	}
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.PullRequests.Create(context.Background(), "octocat/hello-world", input)
+	if err == nil {
+		t.Errorf("Want the error adding the label")
+	}
+	if got == nil || got.Number != 2 {
+		t.Errorf("Want the created pull request along with the error, got %+v", got)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the label to be added as a comment")
+	}
+}
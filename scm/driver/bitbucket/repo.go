@@ -172,7 +172,25 @@ func (s *repositoryService) FindCombinedStatus(ctx context.Context, repo, ref st
 }
 
 func (s *repositoryService) FindUserPermission(ctx context.Context, repo string, user string) (string, *scm.Response, error) {
-	return "", nil, scm.ErrNotSupported
+	wsname, reponame := scm.Split(repo)
+	path := fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories/%s?q=user.account_id=\"%s\"", wsname, reponame, user)
+	out := new(participants)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return scm.NoPermission, res, wrapError(res, err)
+	}
+	if len(out.Values) == 0 {
+		return scm.NoPermission, res, nil
+	}
+	switch out.Values[0].Permission {
+	case "admin":
+		return scm.AdminPermission, res, nil
+	case "write":
+		return scm.WritePermission, res, nil
+	case "read":
+		return scm.ReadPermission, res, nil
+	}
+	return scm.NoPermission, res, nil
 }
 
 func (s *repositoryService) AddCollaborator(ctx context.Context, repo, user, permission string) (bool, bool, *scm.Response, error) {
@@ -267,8 +285,19 @@ func (s *repositoryService) List(ctx context.Context, opts scm.ListOptions) ([]*
 	return convertRepositoryList(out), res, wrapError(res, err)
 }
 
-func (s *repositoryService) ListOrganisation(context.Context, string, scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+// ListOrganisation returns the repositories of the workspace.
+func (s *repositoryService) ListOrganisation(ctx context.Context, org string, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s?%s", org, encodeListOptions(opts))
+	if opts.URL != "" {
+		path = opts.URL
+	}
+	out := new(repositories)
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	if err != nil {
+		return nil, res, wrapError(res, err)
+	}
+	err = copyPagination(out.pagination, res)
+	return convertRepositoryList(out), res, wrapError(res, err)
 }
 
 func (s *repositoryService) ListUser(context.Context, string, scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
@@ -473,6 +502,8 @@ type status struct {
 			Href string `json:"href,omitempty"`
 		} `json:"commit,omitempty"`
 	} `json:"links,omitempty"`
+	CreatedOn *time.Time `json:"created_on,omitempty"`
+	UpdatedOn *time.Time `json:"updated_on,omitempty"`
 }
 
 func convertStatusList(from *statuses) []*scm.Status {
@@ -488,13 +519,20 @@ func convertStatus(from *status) *scm.Status {
 	if from.Links != nil {
 		link = from.Links.Commit.Href
 	}
-	return &scm.Status{
+	to := &scm.Status{
 		State:  convertState(from.State),
 		Label:  from.Key,
 		Desc:   from.Desc,
 		Target: from.URL,
 		Link:   link,
 	}
+	if from.CreatedOn != nil {
+		to.Created = *from.CreatedOn
+	}
+	if from.UpdatedOn != nil {
+		to.Updated = *from.UpdatedOn
+	}
+	return to
 }
 
 func convertState(from string) scm.State {
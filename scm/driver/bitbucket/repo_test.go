@@ -138,6 +138,54 @@ func TestRepositoryList(t *testing.T) {
 	}
 }
 
+func TestRepositoryListOrganisation(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian").
+		MatchParam("pagelen", "30").
+		Reply(200).
+		Type("application/json").
+		File("testdata/repos.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Repositories.ListOrganisation(context.Background(), "atlassian", scm.ListOptions{Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Repository{}
+	raw, _ := ioutil.ReadFile("testdata/org_repos.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepositoryFindUserPermission(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/workspaces/atlassian/permissions/repositories/atlaskit").
+		MatchParam("q", `user.account_id="557058:5d1e6c2f-0a3b-4c7d-9e8f-1a2b3c4d5e6f"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/repo_permission.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Repositories.FindUserPermission(context.Background(), "atlassian/atlaskit", "557058:5d1e6c2f-0a3b-4c7d-9e8f-1a2b3c4d5e6f")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if want := scm.WritePermission; got != want {
+		t.Errorf("Want permission %q, got %q", want, got)
+	}
+}
+
 func TestStatusList(t *testing.T) {
 	defer gock.Off()
 
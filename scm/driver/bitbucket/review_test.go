@@ -38,3 +38,24 @@ func TestReviewDelete(t *testing.T) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
+
+func TestReviewCreateComment(t *testing.T) {
+	_, _, err := NewDefault().Reviews.CreateComment(context.Background(), "", 0, &scm.ReviewCommentInput{})
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
+
+func TestReviewListThreads(t *testing.T) {
+	_, _, err := NewDefault().Reviews.ListThreads(context.Background(), "", 0, scm.ListOptions{})
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
+
+func TestReviewResolveThread(t *testing.T) {
+	_, err := NewDefault().Reviews.ResolveThread(context.Background(), "", 0, "")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
@@ -49,6 +49,7 @@ func (s *userService) AcceptInvitation(context.Context, int64) (*scm.Response, e
 }
 
 type user struct {
+	UUID         string `json:"uuid"`
 	AccountID    string `json:"account_id"`
 	Login        string `json:"username"`
 	Name         string `json:"nickname"`
@@ -5,9 +5,11 @@
 package bitbucket
 
 import (
+	"fmt"
 	"net/url"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -65,6 +67,9 @@ func encodeCommitListOptions(opts scm.CommitListOptions) string {
 	if opts.Size != 0 {
 		params.Set("pagelen", strconv.Itoa(opts.Size))
 	}
+	if opts.Path != "" {
+		params.Set("path", opts.Path)
+	}
 	return params.Encode()
 }
 
@@ -81,6 +86,22 @@ func encodeIssueListOptions(opts scm.IssueListOptions) string {
 	} else if opts.Closed {
 		params.Set("state", "closed")
 	}
+	// the issue tracker filters by state with a query
+	if opts.Open != opts.Closed {
+		var terms []string
+		for _, state := range closedStates {
+			if opts.Open {
+				terms = append(terms, fmt.Sprintf("state != %q", state))
+			} else {
+				terms = append(terms, fmt.Sprintf("state = %q", state))
+			}
+		}
+		if opts.Open {
+			params.Set("q", strings.Join(terms, " AND "))
+		} else {
+			params.Set("q", strings.Join(terms, " OR "))
+		}
+	}
 	return params.Encode()
 }
 
@@ -101,6 +122,31 @@ func encodePullRequestListOptions(opts scm.PullRequestListOptions) string {
 	} else if opts.Closed {
 		params.Set("state", "closed")
 	}
+	var query []string
+	if opts.Head != "" {
+		head := opts.Head
+		if i := strings.Index(head, ":"); i >= 0 {
+			query = append(query, fmt.Sprintf("source.repository.owner.username=%q", head[:i]))
+			head = head[i+1:]
+		}
+		query = append(query, fmt.Sprintf("source.branch.name=%q", head))
+	}
+	if opts.Base != "" {
+		query = append(query, fmt.Sprintf("destination.branch.name=%q", opts.Base))
+	}
+	if opts.Author != "" {
+		query = append(query, fmt.Sprintf("author.username=%q", opts.Author))
+	}
+	if len(query) > 0 {
+		params.Set("q", strings.Join(query, " AND "))
+	}
+	if opts.Sort != "" {
+		sort := opts.Sort + "_on"
+		if opts.Direction == "desc" {
+			sort = "-" + sort
+		}
+		params.Set("sort", sort)
+	}
 	return params.Encode()
 }
 
@@ -5,6 +5,7 @@
 package bitbucket
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/jenkins-x/go-scm/scm"
@@ -35,6 +36,18 @@ func Test_encodeCommitListOptions(t *testing.T) {
 	}
 }
 
+func Test_encodeCommitListOptions_Path(t *testing.T) {
+	opts := scm.CommitListOptions{
+		Ref:  "master",
+		Path: "apps/frontend",
+	}
+	want := "path=apps%2Ffrontend"
+	got := encodeCommitListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded commit list options %q, got %q", want, got)
+	}
+}
+
 func Test_encodeIssueListOptions(t *testing.T) {
 	opts := scm.IssueListOptions{
 		Page:   10,
@@ -49,6 +62,15 @@ func Test_encodeIssueListOptions(t *testing.T) {
 	}
 }
 
+func Test_encodeIssueListOptions_State(t *testing.T) {
+	opts := scm.IssueListOptions{Open: true}
+	want := `state != "resolved" AND state != "invalid" AND state != "duplicate" AND state != "wontfix" AND state != "closed"`
+	params, _ := url.ParseQuery(encodeIssueListOptions(opts))
+	if got := params.Get("q"); got != want {
+		t.Errorf("Want issue query %q, got %q", want, got)
+	}
+}
+
 func Test_encodePullRequestListOptions(t *testing.T) {
 	t.Parallel()
 	opts := scm.PullRequestListOptions{
@@ -64,6 +86,26 @@ func Test_encodePullRequestListOptions(t *testing.T) {
 	}
 }
 
+func Test_encodePullRequestListOptions_Filters(t *testing.T) {
+	t.Parallel()
+	opts := scm.PullRequestListOptions{
+		Head:      "octocat:feature",
+		Base:      "master",
+		Author:    "jcitizen",
+		Sort:      "updated",
+		Direction: "desc",
+	}
+	want := url.Values{
+		"pagelen": {"50"},
+		"q":       {`source.repository.owner.username="octocat" AND source.branch.name="feature" AND destination.branch.name="master" AND author.username="jcitizen"`},
+		"sort":    {"-updated_on"},
+	}.Encode()
+	got := encodePullRequestListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded pr list options %q, got %q", want, got)
+	}
+}
+
 func Test_copyPagination(t *testing.T) {
 	tests := []struct {
 		from pagination
@@ -0,0 +1,62 @@
+package coding
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// unsupported lists the features of the services of the
+// client which return scm.ErrNotSupported.
+var unsupported = []scm.Feature{
+	"Contents.Blame",
+	"Contents.Create",
+	"Contents.Delete",
+	"Contents.List",
+	"Contents.Update",
+	"Git.Compare",
+	"Git.CompareCommits",
+	"Git.CreateRef",
+	"Git.DeleteRef",
+	"Git.FindTag",
+	"Git.ListChanges",
+	"Git.ListTags",
+	"Organizations.AcceptOrganizationInvitation",
+	"Organizations.Create",
+	"Organizations.Delete",
+	"Organizations.IsAdmin",
+	"Organizations.IsMember",
+	"Organizations.ListMemberships",
+	"Organizations.ListPendingInvitations",
+	"Organizations.ListTeamMembers",
+	"Organizations.ListTeams",
+	"PullRequests.ClearMilestone",
+	"PullRequests.ConvertToDraft",
+	"PullRequests.DisableAutoMerge",
+	"PullRequests.EnableAutoMerge",
+	"PullRequests.List",
+	"PullRequests.ListChanges",
+	"PullRequests.ListCommits",
+	"PullRequests.ListEvents",
+	"PullRequests.MarkReady",
+	"PullRequests.SetMilestone",
+	"PullRequests.UpdateBranch",
+	"Repositories.AddCollaborator",
+	"Repositories.CreateStatus",
+	"Repositories.Delete",
+	"Repositories.DeleteHook",
+	"Repositories.FindCombinedStatus",
+	"Repositories.FindHook",
+	"Repositories.FindPerms",
+	"Repositories.FindUserPermission",
+	"Repositories.Fork",
+	"Repositories.IsCollaborator",
+	"Repositories.List",
+	"Repositories.ListCollaborators",
+	"Repositories.ListLabels",
+	"Repositories.ListOrganisation",
+	"Repositories.ListStatus",
+	"Repositories.ListUser",
+	"Repositories.UpdateHook",
+	"Users.AcceptInvitation",
+	"Users.CreateToken",
+	"Users.DeleteToken",
+	"Users.FindLogin",
+	"Users.ListInvitations",
+}
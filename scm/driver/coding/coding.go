@@ -14,7 +14,10 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/jenkins-x/go-scm/scm"
@@ -37,12 +40,17 @@ type apiResponse struct {
 type wrapper struct {
 	*scm.Client
 	token string
+
+	// depots caches the depot ids of the repositories
+	// as resolving one takes two requests.
+	mu     sync.Mutex
+	depots map[string]int
 }
 
 // NewWebHookService creates a new instance of the webhook service without the rest of the client
-// func NewWebHookService() scm.WebhookService {
-// 	return &webhookService{nil}
-// }
+func NewWebHookService() scm.WebhookService {
+	return &webhookService{nil}
+}
 
 // New returns a new Gitea API client without a token set
 func New(uri string) (*scm.Client, error) {
@@ -84,8 +92,8 @@ func NewWithToken(uri string, token string) (*scm.Client, error) {
 	client.BaseURL = base
 	// initialize services
 	client.Driver = scm.DriverCoding
-	// client.Contents = &contentService{client}
-	// client.Git = &gitService{client}
+	client.Contents = &contentService{client}
+	client.Git = &gitService{client}
 	// client.Issues = &issueService{client}
 	// client.Milestones = &milestoneService{client}
 	client.Organizations = &organizationService{client}
@@ -95,6 +103,7 @@ func NewWithToken(uri string, token string) (*scm.Client, error) {
 	// client.Releases = &releaseService{client}
 	client.Users = &userService{client}
 	client.Webhooks = &webhookService{client}
+	client.Unsupported = unsupported
 	return client.Client, nil
 }
 
@@ -152,6 +161,42 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	return res, json.NewDecoder(res.Body).Decode(out)
 }
 
+// findDepotID returns the id of the depot of the repository which
+// the open api uses to address the repository. The id is cached
+// for the lifetime of the client.
+func (c *wrapper) findDepotID(ctx context.Context, repo string) (int, error) {
+	c.mu.Lock()
+	id, ok := c.depots[repo]
+	c.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+	repoInfo, _, err := c.Repositories.Find(ctx, repo)
+	if err != nil {
+		return 0, err
+	}
+	id, err = strconv.Atoi(repoInfo.ID)
+	if err != nil {
+		return 0, err
+	}
+	c.mu.Lock()
+	if c.depots == nil {
+		c.depots = map[string]int{}
+	}
+	c.depots[repo] = id
+	c.mu.Unlock()
+	return id, nil
+}
+
+// convertTimestamp converts the milliseconds since the epoch
+// which the open api uses for dates.
+func convertTimestamp(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
+
 // toSCMResponse creates a new Response for the provided
 // http.Response. r must not be nil.
 func toSCMResponse(r *gitea.Response) *scm.Response {
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
 )
 
 var mockHeaders = map[string]string{
@@ -25,48 +26,48 @@ var mockPageHeaders = map[string]string{
 }
 
 func TestClient(t *testing.T) {
-	client, err := New("https://api.github.com")
+	client, err := New("https://e.coding.net")
 	if err != nil {
 		t.Error(err)
 	}
-	if got, want := client.BaseURL.String(), "https://api.github.com/"; got != want {
+	if got, want := client.BaseURL.String(), "https://e.coding.net/open-api"; got != want {
 		t.Errorf("Want Client URL %q, got %q", want, got)
 	}
 }
 
 func TestGraphQLClient(t *testing.T) {
-	client, err := New("https://api.github.com")
+	client, err := New("https://e.coding.net")
 	if err != nil {
 		t.Error(err)
 	}
-	if got, want := client.GraphQLURL.String(), "https://api.github.com/graphql"; got != want {
-		t.Errorf("Want GraphQl Client URL %q, got %q", want, got)
+	if client.GraphQLURL != nil {
+		t.Errorf("Want no GraphQl Client URL, got %q", client.GraphQLURL)
 	}
 }
 
 func TestClient_Base(t *testing.T) {
-	client, err := New("https://github.example.com/api/v3")
+	client, err := New("https://coding.example.com/open-api")
 	if err != nil {
 		t.Error(err)
 	}
-	if got, want := client.BaseURL.String(), "https://github.example.com/api/v3/"; got != want {
+	if got, want := client.BaseURL.String(), "https://coding.example.com/open-api"; got != want {
 		t.Errorf("Want Client URL %q, got %q", want, got)
 	}
 }
 
 func TestEnterpriseGraphQLClient(t *testing.T) {
-	client, err := New("https://github.example.com/api/v3")
+	client, err := New("https://coding.example.com/open-api")
 	if err != nil {
 		t.Error(err)
 	}
-	if got, want := client.GraphQLURL.String(), "https://github.example.com/api/graphql"; got != want {
-		t.Errorf("Want GraphQl Client URL %q, got %q", want, got)
+	if client.GraphQLURL != nil {
+		t.Errorf("Want no GraphQl Client URL, got %q", client.GraphQLURL)
 	}
 }
 
 func TestClient_Default(t *testing.T) {
 	client := NewDefault()
-	if got, want := client.BaseURL.String(), "https://api.github.com/"; got != want {
+	if got, want := client.BaseURL.String(), "https://e.coding.net/open-api"; got != want {
 		t.Errorf("Want Client URL %q, got %q", want, got)
 	}
 }
@@ -116,3 +117,21 @@ func testRequest(res *scm.Response) func(t *testing.T) {
 		}
 	}
 }
+
+// mockDepot mocks the requests which resolve the demo/demo-repo
+// repository to its depot.
+func mockDepot() {
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		BodyString(`"Action":"DescribeProjectByName"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/project.json")
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		BodyString(`"Action":"DescribeProjectDepotInfoList"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/depots.json")
+}
@@ -0,0 +1,105 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package coding
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type contentService struct {
+	client *wrapper
+}
+
+type gitFile struct {
+	FileName string `json:"FileName"`
+	Path     string `json:"Path"`
+	Content  string `json:"Content"`
+	Encoding string `json:"Encoding"`
+	Sha      string `json:"Sha"`
+}
+
+type findFileRequest struct {
+	apiRequest
+	DepotId int    `json:"DepotId"`
+	Ref     string `json:"Ref"`
+	Path    string `json:"Path"`
+}
+
+type findFileResponse struct {
+	Response struct {
+		apiResponse
+		GitFile *gitFile `json:"GitFile"`
+	} `json:"Response"`
+}
+
+func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm.Content, *scm.Response, error) {
+	did, err := s.client.findDepotID(ctx, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	in := new(findFileRequest)
+	in.Action = "DescribeGitFile"
+	in.DepotId = did
+	in.Ref = ref
+	in.Path = path
+
+	out := new(findFileResponse)
+	res, err := s.client.do(ctx, "POST", "", in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if out.Response.Error != nil {
+		return nil, res, errors.New(out.Response.Error.Message)
+	}
+	if out.Response.GitFile == nil {
+		return nil, res, scm.ErrNotFound
+	}
+	content, err := convertContent(out.Response.GitFile, path)
+	return content, res, err
+}
+
+func (s *contentService) List(ctx context.Context, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *contentService) Create(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *contentService) Update(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *contentService) Delete(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *contentService) Blame(ctx context.Context, repo, path, ref string) ([]*scm.BlameRange, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func convertContent(from *gitFile, path string) (*scm.Content, error) {
+	data := []byte(from.Content)
+	if from.Encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(from.Content)
+		if err != nil {
+			return nil, err
+		}
+		data = decoded
+	}
+	if from.Path != "" {
+		path = from.Path
+	}
+	return &scm.Content{
+		Path: path,
+		Data: data,
+		Sha:  from.Sha,
+	}, nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package coding
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestContentFind(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		MatchType("json").
+		JSON(map[string]interface{}{"Action": "DescribeGitFile", "DepotId": 8001, "Ref": "master", "Path": "README.md"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/content.json")
+
+	client := NewDefaultWithToken("")
+	got, _, err := client.Contents.Find(context.Background(), "demo/demo-repo", "README.md", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Content)
+	raw, _ := ioutil.ReadFile("testdata/content.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if got, want := string(got.Data), "# Demo\n"; got != want {
+		t.Errorf("Want content %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package coding
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type gitService struct {
+	client *wrapper
+}
+
+type branchItem struct {
+	BranchName      string `json:"BranchName"`
+	IsDefaultBranch bool   `json:"IsDefaultBranch"`
+	IsProtected     bool   `json:"IsProtected"`
+	Sha             string `json:"Sha"`
+}
+
+type findBranchRequest struct {
+	apiRequest
+	DepotId    int    `json:"DepotId"`
+	BranchName string `json:"BranchName"`
+}
+
+type findBranchResponse struct {
+	Response struct {
+		apiResponse
+		GitBranch *branchItem `json:"GitBranch"`
+	} `json:"Response"`
+}
+
+type listBranchesRequest struct {
+	apiRequest
+	DepotId    int `json:"DepotId"`
+	PageNumber int `json:"PageNumber"`
+	PageSize   int `json:"PageSize"`
+}
+
+type listBranchesResponse struct {
+	Response struct {
+		apiResponse
+		Branches   []*branchItem `json:"Branches"`
+		TotalCount int           `json:"TotalCount"`
+	} `json:"Response"`
+}
+
+type commitItem struct {
+	Sha            string `json:"Sha"`
+	ShortMessage   string `json:"ShortMessage"`
+	FullMessage    string `json:"FullMessage"`
+	AuthorName     string `json:"AuthorName"`
+	AuthorEmail    string `json:"AuthorEmail"`
+	CommitDate     int64  `json:"CommitDate"`
+	CommitterName  string `json:"CommitterName"`
+	CommitterEmail string `json:"CommitterEmail"`
+}
+
+type findCommitRequest struct {
+	apiRequest
+	DepotId int    `json:"DepotId"`
+	Sha     string `json:"Sha"`
+}
+
+type findCommitResponse struct {
+	Response struct {
+		apiResponse
+		Commit *commitItem `json:"Commit"`
+	} `json:"Response"`
+}
+
+type listCommitsRequest struct {
+	apiRequest
+	DepotId    int    `json:"DepotId"`
+	Ref        string `json:"Ref"`
+	Path       string `json:"Path,omitempty"`
+	PageNumber int    `json:"PageNumber"`
+	PageSize   int    `json:"PageSize"`
+}
+
+type listCommitsResponse struct {
+	Response struct {
+		apiResponse
+		Commits []*commitItem `json:"Commits"`
+	} `json:"Response"`
+}
+
+func (s *gitService) FindBranch(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
+	did, err := s.client.findDepotID(ctx, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	in := new(findBranchRequest)
+	in.Action = "DescribeGitBranch"
+	in.DepotId = did
+	in.BranchName = scm.TrimRef(name)
+
+	out := new(findBranchResponse)
+	res, err := s.client.do(ctx, "POST", "", in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if out.Response.Error != nil {
+		return nil, res, errors.New(out.Response.Error.Message)
+	}
+	if out.Response.GitBranch == nil {
+		return nil, res, scm.ErrNotFound
+	}
+	return convertBranch(out.Response.GitBranch), res, nil
+}
+
+func (s *gitService) FindCommit(ctx context.Context, repo, ref string) (*scm.Commit, *scm.Response, error) {
+	did, err := s.client.findDepotID(ctx, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	in := new(findCommitRequest)
+	in.Action = "DescribeGitCommitInfo"
+	in.DepotId = did
+	in.Sha = ref
+
+	out := new(findCommitResponse)
+	res, err := s.client.do(ctx, "POST", "", in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if out.Response.Error != nil {
+		return nil, res, errors.New(out.Response.Error.Message)
+	}
+	if out.Response.Commit == nil {
+		return nil, res, scm.ErrNotFound
+	}
+	return convertCommit(out.Response.Commit), res, nil
+}
+
+func (s *gitService) FindTag(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gitService) ListBranches(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	did, err := s.client.findDepotID(ctx, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	in := new(listBranchesRequest)
+	in.Action = "DescribeGitBranches"
+	in.DepotId = did
+	in.PageNumber, in.PageSize = convertListOptions(opts)
+
+	out := new(listBranchesResponse)
+	res, err := s.client.do(ctx, "POST", "", in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if out.Response.Error != nil {
+		return nil, res, errors.New(out.Response.Error.Message)
+	}
+	if in.PageNumber*in.PageSize < out.Response.TotalCount {
+		res.Page.Next = in.PageNumber + 1
+	}
+	return convertBranchList(out.Response.Branches), res, nil
+}
+
+func (s *gitService) ListCommits(ctx context.Context, repo string, opts scm.CommitListOptions) ([]*scm.Commit, *scm.Response, error) {
+	did, err := s.client.findDepotID(ctx, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	in := new(listCommitsRequest)
+	in.Action = "DescribeGitCommits"
+	in.DepotId = did
+	in.Ref = opts.Sha
+	if in.Ref == "" {
+		in.Ref = opts.Ref
+	}
+	in.Path = opts.Path
+	in.PageNumber, in.PageSize = convertListOptions(scm.ListOptions{Page: opts.Page, Size: opts.Size})
+
+	out := new(listCommitsResponse)
+	res, err := s.client.do(ctx, "POST", "", in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if out.Response.Error != nil {
+		return nil, res, errors.New(out.Response.Error.Message)
+	}
+	return convertCommitList(out.Response.Commits), res, nil
+}
+
+func (s *gitService) ListChanges(ctx context.Context, repo, ref string, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, opts scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gitService) ListTags(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+// FindRef returns the sha of the branch as the open api only
+// resolves branches.
+func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *scm.Response, error) {
+	branch, res, err := s.FindBranch(ctx, repo, strings.TrimPrefix(ref, "heads/"))
+	if err != nil {
+		return "", res, err
+	}
+	return branch.Sha, res, nil
+}
+
+func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *gitService) CreateRef(ctx context.Context, repo, ref, sha string) (*scm.Reference, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+// convertListOptions returns the page number and size of the
+// options defaulting to the first page of 100 entries.
+func convertListOptions(opts scm.ListOptions) (int, int) {
+	page, size := opts.Page, opts.Size
+	if page == 0 {
+		page = 1
+	}
+	if size == 0 {
+		size = 100
+	}
+	return page, size
+}
+
+func convertBranchList(from []*branchItem) []*scm.Reference {
+	to := []*scm.Reference{}
+	for _, v := range from {
+		to = append(to, convertBranch(v))
+	}
+	return to
+}
+
+func convertBranch(from *branchItem) *scm.Reference {
+	return &scm.Reference{
+		Name: from.BranchName,
+		Path: scm.ExpandRef(from.BranchName, "refs/heads/"),
+		Sha:  from.Sha,
+	}
+}
+
+func convertCommitList(from []*commitItem) []*scm.Commit {
+	to := []*scm.Commit{}
+	for _, v := range from {
+		to = append(to, convertCommit(v))
+	}
+	return to
+}
+
+func convertCommit(from *commitItem) *scm.Commit {
+	message := from.FullMessage
+	if message == "" {
+		message = from.ShortMessage
+	}
+	return &scm.Commit{
+		Sha:     from.Sha,
+		Message: message,
+		Author: scm.Signature{
+			Name:  from.AuthorName,
+			Email: from.AuthorEmail,
+			Date:  convertTimestamp(from.CommitDate),
+		},
+		Committer: scm.Signature{
+			Name:  from.CommitterName,
+			Email: from.CommitterEmail,
+			Date:  convertTimestamp(from.CommitDate),
+		},
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package coding
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestGitFindBranch(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		MatchType("json").
+		JSON(map[string]interface{}{"Action": "DescribeGitBranch", "DepotId": 8001, "BranchName": "master"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/branch.json")
+
+	client := NewDefaultWithToken("")
+	got, _, err := client.Git.FindBranch(context.Background(), "demo/demo-repo", "refs/heads/master")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Reference)
+	raw, _ := ioutil.ReadFile("testdata/branch.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitFindRef(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		BodyString(`"Action":"DescribeGitBranch"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/branch.json")
+
+	client := NewDefaultWithToken("")
+	got, _, err := client.Git.FindRef(context.Background(), "demo/demo-repo", "heads/master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "6dcb09b5b57875f334f61aebed695e2e4193db5e"; got != want {
+		t.Errorf("Want sha %q, got %q", want, got)
+	}
+}
+
+func TestGitListBranches(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		MatchType("json").
+		JSON(map[string]interface{}{"Action": "DescribeGitBranches", "DepotId": 8001, "PageNumber": 1, "PageSize": 1}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/branches.json")
+
+	client := NewDefaultWithToken("")
+	got, res, err := client.Git.ListBranches(context.Background(), "demo/demo-repo", scm.ListOptions{Size: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Reference{}
+	raw, _ := ioutil.ReadFile("testdata/branches.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if got, want := res.Page.Next, 2; got != want {
+		t.Errorf("Want next page %d, got %d", want, got)
+	}
+}
+
+func TestGitFindCommit(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		MatchType("json").
+		JSON(map[string]interface{}{"Action": "DescribeGitCommitInfo", "DepotId": 8001, "Sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/commit.json")
+
+	client := NewDefaultWithToken("")
+	got, _, err := client.Git.FindCommit(context.Background(), "demo/demo-repo", "6dcb09b5b57875f334f61aebed695e2e4193db5e")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Commit)
+	raw, _ := ioutil.ReadFile("testdata/commit.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitListCommits(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		MatchType("json").
+		JSON(map[string]interface{}{"Action": "DescribeGitCommits", "DepotId": 8001, "Ref": "master", "Path": "README.md", "PageNumber": 1, "PageSize": 100}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/commits.json")
+
+	client := NewDefaultWithToken("")
+	got, _, err := client.Git.ListCommits(context.Background(), "demo/demo-repo", scm.CommitListOptions{Ref: "master", Path: "README.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Commit{}
+	raw, _ := ioutil.ReadFile("testdata/commits.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitFindBranch_CachedDepot(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		BodyString(`"Action":"DescribeGitBranch"`).
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		File("testdata/branch.json")
+
+	client := NewDefaultWithToken("")
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Git.FindBranch(context.Background(), "demo/demo-repo", "master"); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the depot to be resolved once")
+	}
+}
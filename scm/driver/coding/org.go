@@ -116,7 +116,9 @@ func (s *organizationService) Find(ctx context.Context, name string) (*scm.Organ
 
 	out := new(findProjectResponse)
 	res, err := s.client.do(ctx, "POST", "", &body, out)
-
+	if err != nil {
+		return nil, res, err
+	}
 	if out.Response.Error != nil {
 		return nil, res, errors.New(out.Response.Error.Message)
 	}
@@ -127,7 +129,7 @@ func (s *organizationService) Find(ctx context.Context, name string) (*scm.Organ
 func (s *organizationService) List(ctx context.Context, opts scm.ListOptions) ([]*scm.Organization, *scm.Response, error) {
 	user, res, err := s.client.Users.Find(ctx)
 	if err != nil {
-		return nil, nil, err
+		return nil, res, err
 	}
 	body := listProjectsRequest{
 		apiRequest: apiRequest{
@@ -138,7 +140,9 @@ func (s *organizationService) List(ctx context.Context, opts scm.ListOptions) ([
 
 	out := new(projectListResponse)
 	res, err = s.client.do(ctx, "POST", "", &body, out)
-
+	if err != nil {
+		return nil, res, err
+	}
 	if out.Response.Error != nil {
 		return nil, res, errors.New(out.Response.Error.Message)
 	}
@@ -163,7 +167,9 @@ func (s *organizationService) ListOrgMembers(ctx context.Context, org string, op
 
 	out := new(listProjectMemberResponse)
 	res, err := s.client.do(ctx, "POST", "", &body, out)
-
+	if err != nil {
+		return nil, res, err
+	}
 	if out.Response.Error != nil {
 		return nil, res, errors.New(out.Response.Error.Message)
 	}
@@ -11,34 +11,63 @@ import (
 	"gopkg.in/h2non/gock.v1"
 )
 
-func TestOrgList(t *testing.T) {
+func TestOrgFind(t *testing.T) {
 	defer gock.Off()
 
-	// gock.New("https://e.coding.net/open-api").
-	// 	Post("").
-	// 	Reply(200).
-	// 	Type("application/json").
-	// 	File("testdata/user.json")
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		JSON(map[string]interface{}{"Action": "DescribeProjectByName", "ProjectName": "demo"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/project.json")
 
 	client := NewDefaultWithToken("")
-	got, res, err := client.Organizations.List(context.Background(), scm.ListOptions{})
+	got, _, err := client.Organizations.Find(context.Background(), "demo")
 	if err != nil {
 		t.Error(err)
 		return
 	}
 
-	org, res, err := client.Organizations.Find(context.Background(), "tw-test")
+	want := new(scm.Organization)
+	raw, _ := ioutil.ReadFile("testdata/project.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestOrgList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		BodyString(`"Action":"DescribeCodingCurrentUser"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
 
-	t.Log(org)
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		JSON(map[string]interface{}{"Action": "DescribeUserProjects", "userId": 150258}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/projects.json")
 
-	want := new(scm.User)
-	raw, _ := ioutil.ReadFile("testdata/user.json.golden")
+	client := NewDefaultWithToken("")
+	got, _, err := client.Organizations.List(context.Background(), scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Organization{}
+	raw, _ := ioutil.ReadFile("testdata/projects.json.golden")
 	json.Unmarshal(raw, &want)
 
 	if diff := cmp.Diff(got, want); diff != "" {
 		t.Errorf("Unexpected Results")
 		t.Log(diff)
 	}
-
-	t.Run("Request", testRequest(res))
 }
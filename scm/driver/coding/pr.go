@@ -79,6 +79,50 @@ type closeMergeRequest struct {
 	MergeId int `json:"MergeId"`
 }
 
+type modifyMergeRequest struct {
+	apiRequest
+	DepotId int    `json:"DepotId"`
+	MergeId int    `json:"MergeId"`
+	Title   string `json:"Title,omitempty"`
+	Content string `json:"Content,omitempty"`
+}
+
+type mergeRequestNote struct {
+	Id        int    `json:"Id"`
+	Content   string `json:"Content"`
+	Author    user   `json:"Author"`
+	CreatedAt int64  `json:"CreatedAt"`
+	UpdatedAt int64  `json:"UpdatedAt"`
+}
+
+type listMergeRequestNotesRequest struct {
+	apiRequest
+	DepotId int `json:"DepotId"`
+	MergeId int `json:"MergeId"`
+}
+
+type listMergeRequestNotesResponse struct {
+	Response struct {
+		apiResponse
+		Notes []*mergeRequestNote `json:"Notes"`
+	} `json:"Response"`
+}
+
+type mergeRequestNoteRequest struct {
+	apiRequest
+	DepotId int    `json:"DepotId"`
+	MergeId int    `json:"MergeId"`
+	NoteId  int    `json:"NoteId,omitempty"`
+	Content string `json:"Content,omitempty"`
+}
+
+type mergeRequestNoteResponse struct {
+	Response struct {
+		apiResponse
+		Note *mergeRequestNote `json:"Note"`
+	} `json:"Response"`
+}
+
 func (s *pullService) Find(ctx context.Context, repo string, number int) (*scm.PullRequest, *scm.Response, error) {
 	repoInfo, _, err := s.client.Repositories.Find(ctx, repo)
 	if err != nil {
@@ -104,15 +148,37 @@ func (s *pullService) Find(ctx context.Context, repo string, number int) (*scm.P
 		return nil, nil, errors.New(out.Response.Error.Message)
 	}
 
-	return nil, res, err
+	if out.Response.MergeRequestInfo == nil {
+		return nil, res, scm.ErrNotFound
+	}
+
+	pr, _, err := s.convertPullRequest(ctx, out.Response.MergeRequestInfo)
+	if err != nil {
+		return nil, res, err
+	}
+	pr.Number = number
+	return pr, res, nil
+}
+
+func (s *pullService) FindMergeability(ctx context.Context, repo string, number int) (*scm.Mergeability, *scm.Response, error) {
+	pr, res, err := s.Find(ctx, repo, number)
+	if err != nil {
+		return nil, res, err
+	}
+	return scm.FindMergeability(ctx, s.client.Client, repo, pr, nil)
 }
 
 func (s *pullService) FindComment(ctx context.Context, repo string, index, id int) (*scm.Comment, *scm.Response, error) {
-	// path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/notes/%d", encode(repo), index, id)
-	// out := new(issueComment)
-	// res, err := s.client.do(ctx, "GET", path, nil, out)
-	// return convertIssueComment(out), res, err
-	return nil, nil, scm.ErrNotSupported
+	comments, res, err := s.ListComments(ctx, repo, index, scm.ListOptions{})
+	if err != nil {
+		return nil, res, err
+	}
+	for _, comment := range comments {
+		if comment.ID == id {
+			return comment, res, nil
+		}
+	}
+	return nil, res, scm.ErrNotFound
 }
 
 func (s *pullService) List(ctx context.Context, repo string, opts scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
@@ -138,10 +204,32 @@ func (s *pullService) ListChanges(ctx context.Context, repo string, number int,
 	return nil, nil, scm.ErrNotSupported
 }
 
-func (s *pullService) ListComments(ctx context.Context, repo string, index int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
+func (s *pullService) ListCommits(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Commit, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
 
+func (s *pullService) ListComments(ctx context.Context, repo string, index int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
+	did, err := s.client.findDepotID(ctx, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	in := new(listMergeRequestNotesRequest)
+	in.Action = "DescribeMergeRequestNotes"
+	in.DepotId = did
+	in.MergeId = index
+
+	out := new(listMergeRequestNotesResponse)
+	res, err := s.client.do(ctx, "POST", "", in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if out.Response.Error != nil {
+		return nil, res, errors.New(out.Response.Error.Message)
+	}
+	return convertCommentList(out.Response.Notes), res, nil
+}
+
 func (s *pullService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
 	// mr, _, err := s.Find(ctx, repo, number)
 	// if err != nil {
@@ -178,15 +266,52 @@ func (s *pullService) DeleteLabel(ctx context.Context, repo string, number int,
 // }
 
 func (s *pullService) CreateComment(ctx context.Context, repo string, index int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	in := new(mergeRequestNoteRequest)
+	in.Action = "CreateMergeRequestNote"
+	in.MergeId = index
+	in.Content = input.Body
+	return s.modifyNote(ctx, repo, in)
 }
 
 func (s *pullService) DeleteComment(ctx context.Context, repo string, index, id int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	in := new(mergeRequestNoteRequest)
+	in.Action = "DeleteMergeRequestNote"
+	in.MergeId = index
+	in.NoteId = id
+	_, res, err := s.modifyNote(ctx, repo, in)
+	return res, err
 }
 
 func (s *pullService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	in := new(mergeRequestNoteRequest)
+	in.Action = "ModifyMergeRequestNote"
+	in.MergeId = number
+	in.NoteId = id
+	in.Content = input.Body
+	return s.modifyNote(ctx, repo, in)
+}
+
+// modifyNote performs the note action of the request against the
+// depot of the repository and returns the resulting note, if any.
+func (s *pullService) modifyNote(ctx context.Context, repo string, in *mergeRequestNoteRequest) (*scm.Comment, *scm.Response, error) {
+	did, err := s.client.findDepotID(ctx, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	in.DepotId = did
+
+	out := new(mergeRequestNoteResponse)
+	res, err := s.client.do(ctx, "POST", "", in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if out.Response.Error != nil {
+		return nil, res, errors.New(out.Response.Error.Message)
+	}
+	if out.Response.Note == nil {
+		return nil, res, nil
+	}
+	return convertComment(out.Response.Note), res, nil
 }
 
 func (s *pullService) Merge(ctx context.Context, repo string, number int, options *scm.PullRequestMergeOptions) (*scm.Response, error) {
@@ -223,6 +348,26 @@ func (s *pullService) Merge(ctx context.Context, repo string, number int, option
 	return res, err
 }
 
+func (s *pullService) EnableAutoMerge(ctx context.Context, repo string, number int, options *scm.PullRequestMergeOptions) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) DisableAutoMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) UpdateBranch(ctx context.Context, repo string, number int, method string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) MarkReady(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) ConvertToDraft(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
 func (s *pullService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
 
 	repoInfo, _, err := s.client.Repositories.Find(ctx, repo)
@@ -255,7 +400,25 @@ func (s *pullService) Close(ctx context.Context, repo string, number int) (*scm.
 }
 
 func (s *pullService) Reopen(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	did, err := s.client.findDepotID(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	in := new(closeMergeRequest)
+	in.Action = "ModifyReopenMR"
+	in.DepotId = did
+	in.MergeId = number
+
+	out := new(mergeResponse)
+	res, err := s.client.do(ctx, "POST", "", in, out)
+	if err != nil {
+		return res, err
+	}
+	if out.Response.Error != nil {
+		return res, errors.New(out.Response.Error.Message)
+	}
+	return res, nil
 }
 
 func (s *pullService) AssignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
@@ -328,6 +491,10 @@ func (s *pullService) UnrequestReview(ctx context.Context, repo string, number i
 }
 
 func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
+	// the merge request api cannot set labels, assignees, milestones or reviewers
+	if len(input.Labels) > 0 || len(input.Assignees) > 0 || input.Milestone != 0 || len(input.Reviewers) > 0 {
+		return nil, nil, scm.ErrNotSupported
+	}
 
 	rep, _, err := s.client.Repositories.Find(ctx, repo)
 	if err != nil {
@@ -365,7 +532,27 @@ func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRe
 }
 
 func (s *pullService) Update(ctx context.Context, repo string, number int, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	did, err := s.client.findDepotID(ctx, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	in := new(modifyMergeRequest)
+	in.Action = "ModifyMergeRequest"
+	in.DepotId = did
+	in.MergeId = number
+	in.Title = input.Title
+	in.Content = input.Body
+
+	out := new(mergeResponse)
+	res, err := s.client.do(ctx, "POST", "", in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if out.Response.Error != nil {
+		return nil, res, errors.New(out.Response.Error.Message)
+	}
+	return s.Find(ctx, repo, number)
 }
 
 func (s *pullService) SetMilestone(ctx context.Context, repo string, prID int, number int) (*scm.Response, error) {
@@ -558,6 +745,24 @@ func (s *pullService) convertPullRequest(ctx context.Context, from *mergeRequest
 // 	return to
 // }
 
+func convertCommentList(from []*mergeRequestNote) []*scm.Comment {
+	to := []*scm.Comment{}
+	for _, v := range from {
+		to = append(to, convertComment(v))
+	}
+	return to
+}
+
+func convertComment(from *mergeRequestNote) *scm.Comment {
+	return &scm.Comment{
+		ID:      from.Id,
+		Body:    from.Content,
+		Author:  *convertUser(&from.Author),
+		Created: convertTimestamp(from.CreatedAt),
+		Updated: convertTimestamp(from.UpdatedAt),
+	}
+}
+
 func codingStateToSCMState(glState string) string {
 
 	// 	CANMERGE	状态可自动合并
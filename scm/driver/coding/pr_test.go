@@ -3,3 +3,270 @@
 // license that can be found in the LICENSE file.
 
 package coding
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestPullRequestFind(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		MatchType("json").
+		JSON(map[string]interface{}{"Action": "DescribeMergeRequest", "DepotId": 8001, "MergeId": 1}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	client := NewDefaultWithToken("")
+	got, res, err := client.PullRequests.Find(context.Background(), "demo/demo-repo", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.PullRequest)
+	raw, _ := ioutil.ReadFile("testdata/pr.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if res == nil {
+		t.Errorf("Expect a response")
+	}
+}
+
+func TestPullRequestUpdate(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		MatchType("json").
+		JSON(map[string]interface{}{"Action": "ModifyMergeRequest", "DepotId": 8001, "MergeId": 1, "Title": "Add the contributing guide", "Content": "Explains how to open a merge request."}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/success.json")
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		BodyString(`"Action":"DescribeMergeRequest"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	input := &scm.PullRequestInput{
+		Title: "Add the contributing guide",
+		Body:  "Explains how to open a merge request.",
+	}
+
+	client := NewDefaultWithToken("")
+	got, _, err := client.PullRequests.Update(context.Background(), "demo/demo-repo", 1, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.PullRequest)
+	raw, _ := ioutil.ReadFile("testdata/pr.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullRequestReopen(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		MatchType("json").
+		JSON(map[string]interface{}{"Action": "ModifyReopenMR", "DepotId": 8001, "MergeId": 1}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/success.json")
+
+	client := NewDefaultWithToken("")
+	_, err := client.PullRequests.Reopen(context.Background(), "demo/demo-repo", 1)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestPullRequestCommentList(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		MatchType("json").
+		JSON(map[string]interface{}{"Action": "DescribeMergeRequestNotes", "DepotId": 8001, "MergeId": 1}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/comments.json")
+
+	client := NewDefaultWithToken("")
+	got, _, err := client.PullRequests.ListComments(context.Background(), "demo/demo-repo", 1, scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Comment{}
+	raw, _ := ioutil.ReadFile("testdata/comments.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullRequestCommentFind(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		BodyString(`"Action":"DescribeMergeRequestNotes"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/comments.json")
+
+	client := NewDefaultWithToken("")
+	got, _, err := client.PullRequests.FindComment(context.Background(), "demo/demo-repo", 1, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Comment)
+	raw, _ := ioutil.ReadFile("testdata/comment.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullRequestCommentCreate(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		MatchType("json").
+		JSON(map[string]interface{}{"Action": "CreateMergeRequestNote", "DepotId": 8001, "MergeId": 1, "Content": "Looks good to me"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/comment.json")
+
+	client := NewDefaultWithToken("")
+	got, _, err := client.PullRequests.CreateComment(context.Background(), "demo/demo-repo", 1, &scm.CommentInput{Body: "Looks good to me"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Comment)
+	raw, _ := ioutil.ReadFile("testdata/comment.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullRequestCommentEdit(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		MatchType("json").
+		JSON(map[string]interface{}{"Action": "ModifyMergeRequestNote", "DepotId": 8001, "MergeId": 1, "NoteId": 42, "Content": "Looks good to me"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/comment.json")
+
+	client := NewDefaultWithToken("")
+	got, _, err := client.PullRequests.EditComment(context.Background(), "demo/demo-repo", 1, 42, &scm.CommentInput{Body: "Looks good to me"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Comment)
+	raw, _ := ioutil.ReadFile("testdata/comment.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullRequestCommentDelete(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		MatchType("json").
+		JSON(map[string]interface{}{"Action": "DeleteMergeRequestNote", "DepotId": 8001, "MergeId": 1, "NoteId": 42}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/success.json")
+
+	client := NewDefaultWithToken("")
+	_, err := client.PullRequests.DeleteComment(context.Background(), "demo/demo-repo", 1, 42)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestPullRequestError(t *testing.T) {
+	defer gock.Off()
+
+	mockDepot()
+
+	gock.New("https://e.coding.net/open-api").
+		Post("").
+		BodyString(`"Action":"ModifyReopenMR"`).
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"Response":{"RequestId":"367bdaa8-e4c7-a070-ecf6-00fea8e6fab9","Error":{"Code":"InvalidParameter","Message":"merge request is merged"}}}`)
+
+	client := NewDefaultWithToken("")
+	_, err := client.PullRequests.Reopen(context.Background(), "demo/demo-repo", 1)
+	if err == nil || err.Error() != "merge request is merged" {
+		t.Errorf("Want the open api error, got %v", err)
+	}
+}
@@ -37,11 +37,14 @@ func (s *userService) Find(ctx context.Context) (*scm.User, *scm.Response, error
 		Action: "DescribeCodingCurrentUser",
 	}
 	res, err := s.client.do(ctx, "POST", "", &body, out)
+	if err != nil {
+		return nil, res, err
+	}
 	if out.Response.Error != nil {
 		return nil, res, errors.New(out.Response.Error.Message)
 	}
 	res.ID = out.Response.RequestId
-	return convertUser(&out.Response.User), res, err
+	return convertUser(&out.Response.User), res, nil
 }
 
 func (s *userService) FindLogin(ctx context.Context, login string) (*scm.User, *scm.Response, error) {
@@ -50,7 +53,10 @@ func (s *userService) FindLogin(ctx context.Context, login string) (*scm.User, *
 
 func (s *userService) FindEmail(ctx context.Context) (string, *scm.Response, error) {
 	user, res, err := s.Find(ctx)
-	return user.Email, res, err
+	if err != nil {
+		return "", res, err
+	}
+	return user.Email, res, nil
 }
 
 func (s *userService) ListInvitations(ctx context.Context) ([]*scm.Invitation, *scm.Response, error) {
@@ -63,7 +69,7 @@ func (s *userService) AcceptInvitation(ctx context.Context, invitationID int64)
 
 type user struct {
 	ID              int         `json:"Id"`
-	Status          int         `json:"Status`
+	Status          int         `json:"Status"`
 	Email           null.String `json:"Email"`
 	GlobalKey       string      `json:"GlobalKey"`
 	Avatar          string      `json:"Avatar"`
@@ -5,7 +5,6 @@
 package coding
 
 import (
-	"crypto/subtle"
 	"encoding/json"
 	"io"
 	"io/ioutil"
@@ -14,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jenkins-x/go-scm/pkg/hmac"
 	"github.com/jenkins-x/go-scm/scm"
 )
 
@@ -40,10 +40,7 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 		hook, err = parsePushHook(data, hookId)
 	case "Issue Hook":
 		return nil, scm.UnknownWebhook{Event: event}
-	case "GIT_MR_CREATED":
-	case "GIT_MR_UPDATED":
-	case "GIT_MR_MERGED":
-	case "GIT_MR_CLOSED":
+	case "GIT_MR_CREATED", "GIT_MR_UPDATED", "GIT_MR_MERGED", "GIT_MR_CLOSED":
 		hook, err = parsePullRequestHook(data, event, hookId)
 	default:
 		return nil, scm.UnknownWebhook{Event: event}
@@ -52,17 +49,18 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 		return nil, err
 	}
 
-	// get the gitlab shared token to verify the payload
+	// get the coding shared secret to verify the payload
 	// authenticity. If no key is provided, no validation
 	// is performed.
-	token, err := fn(hook)
+	key, err := fn(hook)
 	if err != nil {
 		return hook, err
-	} else if token == "" {
+	} else if key == "" {
 		return hook, nil
 	}
 
-	if subtle.ConstantTimeCompare([]byte(req.Header.Get("X-Gitlab-Token")), []byte(token)) == 0 {
+	sig := req.Header.Get("X-Coding-Signature")
+	if !hmac.ValidatePrefix(data, []byte(key), sig) {
 		return hook, scm.ErrSignatureInvalid
 	}
 
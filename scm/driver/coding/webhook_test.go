@@ -4,20 +4,17 @@
 
 package coding
 
-// import (
-// 	"bytes"
-// 	"context"
-// 	"encoding/json"
-// 	"io/ioutil"
-// 	"net/http"
-// 	"os"
-// 	"strings"
-// 	"testing"
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
 
-// 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm"
 
-// 	"github.com/google/go-cmp/cmp"
-// )
+	"github.com/google/go-cmp/cmp"
+)
 
 // type mockUserService struct {
 // 	users map[int]*scm.User
@@ -271,3 +268,46 @@ package coding
 // func secretFunc(scm.Webhook) (string, error) {
 // 	return "topsecret", nil
 // }
+
+func TestWebhookPullRequest(t *testing.T) {
+	before, err := ioutil.ReadFile("testdata/webhooks/pull_request_create.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(before))
+	r.Header.Set("X-Coding-Service-Hook-Event", "GIT_MR_CREATED")
+	r.Header.Set("X-Coding-Service-Hook-Id", "a1b2c3")
+	r.Header.Set("X-Coding-Signature", "sha1=6b11a9d865d40a67e5c65e4aed5e68adb22aa015")
+
+	got, err := NewWebHookService().Parse(r, secretFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.PullRequestHook)
+	raw, _ := ioutil.ReadFile("testdata/webhooks/pull_request_create.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestWebhookSignatureInvalid(t *testing.T) {
+	before, _ := ioutil.ReadFile("testdata/webhooks/pull_request_create.json")
+
+	r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(before))
+	r.Header.Set("X-Coding-Service-Hook-Event", "GIT_MR_CREATED")
+	r.Header.Set("X-Coding-Signature", "sha1=380f462cd2e160b84765144beabdad2e930a7ec5")
+
+	_, err := NewWebHookService().Parse(r, secretFunc)
+	if err != scm.ErrSignatureInvalid {
+		t.Errorf("Expect invalid signature error, got %v", err)
+	}
+}
+
+func secretFunc(scm.Webhook) (string, error) {
+	return "topsecret", nil
+}
@@ -0,0 +1,22 @@
+package fake
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// unsupported lists the features of the services of the
+// client which return scm.ErrNotSupported.
+var unsupported = []scm.Feature{
+	"Contents.Blame",
+	"Git.ListChanges",
+	"Organizations.Create",
+	"Organizations.Delete",
+	"Organizations.IsMember",
+	"Organizations.ListOrgMembers",
+	"Repositories.FindPerms",
+	"Repositories.UpdateHook",
+	"Reviews.ListComments",
+	"Reviews.Submit",
+	"Reviews.Update",
+	"Users.AcceptInvitation",
+	"Users.CreateToken",
+	"Users.DeleteToken",
+}
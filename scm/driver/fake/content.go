@@ -2,9 +2,14 @@ package fake
 
 import (
 	"context"
+	"crypto/sha1" // #nosec
+	"encoding/hex"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/jenkins-x/go-scm/scm"
 	"github.com/pkg/errors"
@@ -22,6 +27,9 @@ type contentService struct {
 }
 
 func (c contentService) Find(_ context.Context, repo, path, ref string) (*scm.Content, *scm.Response, error) {
+	if c.data.ContentDir == "" {
+		return c.findFile(repo, path, ref)
+	}
 	f, err := c.path(repo, path, ref)
 	if err != nil {
 		return nil, nil, err
@@ -44,6 +52,9 @@ func (c contentService) Find(_ context.Context, repo, path, ref string) (*scm.Co
 }
 
 func (c contentService) List(_ context.Context, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
+	if c.data.ContentDir == "" {
+		return c.listFiles(repo, path, ref)
+	}
 	dir, err := c.path(repo, path, ref)
 	if err != nil {
 		return nil, nil, err
@@ -73,6 +84,9 @@ func (c contentService) List(_ context.Context, repo, path, ref string) ([]*scm.
 }
 
 func (c contentService) Create(_ context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	if c.data.ContentDir == "" {
+		return c.writeFile(repo, path, params, false)
+	}
 	f, err := c.path(repo, path, "")
 	if err != nil {
 		return nil, err
@@ -81,10 +95,14 @@ func (c contentService) Create(_ context.Context, repo, path string, params *scm
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to write file %s", f)
 	}
+	c.data.push(repo, params.Branch, params.Message, scm.PushCommit{Added: []string{path}}, nil)
 	return nil, nil
 }
 
 func (c contentService) Update(_ context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	if c.data.ContentDir == "" {
+		return c.writeFile(repo, path, params, true)
+	}
 	f, err := c.path(repo, path, "")
 	if err != nil {
 		return nil, err
@@ -93,11 +111,15 @@ func (c contentService) Update(_ context.Context, repo, path string, params *scm
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to write file %s", f)
 	}
+	c.data.push(repo, params.Branch, params.Message, scm.PushCommit{Modified: []string{path}}, nil)
 	return nil, nil
 }
 
-func (c contentService) Delete(_ context.Context, repo, path, ref string) (*scm.Response, error) {
-	f, err := c.path(repo, path, ref)
+func (c contentService) Delete(_ context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	if c.data.ContentDir == "" {
+		return c.deleteFile(repo, path, params)
+	}
+	f, err := c.path(repo, path, params.Branch)
 	if err != nil {
 		return nil, err
 	}
@@ -105,9 +127,141 @@ func (c contentService) Delete(_ context.Context, repo, path, ref string) (*scm.
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to delete file %s", f)
 	}
+	c.data.push(repo, params.Branch, params.Message, scm.PushCommit{Removed: []string{path}}, nil)
+	return nil, nil
+}
+
+func (c contentService) Blame(_ context.Context, repo, path, ref string) ([]*scm.BlameRange, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+// files returns the sha and files of the given ref which defaults to the
+// default branch of the repository when no ContentDir is configured
+func (c contentService) files(repo, ref string) (string, map[string][]byte, error) {
+	if ref == "" {
+		ref = c.data.repository(repo).Branch
+	}
+	sha, ok := c.data.resolve(repo, ref)
+	if !ok {
+		return "", nil, errors.Wrapf(scm.ErrNotFound, "ref %s does not exist in %s", ref, repo)
+	}
+	return sha, c.data.Files[sha], nil
+}
+
+func (c contentService) findFile(repo, path, ref string) (*scm.Content, *scm.Response, error) {
+	_, files, err := c.files(repo, ref)
+	if err != nil {
+		return nil, &scm.Response{Status: 404}, err
+	}
+	data, ok := files[path]
+	if !ok {
+		return nil, &scm.Response{Status: 404}, errors.Wrapf(scm.ErrNotFound, "file %s does not exist", path)
+	}
+	return &scm.Content{
+		Path: path,
+		Data: data,
+		Sha:  blobSha(data),
+	}, nil, nil
+}
+
+func (c contentService) listFiles(repo, dir, ref string) ([]*scm.FileEntry, *scm.Response, error) {
+	_, files, err := c.files(repo, ref)
+	if err != nil {
+		return nil, &scm.Response{Status: 404}, err
+	}
+	prefix := strings.Trim(dir, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	entries := map[string]*scm.FileEntry{}
+	for path, data := range files {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		name := strings.TrimPrefix(path, prefix)
+		entry := &scm.FileEntry{
+			Name: name,
+			Path: path,
+			Type: "file",
+			Size: len(data),
+			Sha:  blobSha(data),
+			Link: fmt.Sprintf("https://fake.com/%s/blob/%s/%s", repo, ref, path),
+		}
+		if i := strings.Index(name, "/"); i >= 0 {
+			name = name[:i]
+			entry = &scm.FileEntry{
+				Name: name,
+				Path: prefix + name,
+				Type: "dir",
+				Link: fmt.Sprintf("https://fake.com/%s/tree/%s/%s", repo, ref, prefix+name),
+			}
+		}
+		entries[name] = entry
+	}
+	if len(entries) == 0 && prefix != "" {
+		return nil, &scm.Response{Status: 404}, errors.Wrapf(scm.ErrNotFound, "directory %s does not exist", dir)
+	}
+	var answer []*scm.FileEntry
+	for _, entry := range entries {
+		answer = append(answer, entry)
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Name < answer[j].Name
+	})
+	return answer, nil, nil
+}
+
+func (c contentService) writeFile(repo, path string, params *scm.ContentParams, update bool) (*scm.Response, error) {
+	_, files, err := c.files(repo, params.Branch)
+	if err != nil && update {
+		return &scm.Response{Status: 404}, err
+	}
+	existing, exists := files[path]
+	changes := scm.PushCommit{Added: []string{path}}
+	if update {
+		if !exists {
+			return &scm.Response{Status: 404}, errors.Wrapf(scm.ErrNotFound, "file %s does not exist", path)
+		}
+		if params.Sha != "" && params.Sha != blobSha(existing) {
+			return &scm.Response{Status: 409}, errors.Errorf("file %s does not match sha %s", path, params.Sha)
+		}
+		changes = scm.PushCommit{Modified: []string{path}}
+	} else if exists {
+		return &scm.Response{Status: 422}, errors.Errorf("file %s already exists", path)
+	}
+	data := append([]byte{}, params.Data...)
+	c.data.push(repo, params.Branch, params.Message, changes, func(files map[string][]byte) {
+		files[path] = data
+	})
+	return nil, nil
+}
+
+func (c contentService) deleteFile(repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	_, files, err := c.files(repo, params.Branch)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	existing, ok := files[path]
+	if !ok {
+		return &scm.Response{Status: 404}, errors.Wrapf(scm.ErrNotFound, "file %s does not exist", path)
+	}
+	if params.Sha != "" && params.Sha != blobSha(existing) {
+		return &scm.Response{Status: 409}, errors.Errorf("file %s does not match sha %s", path, params.Sha)
+	}
+	c.data.push(repo, params.Branch, params.Message, scm.PushCommit{Removed: []string{path}}, func(files map[string][]byte) {
+		delete(files, path)
+	})
 	return nil, nil
 }
 
+// blobSha returns the git blob sha of the data
+func blobSha(data []byte) string {
+	h := sha1.New() // #nosec
+	fmt.Fprintf(h, "blob %d\x00", len(data))
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 func (c contentService) path(repo string, path string, ref string) (string, error) {
 	if c.data.ContentDir == "" {
 		return "", errors.Errorf("no data.ContentDir configured")
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/jenkins-x/go-scm/scm"
 	"github.com/jenkins-x/go-scm/scm/driver/fake"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -77,3 +78,47 @@ func TestContentWithRefs(t *testing.T) {
 		t.Logf("loaded repo %s path %s ref %s got %s\n", repo, ref, path, text)
 	}
 }
+
+func TestContentInMemory(t *testing.T) {
+	client, data := fake.NewDefault()
+	data.ContentDir = ""
+
+	ctx := context.Background()
+	repo := "myorg/memory"
+
+	_, _, err := client.Repositories.Create(ctx, &scm.RepositoryInput{Namespace: "myorg", Name: "memory"})
+	require.NoError(t, err, "failed to create repository")
+
+	_, err = client.Contents.Create(ctx, repo, "somedir/hello.txt", &scm.ContentParams{Message: "add hello", Data: []byte("hello")})
+	require.NoError(t, err, "failed to create file")
+
+	_, err = client.Contents.Create(ctx, repo, "somedir/hello.txt", &scm.ContentParams{Message: "add hello", Data: []byte("hello")})
+	require.Error(t, err, "should not create a file which already exists")
+
+	c, _, err := client.Contents.Find(ctx, repo, "somedir/hello.txt", "")
+	require.NoError(t, err, "failed to find file")
+	assert.Equal(t, "hello", string(c.Data))
+	assert.Equal(t, "b6fc4c620b67d95f953a5c1c1230aaab5db5a1b0", c.Sha, "git blob sha")
+
+	files, _, err := client.Contents.List(ctx, repo, "", "master")
+	require.NoError(t, err, "failed to list files")
+	require.Len(t, files, 1)
+	assert.Equal(t, "somedir", files[0].Name)
+	assert.Equal(t, "dir", files[0].Type)
+
+	_, err = client.Contents.Update(ctx, repo, "somedir/hello.txt", &scm.ContentParams{Message: "update hello", Data: []byte("world"), Sha: "0000"})
+	require.Error(t, err, "should not update a file with the wrong sha")
+
+	_, err = client.Contents.Update(ctx, repo, "somedir/hello.txt", &scm.ContentParams{Message: "update hello", Data: []byte("world"), Sha: c.Sha})
+	require.NoError(t, err, "failed to update file")
+
+	updated, _, err := client.Contents.Find(ctx, repo, "somedir/hello.txt", "master")
+	require.NoError(t, err, "failed to find file")
+	assert.Equal(t, "world", string(updated.Data))
+
+	_, err = client.Contents.Delete(ctx, repo, "somedir/hello.txt", &scm.ContentParams{Branch: "master", Message: "delete hello"})
+	require.NoError(t, err, "failed to delete file")
+
+	_, _, err = client.Contents.Find(ctx, repo, "somedir/hello.txt", "master")
+	assert.True(t, scm.IsScmNotFound(err), "file should have been deleted")
+}
@@ -19,6 +19,8 @@ type Data struct {
 	PullRequestLabelsExisting  []string
 	ReviewID                   int
 	Reviews                    map[int][]*scm.Review
+	ReviewCommentID            int
+	ReviewThreads              map[int][]*scm.ReviewThread
 	Statuses                   map[string][]*scm.Status
 	IssueEvents                map[int][]*scm.ListedIssueEvent
 	Commits                    map[string]*scm.Commit
@@ -69,6 +71,18 @@ type Data struct {
 	// A list of refs that got deleted via DeleteRef
 	RefsDeleted []DeletedRef
 
+	// Refs the git references of each repository
+	// org/repo -> ref (e.g. "heads/master" or "tags/v1.0.0") -> sha
+	Refs map[string]map[string]string
+
+	// CommitParents the parent shas of each commit so that
+	// the history of a branch can be walked
+	CommitParents map[string][]string
+
+	// IssueRepos the repository owning each issue or pull request number
+	// number -> org/repo
+	IssueRepos map[int]string
+
 	UserPermissions map[string]map[string]string
 
 	// Invitations the current pending invitations
@@ -76,6 +90,25 @@ type Data struct {
 
 	// ContentDir the directory used to implement the Content service to access files and directories
 	ContentDir string
+
+	// Files the contents of each commit used to implement the Content service
+	// in memory when no ContentDir is configured
+	// commit sha -> path -> data
+	Files map[string]map[string][]byte
+
+	// MergeRules the branch protection rules used to evaluate the
+	// mergeability of pull requests
+	// org/repo:branch -> rules
+	MergeRules map[string]*scm.MergeRules
+
+	// WebhookListener if specified is invoked with the webhook for each
+	// change made via the fake client
+	WebhookListener func(scm.Webhook)
+
+	// WebhookChannel if specified receives the webhook for each change made
+	// via the fake client. Sending blocks so the channel should be buffered
+	// or consumed concurrently
+	WebhookChannel chan<- scm.Webhook
 }
 
 // DeletedRef represents a ref that has been deleted
@@ -98,12 +131,18 @@ func NewData() *Data {
 		PullRequestLabelsExisting: []string{},
 		PullRequestsCreated:       map[int]*scm.PullRequestInput{},
 		Reviews:                   map[int][]*scm.Review{},
+		ReviewThreads:             map[int][]*scm.ReviewThread{},
 		Statuses:                  map[string][]*scm.Status{},
 		IssueEvents:               map[int][]*scm.ListedIssueEvent{},
 		Commits:                   map[string]*scm.Commit{},
 		MilestoneMap:              map[string]int{},
 		CommitMap:                 map[string][]scm.Commit{},
 		RemoteFiles:               map[string]map[string]string{},
+		Refs:                      map[string]map[string]string{},
+		CommitParents:             map[string][]string{},
+		Files:                     map[string]map[string][]byte{},
+		MergeRules:                map[string]*scm.MergeRules{},
+		IssueRepos:                map[int]string{},
 		TestRef:                   "abcde",
 		IssueLabelsAdded:          []string{},
 		IssueLabelsExisting:       []string{},
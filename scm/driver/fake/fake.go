@@ -32,16 +32,21 @@ func NewDefault() (*scm.Client, *Data) {
 	client.Repositories = &repositoryService{client: client, data: data}
 	client.Releases = &releaseService{client: client, data: data}
 	client.Reviews = &reviewService{client: client, data: data}
+	client.Search = &searchService{client: client, data: data}
 	client.Users = &userService{client: client, data: data}
 
+	client.Webhooks = &webhookService{client}
+	client.Unsupported = unsupported
+
 	client.Username = data.CurrentUser.Login
-	// TODO
-	/*
-		client.Webhooks = &webhookService{client}
-	*/
 	return client.Client, data
 }
 
+// NewWebHookService creates a new instance of the webhook service without the rest of the client
+func NewWebHookService() scm.WebhookService {
+	return &webhookService{nil}
+}
+
 type wrapper struct {
 	*scm.Client
 }
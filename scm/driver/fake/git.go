@@ -15,11 +15,32 @@ type gitService struct {
 
 func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *scm.Response, error) {
 	f := s.data
+	if sha, ok := f.resolve(repo, ref); ok {
+		return sha, nil, nil
+	}
 	return f.TestRef, nil, nil
 }
 
 func (s *gitService) CreateRef(ctx context.Context, repo, ref, sha string) (*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	f := s.data
+	name := strings.TrimPrefix(ref, "refs/")
+	if !strings.HasPrefix(name, headsPrefix) && !strings.HasPrefix(name, tagsPrefix) {
+		name = headsPrefix + name
+	}
+	refs := f.refs(repo)
+	if _, ok := refs[name]; ok {
+		return nil, &scm.Response{Status: 422}, fmt.Errorf("reference %s already exists in %s", name, repo)
+	}
+	if resolved, ok := f.resolve(repo, sha); ok {
+		sha = resolved
+	}
+	refs[name] = sha
+	f.emitRef(repo, scm.ActionCreate, name, sha)
+	return &scm.Reference{
+		Name: strings.TrimPrefix(strings.TrimPrefix(name, headsPrefix), tagsPrefix),
+		Path: "refs/" + name,
+		Sha:  sha,
+	}, nil, nil
 }
 
 func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Response, error) {
@@ -31,38 +52,124 @@ func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Resp
 	org := paths[0]
 	name := paths[1]
 	f.RefsDeleted = append(f.RefsDeleted, DeletedRef{Org: org, Repo: name, Ref: ref})
+	refs := f.Refs[repo]
+	ref = strings.TrimPrefix(ref, "refs/")
+	for _, name := range []string{ref, headsPrefix + ref} {
+		if sha, ok := refs[name]; ok {
+			delete(refs, name)
+			f.emitRef(repo, scm.ActionDelete, name, sha)
+		}
+	}
 	return nil, nil
 }
 
 func (s *gitService) FindBranch(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	return s.findRef(repo, headsPrefix, name)
 }
 
 func (s *gitService) FindCommit(ctx context.Context, repo, SHA string) (*scm.Commit, *scm.Response, error) {
 	f := s.data
+	if sha, ok := f.resolve(repo, SHA); ok {
+		SHA = sha
+	}
 	return f.Commits[SHA], nil, nil
 }
 
 func (s *gitService) FindTag(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	return s.findRef(repo, tagsPrefix, name)
+}
+
+func (s *gitService) findRef(repo, prefix, name string) (*scm.Reference, *scm.Response, error) {
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "refs/"), prefix)
+	sha, ok := s.data.Refs[repo][prefix+name]
+	if !ok {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	return &scm.Reference{
+		Name: name,
+		Path: "refs/" + prefix + name,
+		Sha:  sha,
+	}, nil, nil
 }
 
 func (s *gitService) ListBranches(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	branches := s.data.listRefs(repo, headsPrefix)
+	returnStart, returnEnd := paginated(opts.Page, opts.Size, len(branches))
+	return branches[returnStart:returnEnd], nil, nil
 }
 
 func (s *gitService) ListCommits(ctx context.Context, repo string, opts scm.CommitListOptions) ([]*scm.Commit, *scm.Response, error) {
-	panic("implement me")
+	f := s.data
+	ref := opts.Sha
+	if ref == "" {
+		ref = opts.Ref
+	}
+	if ref == "" {
+		ref = defaultBranch
+	}
+	sha, ok := f.resolve(repo, ref)
+	if !ok {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	var commits []*scm.Commit
+	for _, c := range f.history(sha) {
+		if f.matchesCommit(c, opts) {
+			commits = append(commits, c)
+		}
+	}
+	returnStart, returnEnd := paginated(opts.Page, opts.Size, len(commits))
+	return commits[returnStart:returnEnd], nil, nil
 }
 
 func (s *gitService) ListChanges(ctx context.Context, repo, ref string, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
-	panic("implement me")
+	return nil, nil, scm.ErrNotSupported
 }
 
 func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
-	panic("implement me")
+	out, res, err := s.Compare(ctx, repo, ref1, ref2, opts)
+	if err != nil {
+		return nil, res, err
+	}
+	return out.Changes, res, nil
+}
+
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, opts scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
+	f := s.data
+	baseSha, ok := f.resolve(repo, base)
+	if !ok {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	headSha, ok := f.resolve(repo, head)
+	if !ok {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	onBase := map[string]bool{}
+	for _, c := range f.history(baseSha) {
+		onBase[c.Sha] = true
+	}
+	out := &scm.Comparison{}
+	for _, c := range f.history(headSha) {
+		if onBase[c.Sha] {
+			out.MergeBase = c.Sha
+			break
+		}
+		out.Commits = append([]*scm.Commit{c}, out.Commits...)
+	}
+	out.Ahead = len(out.Commits)
+	for _, c := range f.history(baseSha) {
+		if c.Sha == out.MergeBase {
+			break
+		}
+		out.Behind++
+	}
+	out.Changes = f.changes(out.MergeBase, headSha)
+	returnStart, returnEnd := paginated(opts.Page, opts.Size, len(out.Commits))
+	out.Commits = out.Commits[returnStart:returnEnd]
+	return out, nil, nil
 }
 
 func (s *gitService) ListTags(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
-	panic("implement me")
+	tags := s.data.listRefs(repo, tagsPrefix)
+	returnStart, returnEnd := paginated(opts.Page, opts.Size, len(tags))
+	return tags[returnStart:returnEnd], nil, nil
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -27,61 +29,80 @@ func (s *issueService) ListEvents(ctx context.Context, repo string, number int,
 }
 
 func (s *issueService) Find(ctx context.Context, repo string, number int) (*scm.Issue, *scm.Response, error) {
-	f := s.data
-	for _, slice := range f.Issues {
-		for _, issue := range slice {
-			if issue.Number == number {
-				return issue, nil, nil
-			}
-		}
+	issue, err := s.data.issue(repo, number)
+	if err != nil {
+		return nil, &scm.Response{Status: 404}, err
 	}
-	return nil, nil, nil
+	return issue, nil, nil
 }
 
 func (s *issueService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
-	f := s.data
-	re := regexp.MustCompile(fmt.Sprintf(`^%s#%d:(.*)$`, repo, number))
 	la := []*scm.Label{}
+	for _, l := range s.labels(repo, number).List() {
+		la = append(la, &scm.Label{Name: l})
+	}
+	return la, nil, nil
+}
+
+// labels returns the current labels of the issue combining the
+// recorded label operations with the labels of the stored issue
+func (s *issueService) labels(repo string, number int) sets.String {
+	f := s.data
+	re := regexp.MustCompile(fmt.Sprintf(`^%s#%d:(.*)$`, regexp.QuoteMeta(repo), number))
 	allLabels := sets.NewString(f.IssueLabelsExisting...)
 	allLabels.Insert(f.IssueLabelsAdded...)
 	allLabels.Delete(f.IssueLabelsRemoved...)
+	answer := sets.NewString()
 	for _, l := range allLabels.List() {
 		groups := re.FindStringSubmatch(l)
 		if groups != nil {
-			la = append(la, &scm.Label{Name: groups[1]})
+			answer.Insert(groups[1])
 		}
 	}
-	return la, nil, nil
+	if issue, err := f.issue(repo, number); err == nil {
+		answer.Insert(issue.Labels...)
+	}
+	return answer
 }
 
 func (s *issueService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
 	f := s.data
 	labelString := fmt.Sprintf("%s#%d:%s", repo, number, label)
-	if sets.NewString(f.IssueLabelsAdded...).Has(labelString) {
+	if sets.NewString(f.IssueLabelsAdded...).Has(labelString) && s.labels(repo, number).Has(label) {
 		return nil, fmt.Errorf("cannot add %v to %s/#%d", label, repo, number)
 	}
-	if f.RepoLabelsExisting == nil {
-		f.IssueLabelsAdded = append(f.IssueLabelsAdded, labelString)
-		return nil, nil
+	if f.RepoLabelsExisting != nil && !sets.NewString(f.RepoLabelsExisting...).Has(label) {
+		return nil, fmt.Errorf("cannot add %v to %s/#%d", label, repo, number)
 	}
-	for _, l := range f.RepoLabelsExisting {
-		if label == l {
-			f.IssueLabelsAdded = append(f.IssueLabelsAdded, labelString)
-			return nil, nil
-		}
+	if issue, err := f.issue(repo, number); err == nil && !sets.NewString(issue.Labels...).Has(label) {
+		issue.Labels = append(issue.Labels, label)
 	}
-	return nil, fmt.Errorf("cannot add %v to %s/#%d", label, repo, number)
+	f.IssueLabelsAdded = append(f.IssueLabelsAdded, labelString)
+	f.labelEvent(number, "labeled", label)
+	f.emitIssue(repo, scm.ActionLabel, number)
+	return nil, nil
 }
 
 // DeleteLabel removes a label
 func (s *issueService) DeleteLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
 	f := s.data
 	labelString := fmt.Sprintf("%s#%d:%s", repo, number, label)
-	if !sets.NewString(f.IssueLabelsRemoved...).Has(labelString) {
-		f.IssueLabelsRemoved = append(f.IssueLabelsRemoved, labelString)
-		return nil, nil
+	if sets.NewString(f.IssueLabelsRemoved...).Has(labelString) && !s.labels(repo, number).Has(label) {
+		return nil, fmt.Errorf("cannot remove %v from %s/#%d", label, repo, number)
 	}
-	return nil, fmt.Errorf("cannot remove %v from %s/#%d", label, repo, number)
+	if issue, err := f.issue(repo, number); err == nil {
+		var labels []string
+		for _, l := range issue.Labels {
+			if l != label {
+				labels = append(labels, l)
+			}
+		}
+		issue.Labels = labels
+	}
+	f.IssueLabelsRemoved = append(f.IssueLabelsRemoved, labelString)
+	f.labelEvent(number, "unlabeled", label)
+	f.emitIssue(repo, scm.ActionUnlabel, number)
+	return nil, nil
 }
 
 // FindIssues returns f.Issues
@@ -106,6 +127,10 @@ func (s *issueService) AssignIssue(ctx context.Context, repo string, number int,
 			continue
 		}
 		f.AssigneesAdded = append(f.AssigneesAdded, fmt.Sprintf("%s#%d:%s", repo, number, a))
+		if issue, err := f.issue(repo, number); err == nil {
+			issue.Assignees = addUsers(issue.Assignees, []string{a})
+			f.emitIssue(repo, scm.ActionAssigned, number)
+		}
 	}
 	if m.Users == nil {
 		return nil, nil
@@ -114,15 +139,46 @@ func (s *issueService) AssignIssue(ctx context.Context, repo string, number int,
 }
 
 func (s *issueService) UnassignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	panic("implement me")
+	issue, err := s.data.issue(repo, number)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	issue.Assignees = removeUsers(issue.Assignees, logins)
+	s.data.emitIssue(repo, scm.ActionUnassigned, number)
+	return nil, nil
 }
 
-func (s *issueService) FindComment(context.Context, string, int, int) (*scm.Comment, *scm.Response, error) {
-	panic("implement me")
+func (s *issueService) FindComment(ctx context.Context, repo string, number int, id int) (*scm.Comment, *scm.Response, error) {
+	f := s.data
+	c := findComment(f.IssueComments[number], id)
+	if c == nil {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	return c, nil, nil
 }
 
-func (s *issueService) List(context.Context, string, scm.IssueListOptions) ([]*scm.Issue, *scm.Response, error) {
-	panic("implement me")
+func (s *issueService) List(ctx context.Context, repo string, opts scm.IssueListOptions) ([]*scm.Issue, *scm.Response, error) {
+	f := s.data
+	var answer []*scm.Issue
+	for number, slice := range f.Issues {
+		if !f.ownedBy(repo, number) {
+			continue
+		}
+		for _, issue := range slice {
+			if opts.Open && !opts.Closed && issue.Closed {
+				continue
+			}
+			if opts.Closed && !opts.Open && !issue.Closed {
+				continue
+			}
+			answer = append(answer, issue)
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Number < answer[j].Number
+	})
+	returnStart, returnEnd := paginated(opts.Page, opts.Size, len(answer))
+	return answer[returnStart:returnEnd], nil, nil
 }
 
 func (s *issueService) ListComments(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
@@ -130,20 +186,39 @@ func (s *issueService) ListComments(ctx context.Context, repo string, number int
 	return append([]*scm.Comment{}, f.IssueComments[number]...), nil, nil
 }
 
-func (s *issueService) Create(context.Context, string, *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
-	panic("implement me")
+func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	f := s.data
+	number := f.nextNumber(repo)
+	now := time.Now()
+	issue := &scm.Issue{
+		Number:  number,
+		Title:   input.Title,
+		Body:    input.Body,
+		Link:    fmt.Sprintf("https://fake.com/%s/issues/%d", repo, number),
+		State:   "open",
+		Author:  f.CurrentUser,
+		Created: now,
+		Updated: now,
+	}
+	f.Issues[number] = append(f.Issues[number], issue)
+	f.emitIssue(repo, scm.ActionOpen, number)
+	return issue, nil, nil
 }
 
 func (s *issueService) CreateComment(ctx context.Context, repo string, number int, comment *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
 	f := s.data
 	f.IssueCommentsAdded = append(f.IssueCommentsAdded, fmt.Sprintf("%s#%d:%s", repo, number, comment.Body))
+	now := time.Now()
 	answer := &scm.Comment{
-		ID:     f.IssueCommentID,
-		Body:   comment.Body,
-		Author: scm.User{Login: botName},
+		ID:      f.IssueCommentID,
+		Body:    comment.Body,
+		Author:  scm.User{Login: botName},
+		Created: now,
+		Updated: now,
 	}
 	f.IssueComments[number] = append(f.IssueComments[number], answer)
 	f.IssueCommentID++
+	f.emitComment(repo, scm.ActionCreate, number, answer)
 	return answer, nil, nil
 }
 
@@ -154,6 +229,7 @@ func (s *issueService) DeleteComment(ctx context.Context, repo string, number in
 		for i, ic := range ics {
 			if ic.ID == id {
 				f.IssueComments[num] = append(ics[:i], ics[i+1:]...)
+				f.emitComment(repo, scm.ActionDelete, num, ic)
 				return nil, nil
 			}
 		}
@@ -162,29 +238,75 @@ func (s *issueService) DeleteComment(ctx context.Context, repo string, number in
 }
 
 func (s *issueService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	f := s.data
+	c := findComment(f.IssueComments[number], id)
+	if c == nil {
+		return nil, &scm.Response{Status: 404}, fmt.Errorf("could not find issue comment %d", id)
+	}
+	c.Body = input.Body
+	c.Version++
+	c.Updated = time.Now()
+	f.emitComment(repo, scm.ActionEdited, number, c)
+	return c, nil, nil
 }
 
-func (s *issueService) Close(context.Context, string, int) (*scm.Response, error) {
-	panic("implement me")
+func (s *issueService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	issue, err := s.data.issue(repo, number)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	user := s.data.CurrentUser
+	issue.Closed = true
+	issue.ClosedBy = &user
+	issue.State = "closed"
+	issue.Updated = time.Now()
+	s.data.emitIssue(repo, scm.ActionClose, number)
+	return nil, nil
 }
 
-func (s *issueService) Reopen(context.Context, string, int) (*scm.Response, error) {
-	panic("implement me")
+func (s *issueService) Reopen(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	issue, err := s.data.issue(repo, number)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	issue.Closed = false
+	issue.ClosedBy = nil
+	issue.State = "open"
+	issue.Updated = time.Now()
+	s.data.emitIssue(repo, scm.ActionReopen, number)
+	return nil, nil
 }
 
-func (s *issueService) Lock(context.Context, string, int) (*scm.Response, error) {
-	panic("implement me")
+func (s *issueService) Lock(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	issue, err := s.data.issue(repo, number)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	issue.Locked = true
+	return nil, nil
 }
 
-func (s *issueService) Unlock(context.Context, string, int) (*scm.Response, error) {
-	panic("implement me")
+func (s *issueService) Unlock(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	issue, err := s.data.issue(repo, number)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	issue.Locked = false
+	return nil, nil
 }
 
 func (s *issueService) SetMilestone(ctx context.Context, repo string, issueID int, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	if _, err := s.data.issue(repo, issueID); err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	s.data.MilestoneMap[fmt.Sprintf("%s#%d", repo, issueID)] = number
+	return nil, nil
 }
 
 func (s *issueService) ClearMilestone(ctx context.Context, repo string, id int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	if _, err := s.data.issue(repo, id); err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	delete(s.data.MilestoneMap, fmt.Sprintf("%s#%d", repo, id))
+	return nil, nil
 }
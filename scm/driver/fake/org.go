@@ -28,7 +28,7 @@ type organizationService struct {
 }
 
 func (s *organizationService) Create(context.Context, *scm.OrganizationInput) (*scm.Organization, *scm.Response, error) {
-	panic("implement me")
+	return nil, nil, scm.ErrNotSupported
 }
 
 func (s *organizationService) Delete(context.Context, string) (*scm.Response, error) {
@@ -36,7 +36,7 @@ func (s *organizationService) Delete(context.Context, string) (*scm.Response, er
 }
 
 func (s *organizationService) IsMember(ctx context.Context, org string, user string) (bool, *scm.Response, error) {
-	panic("implement me")
+	return false, nil, scm.ErrNotSupported
 }
 
 func (s *organizationService) IsAdmin(ctx context.Context, org string, user string) (bool, *scm.Response, error) {
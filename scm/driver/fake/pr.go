@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -16,16 +19,29 @@ type pullService struct {
 }
 
 func (s *pullService) Find(ctx context.Context, repo string, number int) (*scm.PullRequest, *scm.Response, error) {
-	f := s.data
-	val, exists := f.PullRequests[number]
-	if !exists {
-		return nil, nil, fmt.Errorf("Pull request number %d does not exit", number)
+	pr, err := s.data.pullRequest(repo, number)
+	if err != nil {
+		return nil, &scm.Response{Status: 404}, errors.Wrapf(err, "pull request number %d does not exist", number)
+	}
+	return pr, nil, nil
+}
+
+func (s *pullService) FindMergeability(ctx context.Context, repo string, number int) (*scm.Mergeability, *scm.Response, error) {
+	pr, res, err := s.Find(ctx, repo, number)
+	if err != nil {
+		return nil, res, err
 	}
-	return val, nil, nil
+	rules := s.data.MergeRules[repo+":"+pr.Base.Ref]
+	return scm.FindMergeability(ctx, s.client.Client, repo, pr, rules)
 }
 
-func (s *pullService) FindComment(context.Context, string, int, int) (*scm.Comment, *scm.Response, error) {
-	panic("implement me")
+func (s *pullService) FindComment(ctx context.Context, repo string, number int, id int) (*scm.Comment, *scm.Response, error) {
+	f := s.data
+	c := findComment(f.PullRequestComments[number], id)
+	if c == nil {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	return c, nil, nil
 }
 
 func (s *pullService) List(ctx context.Context, fullName string, opts scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
@@ -37,11 +53,22 @@ func (s *pullService) List(ctx context.Context, fullName string, opts scm.PullRe
 		if fn == "" {
 			fn = scm.Join(repo.Namespace, repo.Name)
 		}
-		if fn == fullName {
-			answer = append(answer, pr)
+		if fn != fullName {
+			continue
 		}
+		if opts.Open && !opts.Closed && pr.Closed {
+			continue
+		}
+		if opts.Closed && !opts.Open && !pr.Closed {
+			continue
+		}
+		answer = append(answer, pr)
 	}
-	return answer, nil, nil
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Number < answer[j].Number
+	})
+	returnStart, returnEnd := paginated(opts.Page, opts.Size, len(answer))
+	return answer[returnStart:returnEnd], nil, nil
 }
 
 func (s *pullService) ListChanges(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
@@ -50,111 +77,326 @@ func (s *pullService) ListChanges(ctx context.Context, repo string, number int,
 	return f.PullRequestChanges[number][returnStart:returnEnd], nil, nil
 }
 
+func (s *pullService) ListCommits(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Commit, *scm.Response, error) {
+	f := s.data
+	key := fmt.Sprintf("%s#%d", repo, number)
+	commits, ok := f.CommitMap[key]
+	if !ok {
+		commits = f.pullRequestCommits(repo, number)
+	}
+	returnStart, returnEnd := paginated(opts.Page, opts.Size, len(commits))
+	answer := []*scm.Commit{}
+	for i := returnStart; i < returnEnd; i++ {
+		commit := commits[i]
+		answer = append(answer, &commit)
+	}
+	return answer, nil, nil
+}
+
 func (s *pullService) ListComments(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
 	f := s.data
 	return append([]*scm.Comment{}, f.PullRequestComments[number]...), nil, nil
 }
 
 func (s *pullService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
-	f := s.data
-	re := regexp.MustCompile(fmt.Sprintf(`^%s#%d:(.*)$`, repo, number))
 	la := []*scm.Label{}
+	for _, l := range s.labels(repo, number).List() {
+		la = append(la, &scm.Label{Name: l})
+	}
+	return la, nil, nil
+}
+
+// labels returns the current labels of the pull request combining the
+// recorded label operations with the labels of the stored pull request
+func (s *pullService) labels(repo string, number int) sets.String {
+	f := s.data
+	re := regexp.MustCompile(fmt.Sprintf(`^%s#%d:(.*)$`, regexp.QuoteMeta(repo), number))
 	allLabels := sets.NewString(f.PullRequestLabelsExisting...)
 	allLabels.Insert(f.PullRequestLabelsAdded...)
 	allLabels.Delete(f.PullRequestLabelsRemoved...)
+	answer := sets.NewString()
 	for _, l := range allLabels.List() {
 		groups := re.FindStringSubmatch(l)
 		if groups != nil {
-			la = append(la, &scm.Label{Name: groups[1]})
+			answer.Insert(groups[1])
 		}
 	}
-	return la, nil, nil
+	if pr, err := f.pullRequest(repo, number); err == nil {
+		for _, l := range pr.Labels {
+			answer.Insert(l.Name)
+		}
+	}
+	return answer
 }
 
-func (s *pullService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *pullService) ListEvents(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
+	f := s.data
+	return append([]*scm.ListedIssueEvent{}, f.IssueEvents[number]...), nil, nil
 }
 
 func (s *pullService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
 	f := s.data
-	pr := s.data.PullRequests[number]
-	if pr != nil {
-		found := false
-		for _, l := range pr.Labels {
-			if l.Name == label {
-				found = true
-				break
-			}
-		}
-		if !found {
-			pr.Labels = append(pr.Labels, &scm.Label{
-				ID:   int64(len(pr.Labels)),
-				Name: label,
-			})
-		}
-	}
 	labelString := fmt.Sprintf("%s#%d:%s", repo, number, label)
-	if sets.NewString(f.PullRequestLabelsAdded...).Has(labelString) {
+	if sets.NewString(f.PullRequestLabelsAdded...).Has(labelString) && s.labels(repo, number).Has(label) {
 		return nil, fmt.Errorf("cannot add %v to %s/#%d", label, repo, number)
 	}
-	if f.RepoLabelsExisting == nil {
-		f.PullRequestLabelsAdded = append(f.PullRequestLabelsAdded, labelString)
-		return nil, nil
+	if f.RepoLabelsExisting != nil && !sets.NewString(f.RepoLabelsExisting...).Has(label) {
+		return nil, fmt.Errorf("cannot add %v to %s/#%d", label, repo, number)
 	}
-	for _, l := range f.RepoLabelsExisting {
-		if label == l {
-			f.PullRequestLabelsAdded = append(f.PullRequestLabelsAdded, labelString)
-			return nil, nil
-		}
+	if pr, err := f.pullRequest(repo, number); err == nil {
+		pr.Labels = addLabel(pr.Labels, label)
+	}
+	f.PullRequestLabelsAdded = append(f.PullRequestLabelsAdded, labelString)
+	f.labelEvent(number, "labeled", label)
+	if pr, err := f.pullRequest(repo, number); err == nil {
+		f.emitPullRequest(repo, scm.ActionLabel, pr, label)
 	}
-	return nil, fmt.Errorf("cannot add %v to %s/#%d", label, repo, number)
+	return nil, nil
 }
 
 // DeleteLabel removes a label
 func (s *pullService) DeleteLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
 	f := s.data
 	labelString := fmt.Sprintf("%s#%d:%s", repo, number, label)
-	if !sets.NewString(f.PullRequestLabelsRemoved...).Has(labelString) {
-		f.PullRequestLabelsRemoved = append(f.PullRequestLabelsRemoved, labelString)
-		return nil, nil
+	if sets.NewString(f.PullRequestLabelsRemoved...).Has(labelString) && !s.labels(repo, number).Has(label) {
+		return nil, fmt.Errorf("cannot remove %v from %s/#%d", label, repo, number)
+	}
+	if pr, err := f.pullRequest(repo, number); err == nil {
+		pr.Labels = removeLabel(pr.Labels, label)
+	}
+	f.PullRequestLabelsRemoved = append(f.PullRequestLabelsRemoved, labelString)
+	f.labelEvent(number, "unlabeled", label)
+	if pr, err := f.pullRequest(repo, number); err == nil {
+		f.emitPullRequest(repo, scm.ActionUnlabel, pr, label)
 	}
-	return nil, fmt.Errorf("cannot remove %v from %s/#%d", label, repo, number)
+	return nil, nil
 }
 
 func (s *pullService) Merge(ctx context.Context, repo string, number int, mergeOpts *scm.PullRequestMergeOptions) (*scm.Response, error) {
-	pr, ok := s.data.PullRequests[number]
-	if !ok || pr == nil {
+	f := s.data
+	pr, err := f.pullRequest(repo, number)
+	if err != nil {
 		return nil, fmt.Errorf("pull request %d not found", number)
 	}
+	if pr.Closed {
+		return &scm.Response{Status: 405}, fmt.Errorf("pull request %d is not open", number)
+	}
+	if mergeOpts == nil {
+		mergeOpts = &scm.PullRequestMergeOptions{}
+	}
+	if mergeOpts.SHA != "" && pr.Head.Sha != "" && mergeOpts.SHA != pr.Head.Sha {
+		return &scm.Response{Status: 409}, fmt.Errorf("head of pull request %d has been modified", number)
+	}
+
+	// lets record the merge commit on the base branch if we are tracking its history
+	if _, ok := f.Refs[repo][headsPrefix+pr.Base.Ref]; ok {
+		message := mergeOpts.CommitTitle
+		if message == "" {
+			message = fmt.Sprintf("Merge pull request #%d from %s", number, pr.Head.Ref)
+		}
+		var parents []string
+		if mergeOpts.MergeMethod == "" || mergeOpts.MergeMethod == "merge" {
+			parents = []string{f.Refs[repo][headsPrefix+pr.Base.Ref]}
+			if pr.Head.Sha != "" {
+				parents = append(parents, pr.Head.Sha)
+			}
+		}
+		// lets bring the files of the head across whatever the merge method
+		head := f.Files[pr.Head.Sha]
+		merge := func(files map[string][]byte) {
+			for path, data := range head {
+				files[path] = data
+			}
+		}
+		// lets mark the pull request as merged before pushing so it is not synchronized
+		pr.Closed = true
+		c := f.push(repo, pr.Base.Ref, message, scm.PushCommit{}, merge, parents...)
+		pr.MergeSha = c.Sha
+		pr.Base.Sha = c.Sha
+	}
 	pr.Merged = true
 	pr.State = "closed"
 	pr.Closed = true
 	pr.Mergeable = false
+	pr.Updated = time.Now()
+	f.emitPullRequest(repo, scm.ActionClose, pr, "")
+	if mergeOpts.DeleteSourceBranch {
+		if sha, ok := f.Refs[repo][headsPrefix+pr.Head.Ref]; ok {
+			delete(f.Refs[repo], headsPrefix+pr.Head.Ref)
+			f.emitRef(repo, scm.ActionDelete, headsPrefix+pr.Head.Ref, sha)
+		}
+	}
+	return nil, nil
+}
+
+func (s *pullService) EnableAutoMerge(ctx context.Context, repo string, number int, mergeOpts *scm.PullRequestMergeOptions) (*scm.Response, error) {
+	pr, err := s.data.pullRequest(repo, number)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	if pr.Closed {
+		return &scm.Response{Status: 405}, fmt.Errorf("pull request %d is not open", number)
+	}
+	if mergeOpts == nil {
+		mergeOpts = &scm.PullRequestMergeOptions{}
+	}
+	method := mergeOpts.MergeMethod
+	if method == "" {
+		method = "merge"
+	}
+	pr.AutoMerge = &scm.AutoMerge{
+		EnabledBy:     s.data.CurrentUser,
+		MergeMethod:   method,
+		CommitTitle:   mergeOpts.CommitTitle,
+		CommitMessage: mergeOpts.CommitMessage,
+	}
+	pr.Updated = time.Now()
+	return nil, nil
+}
+
+func (s *pullService) DisableAutoMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	pr, err := s.data.pullRequest(repo, number)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	pr.AutoMerge = nil
+	pr.Updated = time.Now()
+	return nil, nil
+}
+
+func (s *pullService) UpdateBranch(ctx context.Context, repo string, number int, method string) (*scm.Response, error) {
+	f := s.data
+	pr, err := f.pullRequest(repo, number)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	if pr.Closed {
+		return &scm.Response{Status: 405}, fmt.Errorf("pull request %d is not open", number)
+	}
+	base, ok := f.Refs[repo][headsPrefix+pr.Base.Ref]
+	if !ok {
+		return &scm.Response{Status: 422}, fmt.Errorf("base branch %s not found", pr.Base.Ref)
+	}
+
+	var message string
+	var parents []string
+	switch method {
+	case "", "merge":
+		message = fmt.Sprintf("Merge branch '%s' into %s", pr.Base.Ref, pr.Head.Ref)
+		if pr.Head.Sha != "" {
+			parents = append(parents, pr.Head.Sha)
+		}
+		parents = append(parents, base)
+	case "rebase":
+		message = fmt.Sprintf("Rebase %s onto %s", pr.Head.Ref, pr.Base.Ref)
+		parents = []string{base}
+	default:
+		return &scm.Response{Status: 422}, fmt.Errorf("unsupported update method %q", method)
+	}
+	// lets keep the changes of the head on top of the base whatever the update method
+	head := f.Files[pr.Head.Sha]
+	update := func(files map[string][]byte) {
+		for path, data := range f.Files[base] {
+			files[path] = data
+		}
+		for path, data := range head {
+			files[path] = data
+		}
+	}
+	f.push(repo, pr.Head.Ref, message, scm.PushCommit{}, update, parents...)
+	pr.Base.Sha = base
+	pr.Updated = time.Now()
+	return nil, nil
+}
+
+func (s *pullService) MarkReady(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(repo, number, false)
+}
+
+func (s *pullService) ConvertToDraft(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(repo, number, true)
+}
+
+func (s *pullService) setDraft(repo string, number int, draft bool) (*scm.Response, error) {
+	pr, err := s.data.pullRequest(repo, number)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	if pr.Draft == draft {
+		return nil, nil
+	}
+	pr.Draft = draft
+	pr.Updated = time.Now()
+	action := scm.ActionReadyForReview
+	if draft {
+		action = scm.ActionConvertedToDraft
+	}
+	s.data.emitPullRequest(repo, action, pr, "")
 	return nil, nil
 }
 
 func (s *pullService) Update(ctx context.Context, repo string, number int, prInput *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
-	panic("implement me")
+	pr, err := s.data.pullRequest(repo, number)
+	if err != nil {
+		return nil, &scm.Response{Status: 404}, err
+	}
+	if prInput.Title != "" {
+		pr.Title = prInput.Title
+	}
+	if prInput.Body != "" {
+		pr.Body = prInput.Body
+	}
+	if prInput.Base != "" {
+		pr.Base.Ref = prInput.Base
+		pr.Target = prInput.Base
+		pr.Base.Sha, _ = s.data.resolve(repo, prInput.Base)
+	}
+	pr.Updated = time.Now()
+	s.data.emitPullRequest(repo, scm.ActionEdited, pr, "")
+	return pr, nil, nil
 }
 
-func (s *pullService) Close(context.Context, string, int) (*scm.Response, error) {
-	panic("implement me")
+func (s *pullService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	pr, err := s.data.pullRequest(repo, number)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	pr.Closed = true
+	pr.State = "closed"
+	pr.Updated = time.Now()
+	s.data.emitPullRequest(repo, scm.ActionClose, pr, "")
+	return nil, nil
 }
 
-func (s *pullService) Reopen(context.Context, string, int) (*scm.Response, error) {
-	panic("implement me")
+func (s *pullService) Reopen(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	pr, err := s.data.pullRequest(repo, number)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	if pr.Merged {
+		return &scm.Response{Status: 422}, fmt.Errorf("pull request %d has already been merged", number)
+	}
+	pr.Closed = false
+	pr.State = "open"
+	pr.Updated = time.Now()
+	s.data.emitPullRequest(repo, scm.ActionReopen, pr, "")
+	return nil, nil
 }
 
 func (s *pullService) CreateComment(ctx context.Context, repo string, number int, comment *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
 	f := s.data
 	f.PullRequestCommentsAdded = append(f.PullRequestCommentsAdded, fmt.Sprintf("%s#%d:%s", repo, number, comment.Body))
+	now := time.Now()
 	answer := &scm.Comment{
-		ID:     f.IssueCommentID,
-		Body:   comment.Body,
-		Author: scm.User{Login: botName},
+		ID:      f.IssueCommentID,
+		Body:    comment.Body,
+		Author:  scm.User{Login: botName},
+		Created: now,
+		Updated: now,
 	}
 	f.PullRequestComments[number] = append(f.PullRequestComments[number], answer)
 	f.IssueCommentID++
+	f.emitComment(repo, scm.ActionCreate, number, answer)
 	return answer, nil, nil
 }
 
@@ -165,6 +407,7 @@ func (s *pullService) DeleteComment(ctx context.Context, repo string, number int
 		for i, ic := range ics {
 			if ic.ID == id {
 				f.PullRequestComments[num] = append(ics[:i], ics[i+1:]...)
+				f.emitComment(repo, scm.ActionDelete, num, ic)
 				return nil, nil
 			}
 		}
@@ -173,7 +416,16 @@ func (s *pullService) DeleteComment(ctx context.Context, repo string, number int
 }
 
 func (s *pullService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	f := s.data
+	c := findComment(f.PullRequestComments[number], id)
+	if c == nil {
+		return nil, &scm.Response{Status: 404}, fmt.Errorf("could not find pull request comment %d", id)
+	}
+	c.Body = input.Body
+	c.Version++
+	c.Updated = time.Now()
+	f.emitComment(repo, scm.ActionEdited, number, c)
+	return c, nil, nil
 }
 
 func (s *pullService) AssignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
@@ -185,6 +437,10 @@ func (s *pullService) AssignIssue(ctx context.Context, repo string, number int,
 			continue
 		}
 		f.AssigneesAdded = append(f.AssigneesAdded, fmt.Sprintf("%s#%d:%s", repo, number, a))
+		if pr, err := f.pullRequest(repo, number); err == nil {
+			pr.Assignees = addUsers(pr.Assignees, []string{a})
+			f.emitPullRequest(repo, scm.ActionAssigned, pr, "")
+		}
 	}
 	if m.Users == nil {
 		return nil, nil
@@ -193,20 +449,43 @@ func (s *pullService) AssignIssue(ctx context.Context, repo string, number int,
 }
 
 func (s *pullService) UnassignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	panic("implement me")
+	pr, err := s.data.pullRequest(repo, number)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	pr.Assignees = removeUsers(pr.Assignees, logins)
+	s.data.emitPullRequest(repo, scm.ActionUnassigned, pr, "")
+	return nil, nil
 }
 
 func (s *pullService) RequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	pr, err := s.data.pullRequest(repo, number)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	pr.Reviewers = addUsers(pr.Reviewers, logins)
+	s.data.emitPullRequest(repo, scm.ActionReviewRequested, pr, "")
+	return nil, nil
 }
 
 func (s *pullService) UnrequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	pr, err := s.data.pullRequest(repo, number)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	pr.Reviewers = removeUsers(pr.Reviewers, logins)
+	s.data.emitPullRequest(repo, scm.ActionReviewRequestRemoved, pr, "")
+	return nil, nil
 }
 
 func (s *pullService) Create(_ context.Context, fullName string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
 	f := s.data
-	f.PullRequestID++
+	for _, pr := range f.PullRequests {
+		if !pr.Closed && pr.Base.Repo.FullName == fullName && pr.Head.Ref == input.Head && pr.Base.Ref == input.Base {
+			return nil, &scm.Response{Status: 422}, fmt.Errorf("a pull request already exists for %s:%s", fullName, input.Head)
+		}
+	}
+	number := f.nextNumber(fullName)
 	namespace := ""
 	name := ""
 	paths := strings.SplitN(fullName, "/", 2)
@@ -214,31 +493,62 @@ func (s *pullService) Create(_ context.Context, fullName string, input *scm.Pull
 		namespace = paths[0]
 		name = paths[1]
 	}
+	repo := scm.Repository{
+		Namespace: namespace,
+		Name:      name,
+		FullName:  fullName,
+	}
+	headSha, _ := f.resolve(fullName, input.Head)
+	baseSha, _ := f.resolve(fullName, input.Base)
+	now := time.Now()
 	answer := &scm.PullRequest{
-		Number: f.PullRequestID,
-		Title:  input.Title,
-		Body:   input.Body,
+		Number:    number,
+		Title:     input.Title,
+		Body:      input.Body,
+		Sha:       headSha,
+		Ref:       fmt.Sprintf("refs/pull/%d/head", number),
+		Source:    input.Head,
+		Target:    input.Base,
+		State:     "open",
+		Draft:     input.Draft,
+		Mergeable: true,
+		Author:    f.CurrentUser,
 		Base: scm.PullRequestBranch{
-			Ref: input.Base,
-			Repo: scm.Repository{
-				Namespace: namespace,
-				Name:      name,
-				FullName:  fullName,
-			},
+			Ref:  input.Base,
+			Sha:  baseSha,
+			Repo: repo,
 		},
 		Head: scm.PullRequestBranch{
-			Ref: input.Head,
+			Ref:  input.Head,
+			Sha:  headSha,
+			Repo: repo,
 		},
+		Link:    fmt.Sprintf("https://fake.com/%s/pull/%d", fullName, number),
+		Created: now,
+		Updated: now,
 	}
-	f.PullRequestsCreated[f.PullRequestID] = input
-	f.PullRequests[f.PullRequestID] = answer
+	f.PullRequestsCreated[number] = input
+	f.PullRequests[number] = answer
+	f.emitPullRequest(fullName, scm.ActionOpen, answer, "")
 	return answer, nil, nil
 }
 
 func (s *pullService) SetMilestone(ctx context.Context, repo string, prID int, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	pr, err := s.data.pullRequest(repo, prID)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	s.data.MilestoneMap[fmt.Sprintf("%s#%d", repo, prID)] = number
+	pr.Milestone = scm.Milestone{Number: number, ID: number}
+	return nil, nil
 }
 
 func (s *pullService) ClearMilestone(ctx context.Context, repo string, prID int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	pr, err := s.data.pullRequest(repo, prID)
+	if err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	delete(s.data.MilestoneMap, fmt.Sprintf("%s#%d", repo, prID))
+	pr.Milestone = scm.Milestone{}
+	return nil, nil
 }
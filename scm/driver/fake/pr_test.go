@@ -82,3 +82,88 @@ func extractChangeFiles(ch []*scm.Change) []string {
 	}
 	return f
 }
+
+func TestFindMergeability(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+	data.PullRequests[1] = &scm.PullRequest{
+		Number: 1,
+		Base:   scm.PullRequestBranch{Ref: "master"},
+		Head:   scm.PullRequestBranch{Ref: "feature", Sha: "abc"},
+	}
+	data.Statuses["abc"] = []*scm.Status{{Label: "ci", State: scm.StateSuccess}}
+	data.Reviews[1] = []*scm.Review{{Author: scm.User{Login: "bob"}, State: scm.ReviewStateApproved}}
+
+	m, _, err := client.PullRequests.FindMergeability(ctx, "test/test", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Mergeable {
+		t.Errorf("Want mergeable pull request, got blockers %v", m.Blockers)
+	}
+
+	data.MergeRules["test/test:master"] = &scm.MergeRules{
+		RequiredContexts:  []string{"ci", "lint"},
+		RequiredApprovals: 2,
+	}
+	m, _, err = client.PullRequests.FindMergeability(ctx, "test/test", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []scm.MergeBlocker{scm.MergeBlockerMissingChecks, scm.MergeBlockerInsufficientApprovals}
+	if !reflect.DeepEqual(m.Blockers, want) {
+		t.Errorf("Want blockers %v, got %v", want, m.Blockers)
+	}
+
+	if _, _, err := client.PullRequests.FindMergeability(ctx, "test/test", 2); err == nil {
+		t.Errorf("Want error for missing pull request")
+	}
+}
+
+func TestListCommits(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+	data.CommitMap["test/test#1"] = []scm.Commit{{Sha: "a"}, {Sha: "b"}, {Sha: "c"}}
+
+	commits, _, err := client.PullRequests.ListCommits(ctx, "test/test", 1, scm.ListOptions{Page: 2, Size: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 1 || commits[0].Sha != "c" {
+		t.Errorf("Want the last commit on the second page, got %v", commits)
+	}
+
+	commits, _, err = client.PullRequests.ListCommits(ctx, "test/test", 2, scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 0 {
+		t.Errorf("Want no commits for an unknown pull request, got %d", len(commits))
+	}
+}
+
+func TestAutoMerge(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+	data.PullRequests[1] = &scm.PullRequest{Number: 1}
+
+	if _, err := client.PullRequests.EnableAutoMerge(ctx, "test/test", 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := &scm.AutoMerge{EnabledBy: data.CurrentUser, MergeMethod: "merge"}
+	if got := data.PullRequests[1].AutoMerge; !reflect.DeepEqual(got, want) {
+		t.Errorf("Want auto merge %+v, got %+v", want, got)
+	}
+
+	if _, err := client.PullRequests.DisableAutoMerge(ctx, "test/test", 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := data.PullRequests[1].AutoMerge; got != nil {
+		t.Errorf("Want auto merge disabled, got %+v", got)
+	}
+
+	data.PullRequests[1].Closed = true
+	if _, err := client.PullRequests.EnableAutoMerge(ctx, "test/test", 1, nil); err == nil {
+		t.Errorf("Want error enabling auto merge on a closed pull request")
+	}
+}
@@ -70,6 +70,14 @@ func (r *releaseService) Create(_ context.Context, repo string, input *scm.Relea
 		Published:   now,
 	}
 	m[id] = release
+	if r.data.emitting() {
+		r.data.emit(&scm.ReleaseHook{
+			Action:  scm.ActionCreate,
+			Repo:    r.data.repository(repo),
+			Release: *release,
+			Sender:  r.data.CurrentUser,
+		})
+	}
 	return release, nil, nil
 }
 
@@ -96,7 +104,10 @@ func (r *releaseService) Update(_ context.Context, repo string, number int, inpu
 }
 
 func (r *releaseService) UpdateByTag(ctx context.Context, repo string, tag string, input *scm.ReleaseInput) (*scm.Release, *scm.Response, error) {
-	rel, _, _ := r.FindByTag(ctx, repo, tag)
+	rel, res, err := r.FindByTag(ctx, repo, tag)
+	if err != nil {
+		return nil, res, err
+	}
 	return r.Update(ctx, repo, rel.ID, input)
 }
 
@@ -107,6 +118,9 @@ func (r *releaseService) Delete(_ context.Context, repo string, number int) (*sc
 }
 
 func (r *releaseService) DeleteByTag(ctx context.Context, repo string, tag string) (*scm.Response, error) {
-	rel, _, _ := r.FindByTag(ctx, repo, tag)
+	rel, res, err := r.FindByTag(ctx, repo, tag)
+	if err != nil {
+		return res, err
+	}
 	return r.Delete(ctx, repo, rel.ID)
 }
@@ -39,20 +39,36 @@ func (s *repositoryService) FindUserPermission(ctx context.Context, repo string,
 // NormLogin normalizes login strings
 var NormLogin = strings.ToLower
 
-func (s *repositoryService) FindHook(context.Context, string, string) (*scm.Hook, *scm.Response, error) {
-	panic("implement me")
+func (s *repositoryService) FindHook(ctx context.Context, fullName string, id string) (*scm.Hook, *scm.Response, error) {
+	for _, hook := range s.data.Hooks[fullName] {
+		if hook.ID == id {
+			return hook, nil, nil
+		}
+	}
+	return nil, &scm.Response{Status: 404}, scm.ErrNotFound
 }
 
 func (s *repositoryService) FindPerms(context.Context, string) (*scm.Perm, *scm.Response, error) {
-	panic("implement me")
+	return nil, nil, scm.ErrNotSupported
 }
 
-func (s *repositoryService) ListOrganisation(context.Context, string, scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
-	panic("implement me")
+func (s *repositoryService) ListOrganisation(ctx context.Context, org string, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	return s.listNamespace(org, opts)
 }
 
-func (s *repositoryService) ListUser(context.Context, string, scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
-	panic("implement me")
+func (s *repositoryService) ListUser(ctx context.Context, user string, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	return s.listNamespace(user, opts)
+}
+
+func (s *repositoryService) listNamespace(namespace string, opts scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	var answer []*scm.Repository
+	for _, repo := range s.data.Repositories {
+		if repo.Namespace == namespace {
+			answer = append(answer, repo)
+		}
+	}
+	returnStart, returnEnd := paginated(opts.Page, opts.Size, len(answer))
+	return answer[returnStart:returnEnd], nil, nil
 }
 
 func (s *repositoryService) AddCollaborator(ctx context.Context, repo, user, permission string) (bool, bool, *scm.Response, error) {
@@ -163,11 +179,23 @@ func (s *repositoryService) Create(ctx context.Context, input *scm.RepositoryInp
 		Namespace: namespace,
 		Name:      input.Name,
 		FullName:  fullName,
+		Branch:    defaultBranch,
+		Private:   input.Private,
 		Link:      link,
 		Clone:     link,
 		Created:   time.Now(),
 	}
 	s.data.Repositories = append(s.data.Repositories, repo)
+	if _, ok := s.data.Refs[fullName][headsPrefix+defaultBranch]; !ok {
+		s.data.commit(fullName, defaultBranch, "Initial commit")
+	}
+	if s.data.emitting() {
+		s.data.emit(&scm.RepositoryHook{
+			Action: scm.ActionCreate,
+			Repo:   *repo,
+			Sender: s.data.CurrentUser,
+		})
+	}
 	return repo, nil, nil
 }
 
@@ -183,10 +211,12 @@ func (s *repositoryService) ListHooks(ctx context.Context, fullName string, opts
 func (s *repositoryService) CreateHook(ctx context.Context, fullName string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
 	/* #nosec */
 	hook := &scm.Hook{
-		ID:     fmt.Sprintf("%d", rand.Int()),
-		Name:   input.Name,
-		Target: input.Target,
-		Active: true,
+		ID:         fmt.Sprintf("%d", rand.Int()),
+		Name:       input.Name,
+		Target:     input.Target,
+		Events:     input.NativeEvents,
+		Active:     true,
+		SkipVerify: input.SkipVerify,
 	}
 	s.data.Hooks[fullName] = append(s.data.Hooks[fullName], hook)
 	return hook, nil, nil
@@ -217,14 +247,44 @@ func (s *repositoryService) CreateStatus(ctx context.Context, repo string, ref s
 	for _, existing := range statuses {
 		if existing.Label == status.Label {
 			*existing = *status
+			s.emitStatus(repo, status)
 			return status, nil, nil
 		}
 	}
 	statuses = append(statuses, status)
 	s.data.Statuses[ref] = statuses
+	s.emitStatus(repo, status)
 	return status, nil, nil
 }
 
-func (s *repositoryService) Delete(context.Context, string) (*scm.Response, error) {
-	panic("implement me")
+func (s *repositoryService) emitStatus(repo string, status *scm.Status) {
+	if !s.data.emitting() {
+		return
+	}
+	s.data.emit(&scm.StatusHook{
+		Action: scm.ActionCreate,
+		Repo:   s.data.repository(repo),
+		Sender: s.data.CurrentUser,
+		Label:  scm.Label{Name: status.Label},
+	})
+}
+
+func (s *repositoryService) Delete(ctx context.Context, fullName string) (*scm.Response, error) {
+	repos := s.data.Repositories
+	for i, repo := range repos {
+		if repo.FullName == fullName {
+			s.data.Repositories = append(repos[0:i], repos[i+1:]...)
+			delete(s.data.Refs, fullName)
+			delete(s.data.Hooks, fullName)
+			if s.data.emitting() {
+				s.data.emit(&scm.RepositoryHook{
+					Action: scm.ActionDelete,
+					Repo:   *repo,
+					Sender: s.data.CurrentUser,
+				})
+			}
+			return nil, nil
+		}
+	}
+	return &scm.Response{Status: 404}, scm.ErrNotFound
 }
@@ -2,6 +2,8 @@ package fake
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -31,18 +33,43 @@ func (s *reviewService) List(ctx context.Context, repo string, number int, opt s
 
 func (s *reviewService) Create(ctx context.Context, repo string, number int, input *scm.ReviewInput) (*scm.Review, *scm.Response, error) {
 	f := s.data
+	sha := input.Sha
+	if pr, err := f.pullRequest(repo, number); err == nil && sha == "" {
+		sha = pr.Head.Sha
+	}
+	now := time.Now()
 	review := &scm.Review{
-		ID:     f.ReviewID,
-		Author: scm.User{Login: botName},
-		Body:   input.Body,
+		ID:      f.ReviewID,
+		Author:  scm.User{Login: botName},
+		Body:    input.Body,
+		Sha:     sha,
+		State:   reviewState(input.Event),
+		Created: now,
+		Updated: now,
 	}
 	f.Reviews[number] = append(f.Reviews[number], review)
 	f.ReviewID++
+	if pr, err := f.pullRequest(repo, number); err == nil && f.emitting() {
+		f.emit(&scm.ReviewHook{
+			Action:      scm.ActionSubmitted,
+			PullRequest: *pr,
+			Repo:        f.repository(repo),
+			Review:      *review,
+		})
+	}
 	return review, nil, nil
 }
 
-func (s *reviewService) Delete(context.Context, string, int, int) (*scm.Response, error) {
-	panic("implement me")
+func (s *reviewService) Delete(ctx context.Context, repo string, number int, reviewID int) (*scm.Response, error) {
+	f := s.data
+	reviews := f.Reviews[number]
+	for i, review := range reviews {
+		if review.ID == reviewID {
+			f.Reviews[number] = append(reviews[:i], reviews[i+1:]...)
+			return nil, nil
+		}
+	}
+	return &scm.Response{Status: 404}, scm.ErrNotFound
 }
 
 func (s *reviewService) ListComments(ctx context.Context, repo string, prID int, reviewID int, options scm.ListOptions) ([]*scm.ReviewComment, *scm.Response, error) {
@@ -58,5 +85,133 @@ func (s *reviewService) Submit(ctx context.Context, repo string, prID int, revie
 }
 
 func (s *reviewService) Dismiss(ctx context.Context, repo string, prID int, reviewID int, msg string) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	review, _, err := s.Find(ctx, repo, prID, reviewID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if review == nil {
+		return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	review.State = scm.ReviewStateDismissed
+	review.Updated = time.Now()
+	if pr, err := s.data.pullRequest(repo, prID); err == nil && s.data.emitting() {
+		s.data.emit(&scm.ReviewHook{
+			Action:      scm.ActionDismissed,
+			PullRequest: *pr,
+			Repo:        s.data.repository(repo),
+			Review:      *review,
+		})
+	}
+	return review, nil, nil
+}
+
+func (s *reviewService) CreateComment(ctx context.Context, repo string, number int, input *scm.ReviewCommentInput) (*scm.ReviewComment, *scm.Response, error) {
+	f := s.data
+	pr, err := f.pullRequest(repo, number)
+	if err != nil {
+		return nil, &scm.Response{Status: 404}, err
+	}
+	f.ReviewCommentID++
+	now := time.Now()
+	comment := &scm.ReviewComment{
+		ID:      f.ReviewCommentID,
+		Body:    input.Body,
+		Author:  scm.User{Login: botName},
+		Created: now,
+		Updated: now,
+	}
+	if input.ThreadID != "" {
+		thread := f.reviewThread(number, input.ThreadID)
+		if thread == nil {
+			return nil, &scm.Response{Status: 404}, scm.ErrNotFound
+		}
+		root := thread.Comments[0]
+		comment.Path = root.Path
+		comment.Sha = root.Sha
+		comment.Line = root.Line
+		comment.StartLine = root.StartLine
+		comment.Side = root.Side
+		comment.ThreadID = thread.ID
+		comment.InReplyTo = root.ID
+		thread.Comments = append(thread.Comments, comment)
+		return comment, nil, nil
+	}
+
+	sha := input.Sha
+	if sha == "" {
+		sha = pr.Head.Sha
+	}
+	side := input.Side
+	if side == "" {
+		side = scm.ReviewSideRight
+	}
+	comment.Path = input.Path
+	comment.Sha = sha
+	comment.Line = input.Line
+	comment.StartLine = input.StartLine
+	comment.Side = side
+	comment.ThreadID = strconv.Itoa(comment.ID)
+	f.ReviewThreads[number] = append(f.ReviewThreads[number], &scm.ReviewThread{
+		ID:        comment.ThreadID,
+		Path:      comment.Path,
+		Line:      comment.Line,
+		StartLine: comment.StartLine,
+		Side:      comment.Side,
+		Comments:  []*scm.ReviewComment{comment},
+	})
+	return comment, nil, nil
+}
+
+func (s *reviewService) ListThreads(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.ReviewThread, *scm.Response, error) {
+	f := s.data
+	if _, err := f.pullRequest(repo, number); err != nil {
+		return nil, &scm.Response{Status: 404}, err
+	}
+	threads := f.ReviewThreads[number]
+	start, end := paginated(opts.Page, opts.Size, len(threads))
+	return append([]*scm.ReviewThread{}, threads[start:end]...), nil, nil
+}
+
+func (s *reviewService) ResolveThread(ctx context.Context, repo string, number int, threadID string) (*scm.Response, error) {
+	return s.resolveThread(repo, number, threadID, true)
+}
+
+func (s *reviewService) UnresolveThread(ctx context.Context, repo string, number int, threadID string) (*scm.Response, error) {
+	return s.resolveThread(repo, number, threadID, false)
+}
+
+func (s *reviewService) resolveThread(repo string, number int, threadID string, resolved bool) (*scm.Response, error) {
+	if _, err := s.data.pullRequest(repo, number); err != nil {
+		return &scm.Response{Status: 404}, err
+	}
+	thread := s.data.reviewThread(number, threadID)
+	if thread == nil {
+		return &scm.Response{Status: 404}, scm.ErrNotFound
+	}
+	thread.Resolved = resolved
+	return nil, nil
+}
+
+// reviewThread returns the review thread on the pull request with the given id
+func (d *Data) reviewThread(number int, threadID string) *scm.ReviewThread {
+	for _, thread := range d.ReviewThreads[number] {
+		if thread.ID == threadID {
+			return thread
+		}
+	}
+	return nil
+}
+
+// reviewState converts the review event into the resulting review state
+func reviewState(event string) string {
+	switch event {
+	case "APPROVE":
+		return scm.ReviewStateApproved
+	case "REQUEST_CHANGES":
+		return scm.ReviewStateChangesRequested
+	case "COMMENT":
+		return scm.ReviewStateCommented
+	default:
+		return scm.ReviewStatePending
+	}
 }
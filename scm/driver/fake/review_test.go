@@ -0,0 +1,63 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+func TestReviewThreads(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+	data.PullRequests[1] = &scm.PullRequest{
+		Number: 1,
+		Head:   scm.PullRequestBranch{Ref: "feature", Sha: "abc"},
+	}
+
+	comment, _, err := client.Reviews.CreateComment(ctx, "test/test", 1, &scm.ReviewCommentInput{
+		Body:      "Should this be a constant?",
+		Path:      "main.go",
+		StartLine: 10,
+		Line:      12,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment.Sha != "abc" || comment.Side != scm.ReviewSideRight || comment.ThreadID == "" {
+		t.Errorf("Unexpected comment %+v", comment)
+	}
+
+	reply, _, err := client.Reviews.CreateComment(ctx, "test/test", 1, &scm.ReviewCommentInput{
+		Body:     "Done",
+		ThreadID: comment.ThreadID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.InReplyTo != comment.ID || reply.Path != "main.go" || reply.Line != 12 {
+		t.Errorf("Unexpected reply %+v", reply)
+	}
+
+	if _, err := client.Reviews.ResolveThread(ctx, "test/test", 1, comment.ThreadID); err != nil {
+		t.Fatal(err)
+	}
+	threads, _, err := client.Reviews.ListThreads(ctx, "test/test", 1, scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(threads) != 1 || !threads[0].Resolved || len(threads[0].Comments) != 2 || threads[0].StartLine != 10 {
+		t.Errorf("Unexpected threads %+v", threads)
+	}
+
+	if _, err := client.Reviews.UnresolveThread(ctx, "test/test", 1, comment.ThreadID); err != nil {
+		t.Fatal(err)
+	}
+	if threads[0].Resolved {
+		t.Errorf("Want the thread to be unresolved")
+	}
+
+	if _, _, err := client.Reviews.CreateComment(ctx, "test/test", 1, &scm.ReviewCommentInput{ThreadID: "missing"}); err != scm.ErrNotFound {
+		t.Errorf("Want not found replying to a missing thread, got %v", err)
+	}
+}
@@ -0,0 +1,243 @@
+package fake
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type searchService struct {
+	client *wrapper
+	data   *Data
+}
+
+func (s *searchService) Repositories(ctx context.Context, q scm.SearchQuery) (*scm.RepositorySearchResults, *scm.Response, error) {
+	var answer []*scm.Repository
+	for _, repo := range s.data.Repositories {
+		if !matchesRepository(q, repo.FullName) {
+			continue
+		}
+		if !containsTerms(q.Terms, repo.Name, repo.FullName) {
+			continue
+		}
+		answer = append(answer, repo)
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].FullName < answer[j].FullName
+	})
+	start, end := paginated(q.Page, q.Size, len(answer))
+	return &scm.RepositorySearchResults{
+		Total: len(answer),
+		Items: answer[start:end],
+	}, nil, nil
+}
+
+func (s *searchService) Issues(ctx context.Context, q scm.SearchQuery) (*scm.IssueSearchResults, *scm.Response, error) {
+	f := s.data
+	var answer []*scm.SearchIssue
+	for number, slice := range f.Issues {
+		for _, issue := range slice {
+			if issue.PullRequest {
+				continue
+			}
+			fullName := f.IssueRepos[number]
+			if !matchesRepository(q, fullName) {
+				continue
+			}
+			if !matchesState(q, issue.Closed, false) || !containsTerms(q.Terms, issue.Title, issue.Body) {
+				continue
+			}
+			if !matchesFilters(q, issue.Labels, issue.Author.Login, issue.Updated) {
+				continue
+			}
+			answer = append(answer, &scm.SearchIssue{
+				Issue:      *issue,
+				Repository: f.repository(fullName),
+			})
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Number < answer[j].Number
+	})
+	start, end := paginated(q.Page, q.Size, len(answer))
+	return &scm.IssueSearchResults{
+		Total: len(answer),
+		Items: answer[start:end],
+	}, nil, nil
+}
+
+func (s *searchService) PullRequests(ctx context.Context, q scm.SearchQuery) (*scm.PullRequestSearchResults, *scm.Response, error) {
+	var answer []*scm.SearchPullRequest
+	for _, pr := range s.data.PullRequests {
+		repo := pr.Repository()
+		if repo.FullName == "" {
+			repo.FullName = scm.Join(repo.Namespace, repo.Name)
+		}
+		if !matchesRepository(q, repo.FullName) {
+			continue
+		}
+		if !matchesState(q, pr.Closed, pr.Merged) || !containsTerms(q.Terms, pr.Title, pr.Body) {
+			continue
+		}
+		var labels []string
+		for _, l := range pr.Labels {
+			labels = append(labels, l.Name)
+		}
+		if !matchesFilters(q, labels, pr.Author.Login, pr.Updated) {
+			continue
+		}
+		answer = append(answer, &scm.SearchPullRequest{
+			PullRequest: *pr,
+			Repository:  repo,
+		})
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Number < answer[j].Number
+	})
+	start, end := paginated(q.Page, q.Size, len(answer))
+	return &scm.PullRequestSearchResults{
+		Total: len(answer),
+		Items: answer[start:end],
+	}, nil, nil
+}
+
+// Code searches the files on the default branch of the repositories
+func (s *searchService) Code(ctx context.Context, q scm.SearchQuery) (*scm.CodeSearchResults, *scm.Response, error) {
+	f := s.data
+	var answer []*scm.CodeResult
+	for fullName := range f.Refs {
+		if !matchesRepository(q, fullName) {
+			continue
+		}
+		sha, ok := f.resolve(fullName, defaultBranch)
+		if !ok {
+			continue
+		}
+		for name, data := range f.Files[sha] {
+			if !containsTerms(q.Terms, string(data)) {
+				continue
+			}
+			answer = append(answer, &scm.CodeResult{
+				Name:       path.Base(name),
+				Path:       name,
+				Ref:        defaultBranch,
+				Repository: f.repository(fullName),
+			})
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		if answer[i].Repository.FullName != answer[j].Repository.FullName {
+			return answer[i].Repository.FullName < answer[j].Repository.FullName
+		}
+		return answer[i].Path < answer[j].Path
+	})
+	start, end := paginated(q.Page, q.Size, len(answer))
+	return &scm.CodeSearchResults{
+		Total: len(answer),
+		Items: answer[start:end],
+	}, nil, nil
+}
+
+// Commits searches the messages of the commits on the branches of the repositories
+func (s *searchService) Commits(ctx context.Context, q scm.SearchQuery) (*scm.CommitSearchResults, *scm.Response, error) {
+	f := s.data
+	var answer []*scm.SearchCommit
+	for fullName := range f.Refs {
+		if !matchesRepository(q, fullName) {
+			continue
+		}
+		seen := map[string]bool{}
+		for _, branch := range f.listRefs(fullName, headsPrefix) {
+			for _, c := range f.history(branch.Sha) {
+				if seen[c.Sha] {
+					continue
+				}
+				seen[c.Sha] = true
+				if !containsTerms(q.Terms, c.Message) {
+					continue
+				}
+				if q.Author != "" && q.Author != c.Author.Login {
+					continue
+				}
+				answer = append(answer, &scm.SearchCommit{
+					Commit:     *c,
+					Repository: f.repository(fullName),
+				})
+			}
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Author.Date.After(answer[j].Author.Date)
+	})
+	start, end := paginated(q.Page, q.Size, len(answer))
+	return &scm.CommitSearchResults{
+		Total: len(answer),
+		Items: answer[start:end],
+	}, nil, nil
+}
+
+// matchesRepository returns true if the repository is within the repository
+// or organisation the query is scoped to
+func matchesRepository(q scm.SearchQuery, fullName string) bool {
+	if q.Repo != "" && q.Repo != fullName {
+		return false
+	}
+	if q.Org != "" {
+		namespace, _ := scm.Split(fullName)
+		return namespace == q.Org
+	}
+	return true
+}
+
+func matchesState(q scm.SearchQuery, closed, merged bool) bool {
+	switch q.State {
+	case "open":
+		return !closed
+	case "closed":
+		return closed
+	case "merged":
+		return merged
+	}
+	return true
+}
+
+func matchesFilters(q scm.SearchQuery, labels []string, author string, updated time.Time) bool {
+	if q.Author != "" && q.Author != author {
+		return false
+	}
+	if !q.UpdatedAfter.IsZero() && updated.Before(q.UpdatedAfter) {
+		return false
+	}
+	if !q.UpdatedBefore.IsZero() && updated.After(q.UpdatedBefore) {
+		return false
+	}
+	for _, want := range q.Labels {
+		found := false
+		for _, l := range labels {
+			if l == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// containsTerms returns true if every term of the search appears in one
+// of the given texts ignoring case
+func containsTerms(terms string, texts ...string) bool {
+	text := strings.ToLower(strings.Join(texts, "\n"))
+	for _, term := range strings.Fields(strings.ToLower(terms)) {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/search"
+	data.ContentDir = ""
+
+	_, _, err := client.Repositories.Create(ctx, &scm.RepositoryInput{Namespace: "myorg", Name: "search"})
+	require.NoError(t, err, "failed to create repository")
+	_, _, err = client.Repositories.Create(ctx, &scm.RepositoryInput{Namespace: "other", Name: "search"})
+	require.NoError(t, err, "failed to create repository")
+
+	_, err = client.Contents.Create(ctx, repo, "docs/install.md", &scm.ContentParams{Branch: "master", Message: "document the installation", Data: []byte("# Installation")})
+	require.NoError(t, err, "failed to create file")
+
+	issue, _, err := client.Issues.Create(ctx, repo, &scm.IssueInput{Title: "Crash on windows", Body: "it crashes"})
+	require.NoError(t, err, "failed to create issue")
+	_, _, err = client.Issues.Create(ctx, repo, &scm.IssueInput{Title: "Slow on linux"})
+	require.NoError(t, err, "failed to create issue")
+
+	_, _, err = client.Git.CreateRef(ctx, repo, "refs/heads/feature", data.Refs[repo]["heads/master"])
+	require.NoError(t, err, "failed to create feature branch")
+	pr, _, err := client.PullRequests.Create(ctx, repo, &scm.PullRequestInput{Title: "Fix the windows crash", Head: "feature", Base: "master"})
+	require.NoError(t, err, "failed to create pull request")
+
+	repos, _, err := client.Search.Repositories(ctx, scm.SearchQuery{Terms: "search", Org: "myorg"})
+	require.NoError(t, err, "failed to search repositories")
+	require.Len(t, repos.Items, 1, "repositories")
+	assert.Equal(t, repo, repos.Items[0].FullName)
+
+	issues, _, err := client.Search.Issues(ctx, scm.SearchQuery{Terms: "windows", Repo: repo, State: "open"})
+	require.NoError(t, err, "failed to search issues")
+	require.Len(t, issues.Items, 1, "issues")
+	assert.Equal(t, issue.Number, issues.Items[0].Number)
+	assert.Equal(t, repo, issues.Items[0].Repository.FullName)
+
+	pulls, _, err := client.Search.PullRequests(ctx, scm.SearchQuery{Terms: "windows", Org: "myorg"})
+	require.NoError(t, err, "failed to search pull requests")
+	require.Len(t, pulls.Items, 1, "pull requests")
+	assert.Equal(t, pr.Number, pulls.Items[0].Number)
+
+	pulls, _, err = client.Search.PullRequests(ctx, scm.SearchQuery{Repo: repo, State: "merged"})
+	require.NoError(t, err, "failed to search pull requests")
+	assert.Empty(t, pulls.Items, "no pull request is merged")
+
+	code, _, err := client.Search.Code(ctx, scm.SearchQuery{Terms: "installation", Repo: repo})
+	require.NoError(t, err, "failed to search code")
+	require.Len(t, code.Items, 1, "code results")
+	assert.Equal(t, "docs/install.md", code.Items[0].Path)
+	assert.Equal(t, "install.md", code.Items[0].Name)
+
+	commits, _, err := client.Search.Commits(ctx, scm.SearchQuery{Terms: "installation", Repo: repo})
+	require.NoError(t, err, "failed to search commits")
+	require.Len(t, commits.Items, 1, "commits")
+	assert.Equal(t, "document the installation", commits.Items[0].Message)
+}
@@ -0,0 +1,1577 @@
+package server
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type githubUser struct {
+	ID        int    `json:"id"`
+	Login     string `json:"login"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	AvatarURL string `json:"avatar_url"`
+	HTMLURL   string `json:"html_url,omitempty"`
+}
+
+type githubRepository struct {
+	ID            int        `json:"id"`
+	Owner         githubUser `json:"owner"`
+	Name          string     `json:"name"`
+	FullName      string     `json:"full_name"`
+	Private       bool       `json:"private"`
+	Archived      bool       `json:"archived"`
+	HTMLURL       string     `json:"html_url"`
+	SSHURL        string     `json:"ssh_url"`
+	CloneURL      string     `json:"clone_url"`
+	DefaultBranch string     `json:"default_branch"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	Permissions   struct {
+		Admin bool `json:"admin"`
+		Push  bool `json:"push"`
+		Pull  bool `json:"pull"`
+	} `json:"permissions"`
+}
+
+type githubLabel struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+type githubPullRequestBranch struct {
+	Ref  string           `json:"ref"`
+	Sha  string           `json:"sha"`
+	User githubUser       `json:"user"`
+	Repo githubRepository `json:"repo"`
+}
+
+type githubMilestone struct {
+	ID     int    `json:"id"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+}
+
+type githubPullRequest struct {
+	Number             int                     `json:"number"`
+	State              string                  `json:"state"`
+	Title              string                  `json:"title"`
+	Body               string                  `json:"body"`
+	Labels             []*githubLabel          `json:"labels"`
+	DiffURL            string                  `json:"diff_url"`
+	HTMLURL            string                  `json:"html_url"`
+	User               githubUser              `json:"user"`
+	RequestedReviewers []githubUser            `json:"requested_reviewers"`
+	Assignees          []githubUser            `json:"assignees"`
+	Head               githubPullRequestBranch `json:"head"`
+	Base               githubPullRequestBranch `json:"base"`
+	Draft              bool                    `json:"draft"`
+	Merged             bool                    `json:"merged"`
+	Mergeable          bool                    `json:"mergeable"`
+	MergeableState     string                  `json:"mergeable_state"`
+	Rebaseable         bool                    `json:"rebaseable"`
+	MergeSha           string                  `json:"merge_commit_sha"`
+	Milestone          *githubMilestone        `json:"milestone"`
+	CreatedAt          time.Time               `json:"created_at"`
+	UpdatedAt          time.Time               `json:"updated_at"`
+}
+
+type githubIssue struct {
+	ID          int            `json:"id"`
+	Number      int            `json:"number"`
+	HTMLURL     string         `json:"html_url"`
+	State       string         `json:"state"`
+	Title       string         `json:"title"`
+	Body        string         `json:"body"`
+	User        githubUser     `json:"user"`
+	ClosedBy    *githubUser    `json:"closed_by"`
+	Labels      []*githubLabel `json:"labels"`
+	Assignees   []githubUser   `json:"assignees"`
+	Locked      bool           `json:"locked"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	PullRequest *struct{}      `json:"pull_request,omitempty"`
+}
+
+type githubComment struct {
+	ID        int        `json:"id"`
+	HTMLURL   string     `json:"html_url"`
+	User      githubUser `json:"user"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type githubIssueEvent struct {
+	Event   string      `json:"event"`
+	Actor   githubUser  `json:"actor"`
+	Label   githubLabel `json:"label"`
+	Created time.Time   `json:"created_at"`
+}
+
+type githubSignature struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+type githubCommit struct {
+	Sha     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
+		Tree struct {
+			Sha string `json:"sha"`
+			URL string `json:"url"`
+		} `json:"tree"`
+		Author    githubSignature `json:"author"`
+		Committer githubSignature `json:"committer"`
+		Message   string          `json:"message"`
+	} `json:"commit"`
+	Author    githubUser `json:"author"`
+	Committer githubUser `json:"committer"`
+}
+
+type githubBranch struct {
+	Name      string       `json:"name"`
+	Commit    githubCommit `json:"commit"`
+	Protected bool         `json:"protected"`
+}
+
+type githubRef struct {
+	Ref    string `json:"ref"`
+	Object struct {
+		Type string `json:"type"`
+		Sha  string `json:"sha"`
+	} `json:"object"`
+}
+
+type githubContent struct {
+	Type     string `json:"type"`
+	Encoding string `json:"encoding,omitempty"`
+	Size     int    `json:"size"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Content  string `json:"content,omitempty"`
+	Sha      string `json:"sha"`
+	URL      string `json:"url"`
+}
+
+type githubContentInput struct {
+	Message string `json:"message"`
+	Content []byte `json:"content"`
+	Sha     string `json:"sha"`
+	Branch  string `json:"branch"`
+}
+
+type githubStatus struct {
+	State       string    `json:"state"`
+	TargetURL   string    `json:"target_url"`
+	Description string    `json:"description"`
+	Context     string    `json:"context"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type githubCombinedStatus struct {
+	Sha      string          `json:"sha"`
+	State    string          `json:"state"`
+	Statuses []*githubStatus `json:"statuses"`
+}
+
+type githubHook struct {
+	ID     int      `json:"id"`
+	Name   string   `json:"name"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+	Config struct {
+		URL         string `json:"url"`
+		Secret      string `json:"secret,omitempty"`
+		ContentType string `json:"content_type"`
+		InsecureSSL string `json:"insecure_ssl"`
+	} `json:"config"`
+}
+
+func (s *Server) registerGitHub() {
+	s.handle("GET", "user", s.githubFindUser)
+	s.handle("GET", "user/repos", s.githubListUserRepositories)
+	s.handle("POST", "user/repos", s.githubCreateRepository)
+	s.handle("GET", "users/:owner/repos", s.githubListRepositories)
+	s.handle("GET", "orgs/:owner/repos", s.githubListRepositories)
+	s.handle("POST", "orgs/:owner/repos", s.githubCreateRepository)
+
+	s.handle("GET", "repos/:owner/:repo", s.githubFindRepository)
+	s.handle("DELETE", "repos/:owner/:repo", s.githubDeleteRepository)
+
+	s.handle("GET", "repos/:owner/:repo/branches", s.githubListBranches)
+	s.handle("GET", "repos/:owner/:repo/branches/*branch", s.githubFindBranch)
+	s.handle("POST", "repos/:owner/:repo/git/refs", s.githubCreateRef)
+	s.handle("GET", "repos/:owner/:repo/git/refs/*ref", s.githubFindRef)
+	s.handle("DELETE", "repos/:owner/:repo/git/refs/*ref", s.githubDeleteRef)
+	s.handle("GET", "repos/:owner/:repo/commits", s.githubListCommits)
+	s.handle("GET", "repos/:owner/:repo/commits/:ref", s.githubFindCommit)
+	s.handle("GET", "repos/:owner/:repo/commits/:ref/status", s.githubFindCombinedStatus)
+
+	s.handle("GET", "repos/:owner/:repo/contents/*path", s.githubFindContent)
+	s.handle("PUT", "repos/:owner/:repo/contents/*path", s.githubWriteContent)
+	s.handle("DELETE", "repos/:owner/:repo/contents/*path", s.githubDeleteContent)
+
+	s.handle("GET", "repos/:owner/:repo/pulls", s.githubListPullRequests)
+	s.handle("POST", "repos/:owner/:repo/pulls", s.githubCreatePullRequest)
+	s.handle("GET", "repos/:owner/:repo/pulls/:number", s.githubFindPullRequest)
+	s.handle("PATCH", "repos/:owner/:repo/pulls/:number", s.githubUpdatePullRequest)
+	s.handle("GET", "repos/:owner/:repo/pulls/:number/commits", s.githubListPullRequestCommits)
+	s.handle("PUT", "repos/:owner/:repo/pulls/:number/merge", s.githubMergePullRequest)
+	s.handle("POST", "repos/:owner/:repo/pulls/:number/requested_reviewers", s.githubRequestReview)
+	s.handle("DELETE", "repos/:owner/:repo/pulls/:number/requested_reviewers", s.githubRequestReview)
+
+	s.handle("GET", "repos/:owner/:repo/issues", s.githubListIssues)
+	s.handle("POST", "repos/:owner/:repo/issues", s.githubCreateIssue)
+	s.handle("GET", "repos/:owner/:repo/issues/comments/:id", s.githubFindComment)
+	s.handle("PATCH", "repos/:owner/:repo/issues/comments/:id", s.githubEditComment)
+	s.handle("DELETE", "repos/:owner/:repo/issues/comments/:id", s.githubDeleteComment)
+	s.handle("GET", "repos/:owner/:repo/issues/:number", s.githubFindIssue)
+	s.handle("PATCH", "repos/:owner/:repo/issues/:number", s.githubUpdateIssue)
+	s.handle("GET", "repos/:owner/:repo/issues/:number/comments", s.githubListComments)
+	s.handle("POST", "repos/:owner/:repo/issues/:number/comments", s.githubCreateComment)
+	s.handle("GET", "repos/:owner/:repo/issues/:number/labels", s.githubListIssueLabels)
+	s.handle("POST", "repos/:owner/:repo/issues/:number/labels", s.githubAddLabels)
+	s.handle("DELETE", "repos/:owner/:repo/issues/:number/labels/:label", s.githubDeleteLabel)
+	s.handle("GET", "repos/:owner/:repo/issues/:number/events", s.githubListEvents)
+	s.handle("PUT", "repos/:owner/:repo/issues/:number/lock", s.githubLock)
+	s.handle("DELETE", "repos/:owner/:repo/issues/:number/lock", s.githubLock)
+	s.handle("POST", "repos/:owner/:repo/issues/:number/assignees", s.githubAssign)
+	s.handle("DELETE", "repos/:owner/:repo/issues/:number/assignees", s.githubAssign)
+	s.handle("GET", "repos/:owner/:repo/labels", s.githubListLabels)
+
+	s.handle("GET", "repos/:owner/:repo/statuses/:ref", s.githubListStatus)
+	s.handle("POST", "repos/:owner/:repo/statuses/:ref", s.githubCreateStatus)
+
+	s.handle("GET", "repos/:owner/:repo/hooks", s.githubListHooks)
+	s.handle("POST", "repos/:owner/:repo/hooks", s.githubCreateHook)
+	s.handle("GET", "repos/:owner/:repo/hooks/:id", s.githubFindHook)
+	s.handle("DELETE", "repos/:owner/:repo/hooks/:id", s.githubDeleteHook)
+}
+
+func githubRepoName(p params) string {
+	return scm.Join(p["owner"], p["repo"])
+}
+
+func (s *Server) githubFindUser(w http.ResponseWriter, r *http.Request, p params) {
+	user, res, err := s.Client.Users.Find(r.Context())
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, githubUserFrom(user))
+}
+
+func (s *Server) githubListUserRepositories(w http.ResponseWriter, r *http.Request, p params) {
+	p["owner"] = s.Data.CurrentUser.Login
+	s.githubListRepositories(w, r, p)
+}
+
+func (s *Server) githubListRepositories(w http.ResponseWriter, r *http.Request, p params) {
+	out := []*githubRepository{}
+	for _, repo := range s.Data.Repositories {
+		if repo.Namespace == p["owner"] {
+			out = append(out, s.githubRepositoryFrom(repo))
+		}
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) githubCreateRepository(w http.ResponseWriter, r *http.Request, p params) {
+	in := struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Homepage    string `json:"homepage"`
+		Private     bool   `json:"private"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	namespace := p["owner"]
+	if namespace == "" {
+		namespace = s.Data.CurrentUser.Login
+	}
+	if _, exists := s.findRepository(scm.Join(namespace, in.Name)); exists {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"message": "name already exists on this account"})
+		return
+	}
+	repo, res, err := s.Client.Repositories.Create(r.Context(), &scm.RepositoryInput{
+		Namespace:   namespace,
+		Name:        in.Name,
+		Description: in.Description,
+		Homepage:    in.Homepage,
+		Private:     in.Private,
+	})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.githubRepositoryFrom(repo))
+}
+
+func (s *Server) githubFindRepository(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.findRepository(githubRepoName(p))
+	if !ok {
+		notFound(w, "repository %s not found", githubRepoName(p))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.githubRepositoryFrom(repo))
+}
+
+func (s *Server) githubDeleteRepository(w http.ResponseWriter, r *http.Request, p params) {
+	res, err := s.Client.Repositories.Delete(r.Context(), githubRepoName(p))
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) githubListBranches(w http.ResponseWriter, r *http.Request, p params) {
+	refs, res, err := s.Client.Git.ListBranches(r.Context(), githubRepoName(p), scm.ListOptions{})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*githubBranch{}
+	for _, ref := range refs {
+		out = append(out, s.githubBranchFrom(githubRepoName(p), ref))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) githubFindBranch(w http.ResponseWriter, r *http.Request, p params) {
+	ref, res, err := s.Client.Git.FindBranch(r.Context(), githubRepoName(p), p["branch"])
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.githubBranchFrom(githubRepoName(p), ref))
+}
+
+func (s *Server) githubCreateRef(w http.ResponseWriter, r *http.Request, p params) {
+	in := struct {
+		Ref string `json:"ref"`
+		Sha string `json:"sha"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	if !strings.HasPrefix(in.Ref, "refs/") || in.Sha == "" {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"message": "Reference name must start with 'refs/'"})
+		return
+	}
+	ref, res, err := s.Client.Git.CreateRef(r.Context(), githubRepoName(p), in.Ref, in.Sha)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, githubRefFrom(ref.Path, ref.Sha))
+}
+
+func (s *Server) githubFindRef(w http.ResponseWriter, r *http.Request, p params) {
+	repo := githubRepoName(p)
+	name := strings.TrimPrefix(p["ref"], "refs/")
+	var ref *scm.Reference
+	var err error
+	var res *scm.Response
+	switch {
+	case strings.HasPrefix(name, "heads/"):
+		ref, res, err = s.Client.Git.FindBranch(r.Context(), repo, strings.TrimPrefix(name, "heads/"))
+	case strings.HasPrefix(name, "tags/"):
+		ref, res, err = s.Client.Git.FindTag(r.Context(), repo, strings.TrimPrefix(name, "tags/"))
+	default:
+		notFound(w, "reference %s not found", name)
+		return
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, githubRefFrom("refs/"+name, ref.Sha))
+}
+
+func (s *Server) githubDeleteRef(w http.ResponseWriter, r *http.Request, p params) {
+	repo := githubRepoName(p)
+	name := strings.TrimPrefix(p["ref"], "refs/")
+	if _, ok := s.Data.Refs[repo][name]; !ok {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"message": "Reference does not exist"})
+		return
+	}
+	res, err := s.Client.Git.DeleteRef(r.Context(), repo, name)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) githubListCommits(w http.ResponseWriter, r *http.Request, p params) {
+	ref := r.URL.Query().Get("sha")
+	if ref == "" {
+		ref = r.URL.Query().Get("ref")
+	}
+	if ref == "" {
+		if repo, ok := s.findRepository(githubRepoName(p)); ok {
+			ref = repo.Branch
+		}
+	}
+	commits, res, err := s.Client.Git.ListCommits(r.Context(), githubRepoName(p), scm.CommitListOptions{Ref: ref})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*githubCommit{}
+	for _, c := range commits {
+		out = append(out, githubCommitFrom(c))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) githubFindCommit(w http.ResponseWriter, r *http.Request, p params) {
+	c, ok := s.Data.Commits[s.sha(r, githubRepoName(p), p["ref"])]
+	if !ok {
+		notFound(w, "no commit found for SHA: %s", p["ref"])
+		return
+	}
+	writeJSON(w, http.StatusOK, githubCommitFrom(c))
+}
+
+func (s *Server) githubFindContent(w http.ResponseWriter, r *http.Request, p params) {
+	repo := githubRepoName(p)
+	ref := r.URL.Query().Get("ref")
+	path := p["path"]
+	if path != "" {
+		c, _, err := s.Client.Contents.Find(r.Context(), repo, path, ref)
+		if err == nil {
+			writeJSON(w, http.StatusOK, &githubContent{
+				Type:     "file",
+				Encoding: "base64",
+				Size:     len(c.Data),
+				Name:     path[strings.LastIndex(path, "/")+1:],
+				Path:     c.Path,
+				Content:  base64.StdEncoding.EncodeToString(c.Data),
+				Sha:      c.Sha,
+				URL:      fmt.Sprintf("%s/repos/%s/contents/%s", s.URL, repo, path),
+			})
+			return
+		}
+	}
+	entries, res, err := s.Client.Contents.List(r.Context(), repo, path, ref)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*githubContent{}
+	for _, e := range entries {
+		out = append(out, &githubContent{
+			Type: e.Type,
+			Size: e.Size,
+			Name: e.Name,
+			Path: e.Path,
+			Sha:  e.Sha,
+			URL:  fmt.Sprintf("%s/repos/%s/contents/%s", s.URL, repo, e.Path),
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) githubWriteContent(w http.ResponseWriter, r *http.Request, p params) {
+	in := &githubContentInput{}
+	if !decode(w, r, in) {
+		return
+	}
+	params := &scm.ContentParams{
+		Branch:  in.Branch,
+		Message: in.Message,
+		Data:    in.Content,
+		Sha:     in.Sha,
+	}
+	repo := githubRepoName(p)
+	_, _, err := s.Client.Contents.Find(r.Context(), repo, p["path"], in.Branch)
+	exists := err == nil
+	if exists && in.Sha == "" {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"message": `"sha" wasn't supplied`})
+		return
+	}
+	status := http.StatusCreated
+	var res *scm.Response
+	if exists {
+		status = http.StatusOK
+		res, err = s.Client.Contents.Update(r.Context(), repo, p["path"], params)
+	} else {
+		res, err = s.Client.Contents.Create(r.Context(), repo, p["path"], params)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	s.githubWriteContentResult(w, r, repo, p["path"], in.Branch, status)
+}
+
+func (s *Server) githubDeleteContent(w http.ResponseWriter, r *http.Request, p params) {
+	in := &githubContentInput{}
+	if !decode(w, r, in) {
+		return
+	}
+	repo := githubRepoName(p)
+	c, res, err := s.Client.Contents.Find(r.Context(), repo, p["path"], in.Branch)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	if in.Sha != c.Sha {
+		writeJSON(w, http.StatusConflict, map[string]string{"message": fmt.Sprintf("%s does not match %s", p["path"], in.Sha)})
+		return
+	}
+	res, err = s.Client.Contents.Delete(r.Context(), repo, p["path"], &scm.ContentParams{Branch: in.Branch, Message: in.Message, Sha: in.Sha})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	s.githubWriteContentResult(w, r, repo, "", in.Branch, http.StatusOK)
+}
+
+// githubWriteContentResult writes the file and commit resulting from a content change
+func (s *Server) githubWriteContentResult(w http.ResponseWriter, r *http.Request, repo, path, branch string, status int) {
+	if branch == "" {
+		branch = s.Data.Repositories[0].Branch
+		if found, ok := s.findRepository(repo); ok {
+			branch = found.Branch
+		}
+	}
+	out := struct {
+		Content *githubContent `json:"content"`
+		Commit  *githubCommit  `json:"commit"`
+	}{}
+	if c, ok := s.Data.Commits[s.sha(r, repo, branch)]; ok {
+		out.Commit = githubCommitFrom(c)
+	}
+	if path != "" {
+		if c, _, err := s.Client.Contents.Find(r.Context(), repo, path, branch); err == nil {
+			out.Content = &githubContent{
+				Type: "file",
+				Size: len(c.Data),
+				Name: path[strings.LastIndex(path, "/")+1:],
+				Path: c.Path,
+				Sha:  c.Sha,
+			}
+		}
+	}
+	writeJSON(w, status, out)
+}
+
+func (s *Server) githubListPullRequests(w http.ResponseWriter, r *http.Request, p params) {
+	opts := scm.PullRequestListOptions{Open: true}
+	switch r.URL.Query().Get("state") {
+	case "closed":
+		opts = scm.PullRequestListOptions{Closed: true}
+	case "all":
+		opts = scm.PullRequestListOptions{Open: true, Closed: true}
+	}
+	prs, res, err := s.Client.PullRequests.List(r.Context(), githubRepoName(p), opts)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*githubPullRequest{}
+	for _, pr := range prs {
+		out = append(out, s.githubPullRequestFrom(githubRepoName(p), pr))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) githubCreatePullRequest(w http.ResponseWriter, r *http.Request, p params) {
+	in := &scm.PullRequestInput{}
+	body := struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+		Head  string `json:"head"`
+		Base  string `json:"base"`
+	}{}
+	if !decode(w, r, &body) {
+		return
+	}
+	in.Title = body.Title
+	in.Body = body.Body
+	in.Head = body.Head
+	in.Base = body.Base
+	if in.Title == "" || in.Head == "" || in.Base == "" {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"})
+		return
+	}
+	pr, res, err := s.Client.PullRequests.Create(r.Context(), githubRepoName(p), in)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.githubPullRequestFrom(githubRepoName(p), pr))
+}
+
+func (s *Server) githubFindPullRequest(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	pr, res, err := s.Client.PullRequests.Find(r.Context(), githubRepoName(p), n)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.githubPullRequestFrom(githubRepoName(p), pr))
+}
+
+func (s *Server) githubListPullRequestCommits(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	if _, res, err := s.Client.PullRequests.Find(r.Context(), githubRepoName(p), n); err != nil {
+		writeError(w, res, err)
+		return
+	}
+	commits, res, err := s.Client.PullRequests.ListCommits(r.Context(), githubRepoName(p), n, scm.ListOptions{})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*githubCommit{}
+	for _, c := range commits {
+		out = append(out, githubCommitFrom(c))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) githubUpdatePullRequest(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	in := struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+		Base  string `json:"base"`
+		State string `json:"state"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	ctx := r.Context()
+	repo := githubRepoName(p)
+	pr, res, err := s.Client.PullRequests.Update(ctx, repo, n, &scm.PullRequestInput{Title: in.Title, Body: in.Body, Base: in.Base})
+	if err == nil {
+		switch {
+		case in.State == "closed" && !pr.Closed:
+			res, err = s.Client.PullRequests.Close(ctx, repo, n)
+		case in.State == "open" && pr.Closed:
+			res, err = s.Client.PullRequests.Reopen(ctx, repo, n)
+		}
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.githubPullRequestFrom(repo, pr))
+}
+
+func (s *Server) githubMergePullRequest(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	in := struct {
+		CommitTitle string `json:"commit_title"`
+		Sha         string `json:"sha"`
+		MergeMethod string `json:"merge_method"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	ctx := r.Context()
+	repo := githubRepoName(p)
+	if _, res, err := s.Client.PullRequests.Find(ctx, repo, n); err != nil {
+		writeError(w, res, err)
+		return
+	}
+	res, err := s.Client.PullRequests.Merge(ctx, repo, n, &scm.PullRequestMergeOptions{
+		CommitTitle: in.CommitTitle,
+		SHA:         in.Sha,
+		MergeMethod: in.MergeMethod,
+	})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	pr, _, _ := s.Client.PullRequests.Find(ctx, repo, n)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"sha":     pr.MergeSha,
+		"merged":  true,
+		"message": "Pull Request successfully merged",
+	})
+}
+
+func (s *Server) githubRequestReview(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	in := struct {
+		Reviewers []string `json:"reviewers"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	ctx := r.Context()
+	repo := githubRepoName(p)
+	var res *scm.Response
+	var err error
+	if r.Method == http.MethodDelete {
+		res, err = s.Client.PullRequests.UnrequestReview(ctx, repo, n, in.Reviewers)
+	} else {
+		res, err = s.Client.PullRequests.RequestReview(ctx, repo, n, in.Reviewers)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	pr, res, err := s.Client.PullRequests.Find(ctx, repo, n)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.githubPullRequestFrom(repo, pr))
+}
+
+func (s *Server) githubListIssues(w http.ResponseWriter, r *http.Request, p params) {
+	opts := scm.IssueListOptions{Open: true}
+	switch r.URL.Query().Get("state") {
+	case "closed":
+		opts = scm.IssueListOptions{Closed: true}
+	case "all":
+		opts = scm.IssueListOptions{Open: true, Closed: true}
+	}
+	issues, res, err := s.Client.Issues.List(r.Context(), githubRepoName(p), opts)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*githubIssue{}
+	for _, issue := range issues {
+		out = append(out, githubIssueFrom(issue))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) githubCreateIssue(w http.ResponseWriter, r *http.Request, p params) {
+	in := struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.Title == "" {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"})
+		return
+	}
+	issue, res, err := s.Client.Issues.Create(r.Context(), githubRepoName(p), &scm.IssueInput{Title: in.Title, Body: in.Body})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, githubIssueFrom(issue))
+}
+
+// githubIssue returns the issue or pull request as an issue
+func (s *Server) githubIssue(r *http.Request, repo string, n int) (*githubIssue, *scm.Response, error) {
+	if pr, _, err := s.Client.PullRequests.Find(r.Context(), repo, n); err == nil {
+		return s.githubIssueFromPullRequest(pr), nil, nil
+	}
+	issue, res, err := s.Client.Issues.Find(r.Context(), repo, n)
+	if err != nil {
+		return nil, res, err
+	}
+	return githubIssueFrom(issue), nil, nil
+}
+
+func (s *Server) githubFindIssue(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	out, res, err := s.githubIssue(r, githubRepoName(p), n)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) githubUpdateIssue(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	in := map[string]interface{}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	ctx := r.Context()
+	repo := githubRepoName(p)
+	current, res, err := s.githubIssue(r, repo, n)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	isPR := current.PullRequest != nil
+	if state, ok := in["state"].(string); ok && state != current.State {
+		switch {
+		case state == "closed" && isPR:
+			res, err = s.Client.PullRequests.Close(ctx, repo, n)
+		case state == "closed":
+			res, err = s.Client.Issues.Close(ctx, repo, n)
+		case isPR:
+			res, err = s.Client.PullRequests.Reopen(ctx, repo, n)
+		default:
+			res, err = s.Client.Issues.Reopen(ctx, repo, n)
+		}
+	}
+	if milestone, ok := in["milestone"]; ok && err == nil {
+		switch {
+		case milestone == nil && isPR:
+			res, err = s.Client.PullRequests.ClearMilestone(ctx, repo, n)
+		case milestone == nil:
+			res, err = s.Client.Issues.ClearMilestone(ctx, repo, n)
+		case isPR:
+			res, err = s.Client.PullRequests.SetMilestone(ctx, repo, n, toInt(milestone))
+		default:
+			res, err = s.Client.Issues.SetMilestone(ctx, repo, n, toInt(milestone))
+		}
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out, res, err := s.githubIssue(r, repo, n)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) githubListComments(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+	repo := githubRepoName(p)
+	var comments []*scm.Comment
+	var res *scm.Response
+	var err error
+	if s.isPullRequest(r, repo, n) {
+		comments, res, err = s.Client.PullRequests.ListComments(ctx, repo, n, scm.ListOptions{})
+	} else if _, res, err = s.Client.Issues.Find(ctx, repo, n); err == nil {
+		comments, res, err = s.Client.Issues.ListComments(ctx, repo, n, scm.ListOptions{})
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*githubComment{}
+	for _, c := range comments {
+		out = append(out, githubCommentFrom(c))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) githubCreateComment(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	in := &scm.CommentInput{}
+	body := struct {
+		Body string `json:"body"`
+	}{}
+	if !decode(w, r, &body) {
+		return
+	}
+	in.Body = body.Body
+	ctx := r.Context()
+	repo := githubRepoName(p)
+	var c *scm.Comment
+	var res *scm.Response
+	var err error
+	if s.isPullRequest(r, repo, n) {
+		c, res, err = s.Client.PullRequests.CreateComment(ctx, repo, n, in)
+	} else if _, res, err = s.Client.Issues.Find(ctx, repo, n); err == nil {
+		c, res, err = s.Client.Issues.CreateComment(ctx, repo, n, in)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, githubCommentFrom(c))
+}
+
+func (s *Server) githubFindComment(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := number(w, p, "id")
+	if !ok {
+		return
+	}
+	n, ok := s.commentNumber(id)
+	if !ok {
+		notFound(w, "comment %d not found", id)
+		return
+	}
+	ctx := r.Context()
+	repo := githubRepoName(p)
+	var c *scm.Comment
+	var res *scm.Response
+	var err error
+	if s.isPullRequest(r, repo, n) {
+		c, res, err = s.Client.PullRequests.FindComment(ctx, repo, n, id)
+	} else {
+		c, res, err = s.Client.Issues.FindComment(ctx, repo, n, id)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, githubCommentFrom(c))
+}
+
+func (s *Server) githubEditComment(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := number(w, p, "id")
+	if !ok {
+		return
+	}
+	n, ok := s.commentNumber(id)
+	if !ok {
+		notFound(w, "comment %d not found", id)
+		return
+	}
+	body := struct {
+		Body string `json:"body"`
+	}{}
+	if !decode(w, r, &body) {
+		return
+	}
+	in := &scm.CommentInput{Body: body.Body}
+	ctx := r.Context()
+	repo := githubRepoName(p)
+	var c *scm.Comment
+	var res *scm.Response
+	var err error
+	if s.isPullRequest(r, repo, n) {
+		c, res, err = s.Client.PullRequests.EditComment(ctx, repo, n, id, in)
+	} else {
+		c, res, err = s.Client.Issues.EditComment(ctx, repo, n, id, in)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, githubCommentFrom(c))
+}
+
+func (s *Server) githubDeleteComment(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := number(w, p, "id")
+	if !ok {
+		return
+	}
+	n, ok := s.commentNumber(id)
+	if !ok {
+		notFound(w, "comment %d not found", id)
+		return
+	}
+	ctx := r.Context()
+	repo := githubRepoName(p)
+	var res *scm.Response
+	var err error
+	if s.isPullRequest(r, repo, n) {
+		res, err = s.Client.PullRequests.DeleteComment(ctx, repo, n, id)
+	} else {
+		res, err = s.Client.Issues.DeleteComment(ctx, repo, n, id)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// githubIssueLabels returns the labels of the issue or pull request
+func (s *Server) githubIssueLabels(r *http.Request, repo string, n int) ([]*githubLabel, *scm.Response, error) {
+	var labels []*scm.Label
+	var res *scm.Response
+	var err error
+	if s.isPullRequest(r, repo, n) {
+		labels, res, err = s.Client.PullRequests.ListLabels(r.Context(), repo, n, scm.ListOptions{})
+	} else if _, res, err = s.Client.Issues.Find(r.Context(), repo, n); err == nil {
+		labels, res, err = s.Client.Issues.ListLabels(r.Context(), repo, n, scm.ListOptions{})
+	}
+	out := []*githubLabel{}
+	for _, l := range labels {
+		out = append(out, githubLabelFrom(l))
+	}
+	return out, res, err
+}
+
+func (s *Server) githubListIssueLabels(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	out, res, err := s.githubIssueLabels(r, githubRepoName(p), n)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) githubAddLabels(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	var labels []string
+	if !decode(w, r, &labels) {
+		return
+	}
+	ctx := r.Context()
+	repo := githubRepoName(p)
+	isPR := s.isPullRequest(r, repo, n)
+	for _, label := range labels {
+		var res *scm.Response
+		var err error
+		if isPR {
+			res, err = s.Client.PullRequests.AddLabel(ctx, repo, n, label)
+		} else {
+			res, err = s.Client.Issues.AddLabel(ctx, repo, n, label)
+		}
+		if err != nil {
+			writeError(w, res, err)
+			return
+		}
+	}
+	out, res, err := s.githubIssueLabels(r, repo, n)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) githubDeleteLabel(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+	repo := githubRepoName(p)
+	var res *scm.Response
+	var err error
+	if s.isPullRequest(r, repo, n) {
+		res, err = s.Client.PullRequests.DeleteLabel(ctx, repo, n, p["label"])
+	} else {
+		res, err = s.Client.Issues.DeleteLabel(ctx, repo, n, p["label"])
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out, res, err := s.githubIssueLabels(r, repo, n)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) githubListEvents(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	events, res, err := s.Client.Issues.ListEvents(r.Context(), githubRepoName(p), n, scm.ListOptions{})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*githubIssueEvent{}
+	for _, e := range events {
+		out = append(out, &githubIssueEvent{
+			Event:   e.Event,
+			Actor:   githubUserFrom(&e.Actor),
+			Label:   *githubLabelFrom(&e.Label),
+			Created: e.Created,
+		})
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) githubLock(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	var res *scm.Response
+	var err error
+	if r.Method == http.MethodDelete {
+		res, err = s.Client.Issues.Unlock(r.Context(), githubRepoName(p), n)
+	} else {
+		res, err = s.Client.Issues.Lock(r.Context(), githubRepoName(p), n)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) githubAssign(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := number(w, p, "number")
+	if !ok {
+		return
+	}
+	in := struct {
+		Assignees []string `json:"assignees"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	ctx := r.Context()
+	repo := githubRepoName(p)
+	isPR := s.isPullRequest(r, repo, n)
+	var res *scm.Response
+	var err error
+	switch {
+	case r.Method == http.MethodDelete && isPR:
+		res, err = s.Client.PullRequests.UnassignIssue(ctx, repo, n, in.Assignees)
+	case r.Method == http.MethodDelete:
+		res, err = s.Client.Issues.UnassignIssue(ctx, repo, n, in.Assignees)
+	case isPR:
+		res, err = s.Client.PullRequests.AssignIssue(ctx, repo, n, in.Assignees)
+	default:
+		res, err = s.Client.Issues.AssignIssue(ctx, repo, n, in.Assignees)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out, res, err := s.githubIssue(r, repo, n)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, out)
+}
+
+func (s *Server) githubListLabels(w http.ResponseWriter, r *http.Request, p params) {
+	labels, res, err := s.Client.Repositories.ListLabels(r.Context(), githubRepoName(p), scm.ListOptions{})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*githubLabel{}
+	for _, l := range labels {
+		out = append(out, githubLabelFrom(l))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) githubListStatus(w http.ResponseWriter, r *http.Request, p params) {
+	repo := githubRepoName(p)
+	statuses, res, err := s.Client.Repositories.ListStatus(r.Context(), repo, s.sha(r, repo, p["ref"]), scm.ListOptions{})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*githubStatus{}
+	for _, st := range statuses {
+		out = append(out, githubStatusFrom(st))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) githubCreateStatus(w http.ResponseWriter, r *http.Request, p params) {
+	in := &githubStatus{}
+	if !decode(w, r, in) {
+		return
+	}
+	repo := githubRepoName(p)
+	st, res, err := s.Client.Repositories.CreateStatus(r.Context(), repo, s.sha(r, repo, p["ref"]), &scm.StatusInput{
+		State:  scm.ToState(in.State),
+		Label:  in.Context,
+		Desc:   in.Description,
+		Target: in.TargetURL,
+	})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, githubStatusFrom(st))
+}
+
+func (s *Server) githubFindCombinedStatus(w http.ResponseWriter, r *http.Request, p params) {
+	repo := githubRepoName(p)
+	sha := s.sha(r, repo, p["ref"])
+	combined, res, err := s.Client.Repositories.FindCombinedStatus(r.Context(), repo, sha)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := &githubCombinedStatus{Sha: sha, State: "success", Statuses: []*githubStatus{}}
+	for _, st := range combined.Statuses {
+		status := githubStatusFrom(st)
+		out.Statuses = append(out.Statuses, status)
+		switch {
+		case status.State == "failure" || status.State == "error":
+			out.State = "failure"
+		case status.State == "pending" && out.State == "success":
+			out.State = "pending"
+		}
+	}
+	if len(out.Statuses) == 0 {
+		out.State = "pending"
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) githubListHooks(w http.ResponseWriter, r *http.Request, p params) {
+	hooks, res, err := s.Client.Repositories.ListHooks(r.Context(), githubRepoName(p), scm.ListOptions{})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*githubHook{}
+	for _, h := range hooks {
+		out = append(out, githubHookFrom(h))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) githubCreateHook(w http.ResponseWriter, r *http.Request, p params) {
+	in := &githubHook{}
+	if !decode(w, r, in) {
+		return
+	}
+	if s.Data.Hooks == nil {
+		s.Data.Hooks = map[string][]*scm.Hook{}
+	}
+	hook, res, err := s.Client.Repositories.CreateHook(r.Context(), githubRepoName(p), &scm.HookInput{
+		Name:         in.Name,
+		Target:       in.Config.URL,
+		Secret:       in.Config.Secret,
+		SkipVerify:   in.Config.InsecureSSL == "1",
+		NativeEvents: in.Events,
+	})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, githubHookFrom(hook))
+}
+
+func (s *Server) githubFindHook(w http.ResponseWriter, r *http.Request, p params) {
+	hook, res, err := s.Client.Repositories.FindHook(r.Context(), githubRepoName(p), p["id"])
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, githubHookFrom(hook))
+}
+
+func (s *Server) githubDeleteHook(w http.ResponseWriter, r *http.Request, p params) {
+	repo := githubRepoName(p)
+	if _, res, err := s.Client.Repositories.FindHook(r.Context(), repo, p["id"]); err != nil {
+		writeError(w, res, err)
+		return
+	}
+	res, err := s.Client.Repositories.DeleteHook(r.Context(), repo, p["id"])
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func githubUserFrom(u *scm.User) githubUser {
+	return githubUser{
+		ID:        u.ID,
+		Login:     u.Login,
+		Name:      u.Name,
+		Email:     u.Email,
+		AvatarURL: u.Avatar,
+		HTMLURL:   u.Link,
+	}
+}
+
+func githubUsersFrom(users []scm.User) []githubUser {
+	out := []githubUser{}
+	for i := range users {
+		out = append(out, githubUserFrom(&users[i]))
+	}
+	return out
+}
+
+func (s *Server) githubRepositoryFrom(repo *scm.Repository) *githubRepository {
+	fullName := repo.FullName
+	if fullName == "" {
+		fullName = scm.Join(repo.Namespace, repo.Name)
+	}
+	if found, ok := s.findRepository(fullName); ok {
+		repo = found
+	}
+	out := &githubRepository{
+		ID:            s.repositoryID(repo),
+		Owner:         githubUser{Login: repo.Namespace},
+		Name:          repo.Name,
+		FullName:      fullName,
+		Private:       repo.Private,
+		Archived:      repo.Archived,
+		HTMLURL:       fmt.Sprintf("%s/%s", s.URL, fullName),
+		SSHURL:        repo.CloneSSH,
+		CloneURL:      fmt.Sprintf("%s/%s.git", s.URL, fullName),
+		DefaultBranch: repo.Branch,
+		CreatedAt:     repo.Created,
+		UpdatedAt:     repo.Updated,
+	}
+	out.Permissions.Pull = true
+	out.Permissions.Push = true
+	out.Permissions.Admin = true
+	if repo.Perm != nil {
+		out.Permissions.Pull = repo.Perm.Pull
+		out.Permissions.Push = repo.Perm.Push
+		out.Permissions.Admin = repo.Perm.Admin
+	}
+	return out
+}
+
+func githubLabelFrom(l *scm.Label) *githubLabel {
+	return &githubLabel{
+		ID:          l.ID,
+		Name:        l.Name,
+		Color:       l.Color,
+		Description: l.Description,
+	}
+}
+
+func (s *Server) githubPullRequestFrom(repo string, pr *scm.PullRequest) *githubPullRequest {
+	state := "open"
+	if pr.Closed {
+		state = "closed"
+	}
+	branch := func(b scm.PullRequestBranch) githubPullRequestBranch {
+		if b.Repo.FullName == "" && b.Repo.Name == "" {
+			b.Repo.FullName = repo
+			b.Repo.Namespace, b.Repo.Name = scm.Split(repo)
+		}
+		return githubPullRequestBranch{
+			Ref:  b.Ref,
+			Sha:  b.Sha,
+			User: githubUser{Login: b.Repo.Namespace},
+			Repo: *s.githubRepositoryFrom(&b.Repo),
+		}
+	}
+	out := &githubPullRequest{
+		Number:             pr.Number,
+		State:              state,
+		Title:              pr.Title,
+		Body:               pr.Body,
+		Labels:             []*githubLabel{},
+		DiffURL:            fmt.Sprintf("%s/%s/pull/%d.diff", s.URL, repo, pr.Number),
+		HTMLURL:            fmt.Sprintf("%s/%s/pull/%d", s.URL, repo, pr.Number),
+		User:               githubUserFrom(&pr.Author),
+		RequestedReviewers: githubUsersFrom(pr.Reviewers),
+		Assignees:          githubUsersFrom(pr.Assignees),
+		Head:               branch(pr.Head),
+		Base:               branch(pr.Base),
+		Draft:              pr.Draft,
+		Merged:             pr.Merged,
+		Mergeable:          pr.Mergeable,
+		MergeableState:     string(pr.MergeableState),
+		Rebaseable:         pr.Rebaseable,
+		MergeSha:           pr.MergeSha,
+		CreatedAt:          pr.Created,
+		UpdatedAt:          pr.Updated,
+	}
+	if out.Head.Sha == "" {
+		out.Head.Sha = pr.Sha
+	}
+	for _, l := range pr.Labels {
+		out.Labels = append(out.Labels, githubLabelFrom(l))
+	}
+	if pr.Milestone.Number != 0 {
+		out.Milestone = &githubMilestone{
+			ID:     pr.Milestone.ID,
+			Number: pr.Milestone.Number,
+			Title:  pr.Milestone.Title,
+			State:  pr.Milestone.State,
+		}
+	}
+	return out
+}
+
+func githubIssueFrom(issue *scm.Issue) *githubIssue {
+	state := "open"
+	if issue.Closed {
+		state = "closed"
+	}
+	out := &githubIssue{
+		ID:        issue.Number,
+		Number:    issue.Number,
+		HTMLURL:   issue.Link,
+		State:     state,
+		Title:     issue.Title,
+		Body:      issue.Body,
+		User:      githubUserFrom(&issue.Author),
+		Labels:    []*githubLabel{},
+		Assignees: githubUsersFrom(issue.Assignees),
+		Locked:    issue.Locked,
+		CreatedAt: issue.Created,
+		UpdatedAt: issue.Updated,
+	}
+	if issue.ClosedBy != nil {
+		closedBy := githubUserFrom(issue.ClosedBy)
+		out.ClosedBy = &closedBy
+	}
+	for _, l := range issue.Labels {
+		out.Labels = append(out.Labels, &githubLabel{Name: l})
+	}
+	if issue.PullRequest {
+		out.PullRequest = &struct{}{}
+	}
+	return out
+}
+
+func (s *Server) githubIssueFromPullRequest(pr *scm.PullRequest) *githubIssue {
+	state := "open"
+	if pr.Closed {
+		state = "closed"
+	}
+	out := &githubIssue{
+		ID:          pr.Number,
+		Number:      pr.Number,
+		HTMLURL:     pr.Link,
+		State:       state,
+		Title:       pr.Title,
+		Body:        pr.Body,
+		User:        githubUserFrom(&pr.Author),
+		Labels:      []*githubLabel{},
+		Assignees:   githubUsersFrom(pr.Assignees),
+		CreatedAt:   pr.Created,
+		UpdatedAt:   pr.Updated,
+		PullRequest: &struct{}{},
+	}
+	for _, l := range pr.Labels {
+		out.Labels = append(out.Labels, githubLabelFrom(l))
+	}
+	return out
+}
+
+func githubCommentFrom(c *scm.Comment) *githubComment {
+	return &githubComment{
+		ID:        c.ID,
+		HTMLURL:   c.Link,
+		User:      githubUserFrom(&c.Author),
+		Body:      c.Body,
+		CreatedAt: c.Created,
+		UpdatedAt: c.Updated,
+	}
+}
+
+func githubCommitFrom(c *scm.Commit) *githubCommit {
+	out := &githubCommit{
+		Sha:     c.Sha,
+		HTMLURL: c.Link,
+	}
+	out.Commit.Tree.Sha = c.Tree.Sha
+	out.Commit.Tree.URL = c.Tree.Link
+	out.Commit.Message = c.Message
+	out.Commit.Author = githubSignature{Name: c.Author.Name, Email: c.Author.Email, Date: c.Author.Date}
+	out.Commit.Committer = githubSignature{Name: c.Committer.Name, Email: c.Committer.Email, Date: c.Committer.Date}
+	out.Author = githubUser{Login: c.Author.Login, AvatarURL: c.Author.Avatar}
+	out.Committer = githubUser{Login: c.Committer.Login, AvatarURL: c.Committer.Avatar}
+	return out
+}
+
+func (s *Server) githubBranchFrom(repo string, ref *scm.Reference) *githubBranch {
+	out := &githubBranch{Name: ref.Name}
+	if c, ok := s.Data.Commits[ref.Sha]; ok {
+		out.Commit = *githubCommitFrom(c)
+	}
+	out.Commit.Sha = ref.Sha
+	return out
+}
+
+func githubRefFrom(name, sha string) *githubRef {
+	out := &githubRef{Ref: name}
+	out.Object.Type = "commit"
+	out.Object.Sha = sha
+	return out
+}
+
+func githubStatusFrom(st *scm.Status) *githubStatus {
+	state := st.State.String()
+	switch st.State {
+	case scm.StateRunning, scm.StateExpected:
+		state = "pending"
+	case scm.StateCanceled, scm.StateUnknown:
+		state = "error"
+	}
+	return &githubStatus{
+		State:       state,
+		TargetURL:   st.Target,
+		Description: st.Desc,
+		Context:     st.Label,
+	}
+}
+
+func githubHookFrom(h *scm.Hook) *githubHook {
+	id, _ := strconv.Atoi(h.ID)
+	out := &githubHook{
+		ID:     id,
+		Name:   "web",
+		Events: h.Events,
+		Active: h.Active,
+	}
+	if out.Events == nil {
+		out.Events = []string{}
+	}
+	out.Config.URL = h.Target
+	out.Config.ContentType = "json"
+	out.Config.InsecureSSL = "0"
+	if h.SkipVerify {
+		out.Config.InsecureSSL = "1"
+	}
+	return out
+}
+
+// toInt converts a JSON number to an int
+func toInt(v interface{}) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case string:
+		i, _ := strconv.Atoi(n)
+		return i
+	}
+	return 0
+}
@@ -0,0 +1,1653 @@
+package server
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type gitlabUser struct {
+	ID        int    `json:"id"`
+	Username  string `json:"username"`
+	Name      string `json:"name"`
+	Email     string `json:"email,omitempty"`
+	AvatarURL string `json:"avatar_url"`
+	State     string `json:"state"`
+	WebURL    string `json:"web_url,omitempty"`
+}
+
+type gitlabNamespace struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Kind     string `json:"kind"`
+	FullPath string `json:"full_path"`
+}
+
+type gitlabAccess struct {
+	AccessLevel int `json:"access_level"`
+}
+
+type gitlabProject struct {
+	ID                int             `json:"id"`
+	Name              string          `json:"name"`
+	Path              string          `json:"path"`
+	PathWithNamespace string          `json:"path_with_namespace"`
+	Description       string          `json:"description"`
+	DefaultBranch     string          `json:"default_branch"`
+	Visibility        string          `json:"visibility"`
+	Archived          bool            `json:"archived"`
+	WebURL            string          `json:"web_url"`
+	SSHURL            string          `json:"ssh_url_to_repo"`
+	HTTPURL           string          `json:"http_url_to_repo"`
+	Namespace         gitlabNamespace `json:"namespace"`
+	Permissions       struct {
+		ProjectAccess *gitlabAccess `json:"project_access"`
+		GroupAccess   *gitlabAccess `json:"group_access"`
+	} `json:"permissions"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type gitlabCommit struct {
+	ID             string    `json:"id"`
+	ShortID        string    `json:"short_id"`
+	Title          string    `json:"title"`
+	Message        string    `json:"message"`
+	AuthorName     string    `json:"author_name"`
+	AuthorEmail    string    `json:"author_email"`
+	AuthoredDate   time.Time `json:"authored_date"`
+	CommitterName  string    `json:"committer_name"`
+	CommitterEmail string    `json:"committer_email"`
+	CommittedDate  time.Time `json:"committed_date"`
+	CreatedAt      time.Time `json:"created_at"`
+	ParentIDs      []string  `json:"parent_ids"`
+	WebURL         string    `json:"web_url"`
+}
+
+type gitlabBranch struct {
+	Name      string        `json:"name"`
+	Commit    *gitlabCommit `json:"commit"`
+	Protected bool          `json:"protected"`
+	Default   bool          `json:"default"`
+}
+
+type gitlabFile struct {
+	FileName     string `json:"file_name"`
+	FilePath     string `json:"file_path"`
+	Size         int    `json:"size"`
+	Encoding     string `json:"encoding"`
+	Content      string `json:"content"`
+	Ref          string `json:"ref"`
+	BlobID       string `json:"blob_id"`
+	CommitID     string `json:"commit_id"`
+	LastCommitID string `json:"last_commit_id"`
+}
+
+type gitlabTreeEntry struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+}
+
+type gitlabMergeRequest struct {
+	ID              int          `json:"id"`
+	IID             int          `json:"iid"`
+	ProjectID       int          `json:"project_id"`
+	Sha             string       `json:"sha"`
+	MergeCommitSha  string       `json:"merge_commit_sha,omitempty"`
+	Title           string       `json:"title"`
+	Description     string       `json:"description"`
+	State           string       `json:"state"`
+	SourceProjectID int          `json:"source_project_id"`
+	TargetProjectID int          `json:"target_project_id"`
+	SourceBranch    string       `json:"source_branch"`
+	TargetBranch    string       `json:"target_branch"`
+	Labels          []string     `json:"labels"`
+	WebURL          string       `json:"web_url"`
+	WorkInProgress  bool         `json:"work_in_progress"`
+	Draft           bool         `json:"draft"`
+	Author          gitlabUser   `json:"author"`
+	Assignees       []gitlabUser `json:"assignees"`
+	Reviewers       []gitlabUser `json:"reviewers"`
+	MergeStatus     string       `json:"merge_status"`
+	DiffRefs        struct {
+		BaseSha string `json:"base_sha"`
+		HeadSha string `json:"head_sha"`
+	} `json:"diff_refs"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type gitlabIssue struct {
+	ID               int          `json:"id"`
+	IID              int          `json:"iid"`
+	ProjectID        int          `json:"project_id"`
+	State            string       `json:"state"`
+	Title            string       `json:"title"`
+	Description      string       `json:"description"`
+	WebURL           string       `json:"web_url"`
+	DiscussionLocked bool         `json:"discussion_locked"`
+	Labels           []string     `json:"labels"`
+	Author           gitlabUser   `json:"author"`
+	Assignees        []gitlabUser `json:"assignees"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+}
+
+type gitlabNote struct {
+	ID          int        `json:"id"`
+	NoteableIID int        `json:"noteable_iid"`
+	Author      gitlabUser `json:"author"`
+	Body        string     `json:"body"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type gitlabLabel struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+type gitlabStatus struct {
+	ID          int       `json:"id"`
+	Sha         string    `json:"sha"`
+	Ref         string    `json:"ref"`
+	Status      string    `json:"status"`
+	Name        string    `json:"name"`
+	TargetURL   string    `json:"target_url"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type gitlabHook struct {
+	ID                    int       `json:"id"`
+	URL                   string    `json:"url"`
+	ProjectID             int       `json:"project_id"`
+	PushEvents            bool      `json:"push_events"`
+	IssuesEvents          bool      `json:"issues_events"`
+	MergeRequestsEvents   bool      `json:"merge_requests_events"`
+	TagPushEvents         bool      `json:"tag_push_events"`
+	NoteEvents            bool      `json:"note_events"`
+	ReleasesEvents        bool      `json:"releases_events"`
+	EnableSslVerification bool      `json:"enable_ssl_verification"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+// gitlabHookEvents the boolean event query parameters of the hooks API
+var gitlabHookEvents = []string{
+	"push_events",
+	"issues_events",
+	"merge_requests_events",
+	"tag_push_events",
+	"note_events",
+	"releases_events",
+}
+
+func (s *Server) registerGitLab() {
+	s.handle("GET", "api/v4/user", s.gitlabFindUser)
+	s.handle("GET", "api/v4/users", s.gitlabSearchUsers)
+	s.handle("GET", "api/v4/namespaces", s.gitlabListNamespaces)
+
+	s.handle("GET", "api/v4/projects", s.gitlabListProjects)
+	s.handle("POST", "api/v4/projects", s.gitlabCreateProject)
+	s.handle("GET", "api/v4/projects/:id", s.gitlabFindProject)
+	s.handle("DELETE", "api/v4/projects/:id", s.gitlabDeleteProject)
+
+	s.handle("GET", "api/v4/projects/:id/repository/branches", s.gitlabListBranches)
+	s.handle("POST", "api/v4/projects/:id/repository/branches", s.gitlabCreateBranch)
+	s.handle("GET", "api/v4/projects/:id/repository/branches/:branch", s.gitlabFindBranch)
+	s.handle("GET", "api/v4/projects/:id/repository/commits", s.gitlabListCommits)
+	s.handle("POST", "api/v4/projects/:id/repository/commits", s.gitlabCreateCommit)
+	s.handle("GET", "api/v4/projects/:id/repository/commits/:sha", s.gitlabFindCommit)
+	s.handle("GET", "api/v4/projects/:id/repository/commits/:sha/statuses", s.gitlabListStatuses)
+	s.handle("POST", "api/v4/projects/:id/statuses/*sha", s.gitlabCreateStatus)
+
+	s.handle("GET", "api/v4/projects/:id/repository/tree", s.gitlabListTree)
+	s.handle("GET", "api/v4/projects/:id/repository/files/:path", s.gitlabFindFile)
+	s.handle("POST", "api/v4/projects/:id/repository/files/:path", s.gitlabWriteFile)
+	s.handle("PUT", "api/v4/projects/:id/repository/files/:path", s.gitlabWriteFile)
+	s.handle("DELETE", "api/v4/projects/:id/repository/files/:path", s.gitlabDeleteFile)
+
+	s.handle("GET", "api/v4/projects/:id/merge_requests", s.gitlabListMergeRequests)
+	s.handle("POST", "api/v4/projects/:id/merge_requests", s.gitlabCreateMergeRequest)
+	s.handle("GET", "api/v4/projects/:id/merge_requests/:iid", s.gitlabFindMergeRequest)
+	s.handle("PUT", "api/v4/projects/:id/merge_requests/:iid", s.gitlabUpdateMergeRequest)
+	s.handle("GET", "api/v4/projects/:id/merge_requests/:iid/commits", s.gitlabListMergeRequestCommits)
+	s.handle("PUT", "api/v4/projects/:id/merge_requests/:iid/merge", s.gitlabMergeMergeRequest)
+	s.handle("GET", "api/v4/projects/:id/merge_requests/:iid/notes", s.gitlabListNotes)
+	s.handle("POST", "api/v4/projects/:id/merge_requests/:iid/notes", s.gitlabCreateNote)
+	s.handle("GET", "api/v4/projects/:id/merge_requests/:iid/notes/:note", s.gitlabFindNote)
+	s.handle("PUT", "api/v4/projects/:id/merge_requests/:iid/notes/:note", s.gitlabEditNote)
+	s.handle("DELETE", "api/v4/projects/:id/merge_requests/:iid/notes/:note", s.gitlabDeleteNote)
+
+	s.handle("GET", "api/v4/projects/:id/issues", s.gitlabListIssues)
+	s.handle("POST", "api/v4/projects/:id/issues", s.gitlabCreateIssue)
+	s.handle("GET", "api/v4/projects/:id/issues/:iid", s.gitlabFindIssue)
+	s.handle("PUT", "api/v4/projects/:id/issues/:iid", s.gitlabUpdateIssue)
+	s.handle("GET", "api/v4/projects/:id/issues/:iid/notes", s.gitlabListNotes)
+	s.handle("POST", "api/v4/projects/:id/issues/:iid/notes", s.gitlabCreateNote)
+	s.handle("GET", "api/v4/projects/:id/issues/:iid/notes/:note", s.gitlabFindNote)
+	s.handle("PUT", "api/v4/projects/:id/issues/:iid/notes/:note", s.gitlabEditNote)
+	s.handle("DELETE", "api/v4/projects/:id/issues/:iid/notes/:note", s.gitlabDeleteNote)
+
+	s.handle("GET", "api/v4/projects/:id/labels", s.gitlabListLabels)
+
+	s.handle("GET", "api/v4/projects/:id/hooks", s.gitlabListHooks)
+	s.handle("POST", "api/v4/projects/:id/hooks", s.gitlabCreateHook)
+	s.handle("GET", "api/v4/projects/:id/hooks/:hook", s.gitlabFindHook)
+	s.handle("PUT", "api/v4/projects/:id/hooks/:hook", s.gitlabUpdateHook)
+	s.handle("DELETE", "api/v4/projects/:id/hooks/:hook", s.gitlabDeleteHook)
+}
+
+// gitlabProjectName returns the full name of the project identified by its
+// path or numeric id, writing a 404 if there is no such project
+func (s *Server) gitlabProjectName(w http.ResponseWriter, p params) (string, bool) {
+	repo, ok := s.findRepository(p["id"])
+	if !ok {
+		notFound(w, "404 Project Not Found")
+		return "", false
+	}
+	return repo.FullName, true
+}
+
+func (s *Server) gitlabFindUser(w http.ResponseWriter, r *http.Request, p params) {
+	user, res, err := s.Client.Users.Find(r.Context())
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, gitlabUserFrom(user))
+}
+
+func (s *Server) gitlabSearchUsers(w http.ResponseWriter, r *http.Request, p params) {
+	out := []gitlabUser{}
+	if user, _, err := s.Client.Users.FindLogin(r.Context(), r.URL.Query().Get("search")); err == nil {
+		out = append(out, gitlabUserFrom(user))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) gitlabListNamespaces(w http.ResponseWriter, r *http.Request, p params) {
+	search := r.URL.Query().Get("search")
+	names := []string{s.Data.CurrentUser.Login}
+	for _, repo := range s.Data.Repositories {
+		names = append(names, repo.Namespace)
+	}
+	out := []*gitlabNamespace{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		if name == "" || seen[name] || !strings.Contains(name, search) {
+			continue
+		}
+		seen[name] = true
+		kind := "group"
+		if name == s.Data.CurrentUser.Login {
+			kind = "user"
+		}
+		out = append(out, &gitlabNamespace{
+			ID:       s.namespaceID(name),
+			Name:     name,
+			Path:     name,
+			Kind:     kind,
+			FullPath: name,
+		})
+	}
+	// an unknown namespace is created on demand so that projects can be created in it
+	if len(out) == 0 && search != "" {
+		out = append(out, &gitlabNamespace{
+			ID:       s.namespaceID(search),
+			Name:     search,
+			Path:     search,
+			Kind:     "group",
+			FullPath: search,
+		})
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) gitlabListProjects(w http.ResponseWriter, r *http.Request, p params) {
+	out := []*gitlabProject{}
+	for _, repo := range s.Data.Repositories {
+		out = append(out, s.gitlabProjectFrom(repo))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) gitlabCreateProject(w http.ResponseWriter, r *http.Request, p params) {
+	in := struct {
+		Name        string `json:"name"`
+		Path        string `json:"path"`
+		NamespaceID int    `json:"namespace_id"`
+		Description string `json:"description"`
+		Visibility  string `json:"visibility"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	name := in.Path
+	if name == "" {
+		name = in.Name
+	}
+	namespace := s.Data.CurrentUser.Login
+	for ns, id := range s.namespaces {
+		if id == in.NamespaceID {
+			namespace = ns
+		}
+	}
+	if _, exists := s.findRepository(scm.Join(namespace, name)); exists {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"message": map[string][]string{"name": {"has already been taken"}}})
+		return
+	}
+	repo, res, err := s.Client.Repositories.Create(r.Context(), &scm.RepositoryInput{
+		Namespace:   namespace,
+		Name:        name,
+		Description: in.Description,
+		Private:     in.Visibility == "private" || in.Visibility == "internal",
+	})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.gitlabProjectFrom(repo))
+}
+
+func (s *Server) gitlabFindProject(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.findRepository(p["id"])
+	if !ok {
+		notFound(w, "404 Project Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.gitlabProjectFrom(repo))
+}
+
+func (s *Server) gitlabDeleteProject(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	res, err := s.Client.Repositories.Delete(r.Context(), repo)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, map[string]string{"message": "202 Accepted"})
+}
+
+func (s *Server) gitlabListBranches(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	refs, res, err := s.Client.Git.ListBranches(r.Context(), repo, scm.ListOptions{})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	search := r.URL.Query().Get("search")
+	out := []*gitlabBranch{}
+	for _, ref := range refs {
+		if strings.Contains(ref.Name, search) {
+			out = append(out, s.gitlabBranchFrom(repo, ref))
+		}
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) gitlabCreateBranch(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	// the driver passes the full name of the ref as the branch
+	branch := strings.TrimPrefix(query.Get("branch"), "refs/heads/")
+	if _, _, err := s.Client.Git.FindBranch(r.Context(), repo, branch); err == nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "Branch already exists"})
+		return
+	}
+	sha := s.sha(r, repo, query.Get("ref"))
+	if _, ok := s.Data.Commits[sha]; !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "Invalid reference name"})
+		return
+	}
+	ref, res, err := s.Client.Git.CreateRef(r.Context(), repo, "refs/heads/"+branch, sha)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.gitlabBranchFrom(repo, &scm.Reference{Name: branch, Sha: ref.Sha}))
+}
+
+func (s *Server) gitlabFindBranch(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	ref, res, err := s.Client.Git.FindBranch(r.Context(), repo, p["branch"])
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.gitlabBranchFrom(repo, ref))
+}
+
+func (s *Server) gitlabListCommits(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	ref := r.URL.Query().Get("ref_name")
+	if ref == "" {
+		if found, ok := s.findRepository(repo); ok {
+			ref = found.Branch
+		}
+	}
+	commits, res, err := s.Client.Git.ListCommits(r.Context(), repo, scm.CommitListOptions{Ref: s.sha(r, repo, ref)})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*gitlabCommit{}
+	for _, c := range commits {
+		out = append(out, gitlabCommitFrom(c))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) gitlabListMergeRequestCommits(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	n, ok := number(w, p, "iid")
+	if !ok {
+		return
+	}
+	if _, res, err := s.Client.PullRequests.Find(r.Context(), repo, n); err != nil {
+		writeError(w, res, err)
+		return
+	}
+	commits, res, err := s.Client.PullRequests.ListCommits(r.Context(), repo, n, scm.ListOptions{})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	// gitlab lists the commits of a merge request newest first
+	out := []*gitlabCommit{}
+	for i := len(commits) - 1; i >= 0; i-- {
+		out = append(out, gitlabCommitFrom(commits[i]))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) gitlabFindCommit(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	c, ok := s.Data.Commits[s.sha(r, repo, p["sha"])]
+	if !ok {
+		notFound(w, "404 Commit Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, gitlabCommitFrom(c))
+}
+
+// gitlabCreateCommit applies the create, update and delete actions of a commit
+// by using the content service of the fake driver
+func (s *Server) gitlabCreateCommit(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	in := struct {
+		Branch  string `json:"branch"`
+		Message string `json:"commit_message"`
+		Actions []struct {
+			Action   string `json:"action"`
+			Path     string `json:"file_path"`
+			Content  string `json:"content"`
+			Encoding string `json:"encoding"`
+		} `json:"actions"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	ctx := r.Context()
+	for _, action := range in.Actions {
+		data := []byte(action.Content)
+		if action.Encoding == "base64" {
+			decoded, err := base64.StdEncoding.DecodeString(action.Content)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+				return
+			}
+			data = decoded
+		}
+		params := &scm.ContentParams{Branch: in.Branch, Message: in.Message, Data: data}
+		var res *scm.Response
+		var err error
+		switch action.Action {
+		case "create":
+			res, err = s.Client.Contents.Create(ctx, repo, action.Path, params)
+		case "update":
+			existing, _, findErr := s.Client.Contents.Find(ctx, repo, action.Path, in.Branch)
+			if findErr != nil {
+				writeError(w, nil, findErr)
+				return
+			}
+			params.Sha = existing.Sha
+			res, err = s.Client.Contents.Update(ctx, repo, action.Path, params)
+		case "delete":
+			res, err = s.Client.Contents.Delete(ctx, repo, action.Path, params)
+		default:
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": fmt.Sprintf("unsupported action %q", action.Action)})
+			return
+		}
+		if err != nil {
+			// GitLab reports failed actions as bad requests
+			if res == nil || res.Status < 400 {
+				res = &scm.Response{Status: http.StatusBadRequest}
+			}
+			writeError(w, res, err)
+			return
+		}
+	}
+	c, ok := s.Data.Commits[s.sha(r, repo, in.Branch)]
+	if !ok {
+		notFound(w, "404 Branch Not Found")
+		return
+	}
+	writeJSON(w, http.StatusCreated, gitlabCommitFrom(c))
+}
+
+func (s *Server) gitlabListTree(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	entries, res, err := s.Client.Contents.List(r.Context(), repo, query.Get("path"), query.Get("ref"))
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*gitlabTreeEntry{}
+	for _, e := range entries {
+		entry := &gitlabTreeEntry{ID: e.Sha, Name: e.Name, Type: "blob", Path: e.Path, Mode: "100644"}
+		if e.Type == "dir" {
+			entry.Type = "tree"
+			entry.Mode = "040000"
+		}
+		out = append(out, entry)
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) gitlabFindFile(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	ref := r.URL.Query().Get("ref")
+	c, res, err := s.Client.Contents.Find(r.Context(), repo, p["path"], ref)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	sha := s.sha(r, repo, ref)
+	writeJSON(w, http.StatusOK, &gitlabFile{
+		FileName:     p["path"][strings.LastIndex(p["path"], "/")+1:],
+		FilePath:     c.Path,
+		Size:         len(c.Data),
+		Encoding:     "base64",
+		Content:      base64.StdEncoding.EncodeToString(c.Data),
+		Ref:          ref,
+		BlobID:       c.Sha,
+		CommitID:     sha,
+		LastCommitID: sha,
+	})
+}
+
+func (s *Server) gitlabWriteFile(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	in := struct {
+		Branch   string `json:"branch"`
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+		Message  string `json:"commit_message"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	data := []byte(in.Content)
+	if in.Encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(in.Content)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return
+		}
+		data = decoded
+	}
+	ctx := r.Context()
+	params := &scm.ContentParams{Branch: in.Branch, Message: in.Message, Data: data}
+	var res *scm.Response
+	var err error
+	status := http.StatusCreated
+	if r.Method == http.MethodPut {
+		existing, findRes, findErr := s.Client.Contents.Find(ctx, repo, p["path"], in.Branch)
+		if findErr != nil {
+			writeError(w, findRes, findErr)
+			return
+		}
+		params.Sha = existing.Sha
+		status = http.StatusOK
+		res, err = s.Client.Contents.Update(ctx, repo, p["path"], params)
+	} else {
+		res, err = s.Client.Contents.Create(ctx, repo, p["path"], params)
+	}
+	if err != nil {
+		if res == nil || res.Status < 400 {
+			res = &scm.Response{Status: http.StatusBadRequest}
+		}
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, status, map[string]string{"file_path": p["path"], "branch": in.Branch})
+}
+
+func (s *Server) gitlabDeleteFile(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	in := struct {
+		Branch  string `json:"branch"`
+		Message string `json:"commit_message"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	branch := in.Branch
+	if branch == "" {
+		branch = r.URL.Query().Get("branch")
+	}
+	res, err := s.Client.Contents.Delete(r.Context(), repo, p["path"], &scm.ContentParams{Branch: branch, Message: in.Message})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) gitlabListMergeRequests(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	opts := scm.PullRequestListOptions{Open: true, Closed: true}
+	state := query.Get("state")
+	switch state {
+	case "opened":
+		opts = scm.PullRequestListOptions{Open: true}
+	case "closed", "merged":
+		opts = scm.PullRequestListOptions{Closed: true}
+	}
+	if labels := query.Get("labels"); labels != "" {
+		opts.Labels = strings.Split(labels, ",")
+	}
+	prs, res, err := s.Client.PullRequests.List(r.Context(), repo, opts)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*gitlabMergeRequest{}
+	for _, pr := range prs {
+		mr := s.gitlabMergeRequestFrom(repo, pr)
+		// GitLab distinguishes merged from closed merge requests
+		if (state == "closed" || state == "merged") && mr.State != state {
+			continue
+		}
+		out = append(out, mr)
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) gitlabCreateMergeRequest(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	in := struct {
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+		SourceBranch string `json:"source_branch"`
+		TargetBranch string `json:"target_branch"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	if in.Title == "" || in.SourceBranch == "" || in.TargetBranch == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "title, source_branch and target_branch are required"})
+		return
+	}
+	pr, res, err := s.Client.PullRequests.Create(r.Context(), repo, &scm.PullRequestInput{
+		Title: in.Title,
+		Body:  in.Description,
+		Head:  in.SourceBranch,
+		Base:  in.TargetBranch,
+	})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.gitlabMergeRequestFrom(repo, pr))
+}
+
+func (s *Server) gitlabFindMergeRequest(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	n, ok := number(w, p, "iid")
+	if !ok {
+		return
+	}
+	pr, res, err := s.Client.PullRequests.Find(r.Context(), repo, n)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.gitlabMergeRequestFrom(repo, pr))
+}
+
+// gitlabUpdateMergeRequest handles both the query parameters and the JSON body
+// forms of updating a merge request used by the driver
+func (s *Server) gitlabUpdateMergeRequest(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	n, ok := number(w, p, "iid")
+	if !ok {
+		return
+	}
+	in := struct {
+		Title        *string `json:"title"`
+		Description  *string `json:"description"`
+		TargetBranch *string `json:"target_branch"`
+		MilestoneID  *int    `json:"milestone_id"`
+		StateEvent   *string `json:"state_event"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	ctx := r.Context()
+	if _, res, err := s.Client.PullRequests.Find(ctx, repo, n); err != nil {
+		writeError(w, res, err)
+		return
+	}
+	query := r.URL.Query()
+	var res *scm.Response
+	var err error
+	for _, label := range splitList(query.Get("add_labels")) {
+		if res, err = s.Client.PullRequests.AddLabel(ctx, repo, n, label); err != nil {
+			writeError(w, res, err)
+			return
+		}
+	}
+	for _, label := range splitList(query.Get("remove_labels")) {
+		if res, err = s.Client.PullRequests.DeleteLabel(ctx, repo, n, label); err != nil {
+			writeError(w, res, err)
+			return
+		}
+	}
+	update := &scm.PullRequestInput{}
+	if in.Title != nil {
+		update.Title = *in.Title
+	}
+	if in.Description != nil {
+		update.Body = *in.Description
+	}
+	if in.TargetBranch != nil {
+		update.Base = *in.TargetBranch
+	}
+	if in.Title != nil || in.Description != nil || in.TargetBranch != nil {
+		if _, res, err = s.Client.PullRequests.Update(ctx, repo, n, update); err != nil {
+			writeError(w, res, err)
+			return
+		}
+	}
+	if in.MilestoneID != nil {
+		if *in.MilestoneID == 0 {
+			res, err = s.Client.PullRequests.ClearMilestone(ctx, repo, n)
+		} else {
+			res, err = s.Client.PullRequests.SetMilestone(ctx, repo, n, *in.MilestoneID)
+		}
+		if err != nil {
+			writeError(w, res, err)
+			return
+		}
+	}
+	stateEvent := query.Get("state_event")
+	if in.StateEvent != nil {
+		stateEvent = *in.StateEvent
+	}
+	switch stateEvent {
+	case "close", "closed":
+		res, err = s.Client.PullRequests.Close(ctx, repo, n)
+	case "reopen":
+		res, err = s.Client.PullRequests.Reopen(ctx, repo, n)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	s.gitlabFindMergeRequest(w, r, p)
+}
+
+func (s *Server) gitlabMergeMergeRequest(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	n, ok := number(w, p, "iid")
+	if !ok {
+		return
+	}
+	in := struct {
+		CommitMessage       string `json:"merge_commit_message"`
+		SquashCommitMessage string `json:"squash_commit_message"`
+		Squash              string `json:"squash"`
+		SHA                 string `json:"sha"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	ctx := r.Context()
+	if _, res, err := s.Client.PullRequests.Find(ctx, repo, n); err != nil {
+		writeError(w, res, err)
+		return
+	}
+	opts := &scm.PullRequestMergeOptions{CommitTitle: in.CommitMessage, SHA: in.SHA}
+	if in.Squash == "true" {
+		opts.MergeMethod = "squash"
+		opts.CommitTitle = in.SquashCommitMessage
+	}
+	if res, err := s.Client.PullRequests.Merge(ctx, repo, n, opts); err != nil {
+		writeError(w, res, err)
+		return
+	}
+	s.gitlabFindMergeRequest(w, r, p)
+}
+
+func (s *Server) gitlabListIssues(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	opts := scm.IssueListOptions{Open: true, Closed: true}
+	switch r.URL.Query().Get("state") {
+	case "opened":
+		opts = scm.IssueListOptions{Open: true}
+	case "closed":
+		opts = scm.IssueListOptions{Closed: true}
+	}
+	issues, res, err := s.Client.Issues.List(r.Context(), repo, opts)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*gitlabIssue{}
+	for _, issue := range issues {
+		out = append(out, s.gitlabIssueFrom(repo, issue))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) gitlabCreateIssue(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	if query.Get("title") == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "title is missing"})
+		return
+	}
+	issue, res, err := s.Client.Issues.Create(r.Context(), repo, &scm.IssueInput{
+		Title: query.Get("title"),
+		Body:  query.Get("description"),
+	})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.gitlabIssueFrom(repo, issue))
+}
+
+func (s *Server) gitlabFindIssue(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	n, ok := number(w, p, "iid")
+	if !ok {
+		return
+	}
+	issue, res, err := s.Client.Issues.Find(r.Context(), repo, n)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.gitlabIssueFrom(repo, issue))
+}
+
+// gitlabUpdateIssue handles both the query parameters and the JSON body forms
+// of updating an issue used by the driver
+func (s *Server) gitlabUpdateIssue(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	n, ok := number(w, p, "iid")
+	if !ok {
+		return
+	}
+	in := struct {
+		MilestoneID *int    `json:"milestone_id"`
+		StateEvent  *string `json:"state_event"`
+	}{}
+	if !decode(w, r, &in) {
+		return
+	}
+	ctx := r.Context()
+	issue, res, err := s.Client.Issues.Find(ctx, repo, n)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	query := r.URL.Query()
+	if _, ok := query["labels"]; ok {
+		// the labels parameter replaces all of the labels of the issue
+		wanted := map[string]bool{}
+		for _, label := range splitList(query.Get("labels")) {
+			wanted[label] = true
+		}
+		for _, label := range issue.Labels {
+			if !wanted[label] {
+				if res, err = s.Client.Issues.DeleteLabel(ctx, repo, n, label); err != nil {
+					writeError(w, res, err)
+					return
+				}
+			}
+			delete(wanted, label)
+		}
+		for label := range wanted {
+			if res, err = s.Client.Issues.AddLabel(ctx, repo, n, label); err != nil {
+				writeError(w, res, err)
+				return
+			}
+		}
+	}
+	switch query.Get("discussion_locked") {
+	case "true":
+		res, err = s.Client.Issues.Lock(ctx, repo, n)
+	case "false":
+		res, err = s.Client.Issues.Unlock(ctx, repo, n)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	if in.MilestoneID != nil {
+		if *in.MilestoneID == 0 {
+			res, err = s.Client.Issues.ClearMilestone(ctx, repo, n)
+		} else {
+			res, err = s.Client.Issues.SetMilestone(ctx, repo, n, *in.MilestoneID)
+		}
+		if err != nil {
+			writeError(w, res, err)
+			return
+		}
+	}
+	stateEvent := query.Get("state_event")
+	if in.StateEvent != nil {
+		stateEvent = *in.StateEvent
+	}
+	switch stateEvent {
+	case "close":
+		res, err = s.Client.Issues.Close(ctx, repo, n)
+	case "reopen":
+		res, err = s.Client.Issues.Reopen(ctx, repo, n)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	s.gitlabFindIssue(w, r, p)
+}
+
+// gitlabNoteable returns the repository and number of the issue or merge
+// request whose notes are being requested
+func (s *Server) gitlabNoteable(w http.ResponseWriter, r *http.Request, p params) (string, int, bool, bool) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return "", 0, false, false
+	}
+	n, ok := number(w, p, "iid")
+	if !ok {
+		return "", 0, false, false
+	}
+	isMergeRequest := strings.Contains(r.URL.Path, "/merge_requests/")
+	var res *scm.Response
+	var err error
+	if isMergeRequest {
+		_, res, err = s.Client.PullRequests.Find(r.Context(), repo, n)
+	} else {
+		_, res, err = s.Client.Issues.Find(r.Context(), repo, n)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return "", 0, false, false
+	}
+	return repo, n, isMergeRequest, true
+}
+
+func (s *Server) gitlabListNotes(w http.ResponseWriter, r *http.Request, p params) {
+	repo, n, isMergeRequest, ok := s.gitlabNoteable(w, r, p)
+	if !ok {
+		return
+	}
+	var comments []*scm.Comment
+	var res *scm.Response
+	var err error
+	if isMergeRequest {
+		comments, res, err = s.Client.PullRequests.ListComments(r.Context(), repo, n, scm.ListOptions{})
+	} else {
+		comments, res, err = s.Client.Issues.ListComments(r.Context(), repo, n, scm.ListOptions{})
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*gitlabNote{}
+	for _, c := range comments {
+		out = append(out, gitlabNoteFrom(n, c))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) gitlabCreateNote(w http.ResponseWriter, r *http.Request, p params) {
+	repo, n, isMergeRequest, ok := s.gitlabNoteable(w, r, p)
+	if !ok {
+		return
+	}
+	body := r.URL.Query().Get("body")
+	if body == "" {
+		in := struct {
+			Body string `json:"body"`
+		}{}
+		if !decode(w, r, &in) {
+			return
+		}
+		body = in.Body
+	}
+	if body == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "body is missing"})
+		return
+	}
+	in := &scm.CommentInput{Body: body}
+	var c *scm.Comment
+	var res *scm.Response
+	var err error
+	if isMergeRequest {
+		c, res, err = s.Client.PullRequests.CreateComment(r.Context(), repo, n, in)
+	} else {
+		c, res, err = s.Client.Issues.CreateComment(r.Context(), repo, n, in)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, gitlabNoteFrom(n, c))
+}
+
+func (s *Server) gitlabFindNote(w http.ResponseWriter, r *http.Request, p params) {
+	repo, n, isMergeRequest, ok := s.gitlabNoteable(w, r, p)
+	if !ok {
+		return
+	}
+	id, ok := number(w, p, "note")
+	if !ok {
+		return
+	}
+	var c *scm.Comment
+	var res *scm.Response
+	var err error
+	if isMergeRequest {
+		c, res, err = s.Client.PullRequests.FindComment(r.Context(), repo, n, id)
+	} else {
+		c, res, err = s.Client.Issues.FindComment(r.Context(), repo, n, id)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, gitlabNoteFrom(n, c))
+}
+
+func (s *Server) gitlabEditNote(w http.ResponseWriter, r *http.Request, p params) {
+	repo, n, isMergeRequest, ok := s.gitlabNoteable(w, r, p)
+	if !ok {
+		return
+	}
+	id, ok := number(w, p, "note")
+	if !ok {
+		return
+	}
+	body := struct {
+		Body string `json:"body"`
+	}{}
+	if !decode(w, r, &body) {
+		return
+	}
+	in := &scm.CommentInput{Body: body.Body}
+	var c *scm.Comment
+	var res *scm.Response
+	var err error
+	if isMergeRequest {
+		c, res, err = s.Client.PullRequests.EditComment(r.Context(), repo, n, id, in)
+	} else {
+		c, res, err = s.Client.Issues.EditComment(r.Context(), repo, n, id, in)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, gitlabNoteFrom(n, c))
+}
+
+func (s *Server) gitlabDeleteNote(w http.ResponseWriter, r *http.Request, p params) {
+	repo, n, isMergeRequest, ok := s.gitlabNoteable(w, r, p)
+	if !ok {
+		return
+	}
+	id, ok := number(w, p, "note")
+	if !ok {
+		return
+	}
+	var res *scm.Response
+	var err error
+	if isMergeRequest {
+		res, err = s.Client.PullRequests.DeleteComment(r.Context(), repo, n, id)
+	} else {
+		res, err = s.Client.Issues.DeleteComment(r.Context(), repo, n, id)
+	}
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) gitlabListLabels(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	labels, res, err := s.Client.Repositories.ListLabels(r.Context(), repo, scm.ListOptions{})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*gitlabLabel{}
+	for i, l := range labels {
+		out = append(out, &gitlabLabel{ID: i + 1, Name: l.Name, Color: l.Color, Description: l.Description})
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) gitlabListStatuses(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	sha := s.sha(r, repo, p["sha"])
+	statuses, res, err := s.Client.Repositories.ListStatus(r.Context(), repo, sha, scm.ListOptions{})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*gitlabStatus{}
+	for i, st := range statuses {
+		out = append(out, gitlabStatusFrom(i+1, sha, st))
+	}
+	s.writeList(w, r, out)
+}
+
+func (s *Server) gitlabCreateStatus(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	state := gitlabToState(query.Get("state"))
+	if state == scm.StateUnknown {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "state does not have a valid value"})
+		return
+	}
+	sha := s.sha(r, repo, p["sha"])
+	st, res, err := s.Client.Repositories.CreateStatus(r.Context(), repo, sha, &scm.StatusInput{
+		State:  state,
+		Label:  query.Get("name"),
+		Desc:   query.Get("description"),
+		Target: query.Get("target_url"),
+	})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, gitlabStatusFrom(len(s.Data.Statuses[sha]), sha, st))
+}
+
+func (s *Server) gitlabListHooks(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	hooks, res, err := s.Client.Repositories.ListHooks(r.Context(), repo, scm.ListOptions{})
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	out := []*gitlabHook{}
+	for _, h := range hooks {
+		out = append(out, s.gitlabHookFrom(repo, h))
+	}
+	s.writeList(w, r, out)
+}
+
+// gitlabHookInput converts the query parameters of the hooks API to the input
+// of the fake driver, recording the enabled events as native events
+func gitlabHookInput(r *http.Request) *scm.HookInput {
+	query := r.URL.Query()
+	in := &scm.HookInput{
+		Target:       query.Get("url"),
+		Secret:       query.Get("token"),
+		SkipVerify:   query.Get("enable_ssl_verification") == "false",
+		NativeEvents: []string{},
+	}
+	for _, event := range gitlabHookEvents {
+		if query.Get(event) == "true" {
+			in.NativeEvents = append(in.NativeEvents, event)
+		}
+	}
+	return in
+}
+
+func (s *Server) gitlabCreateHook(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	in := gitlabHookInput(r)
+	if in.Target == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "url is missing"})
+		return
+	}
+	if s.Data.Hooks == nil {
+		s.Data.Hooks = map[string][]*scm.Hook{}
+	}
+	hook, res, err := s.Client.Repositories.CreateHook(r.Context(), repo, in)
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.gitlabHookFrom(repo, hook))
+}
+
+func (s *Server) gitlabFindHook(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	hook, res, err := s.Client.Repositories.FindHook(r.Context(), repo, p["hook"])
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.gitlabHookFrom(repo, hook))
+}
+
+// gitlabUpdateHook updates the hook in place as the fake driver does not
+// support updating hooks
+func (s *Server) gitlabUpdateHook(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	hook, res, err := s.Client.Repositories.FindHook(r.Context(), repo, p["hook"])
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	in := gitlabHookInput(r)
+	if in.Target != "" {
+		hook.Target = in.Target
+	}
+	hook.Events = in.NativeEvents
+	hook.SkipVerify = in.SkipVerify
+	writeJSON(w, http.StatusOK, s.gitlabHookFrom(repo, hook))
+}
+
+func (s *Server) gitlabDeleteHook(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.gitlabProjectName(w, p)
+	if !ok {
+		return
+	}
+	if _, res, err := s.Client.Repositories.FindHook(r.Context(), repo, p["hook"]); err != nil {
+		writeError(w, res, err)
+		return
+	}
+	res, err := s.Client.Repositories.DeleteHook(r.Context(), repo, p["hook"])
+	if err != nil {
+		writeError(w, res, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func gitlabUserFrom(u *scm.User) gitlabUser {
+	return gitlabUser{
+		ID:        u.ID,
+		Username:  u.Login,
+		Name:      u.Name,
+		Email:     u.Email,
+		AvatarURL: u.Avatar,
+		State:     "active",
+		WebURL:    u.Link,
+	}
+}
+
+func gitlabUsersFrom(users []scm.User) []gitlabUser {
+	out := []gitlabUser{}
+	for i := range users {
+		out = append(out, gitlabUserFrom(&users[i]))
+	}
+	return out
+}
+
+func (s *Server) gitlabProjectFrom(repo *scm.Repository) *gitlabProject {
+	if repo.FullName == "" {
+		repo.FullName = scm.Join(repo.Namespace, repo.Name)
+	}
+	visibility := "public"
+	if repo.Private {
+		visibility = "private"
+	}
+	out := &gitlabProject{
+		ID:                s.repositoryID(repo),
+		Name:              repo.Name,
+		Path:              repo.Name,
+		PathWithNamespace: repo.FullName,
+		DefaultBranch:     repo.Branch,
+		Visibility:        visibility,
+		Archived:          repo.Archived,
+		WebURL:            fmt.Sprintf("%s/%s", s.URL, repo.FullName),
+		SSHURL:            repo.CloneSSH,
+		HTTPURL:           fmt.Sprintf("%s/%s.git", s.URL, repo.FullName),
+		Namespace: gitlabNamespace{
+			ID:       s.namespaceID(repo.Namespace),
+			Name:     repo.Namespace,
+			Path:     repo.Namespace,
+			Kind:     "group",
+			FullPath: repo.Namespace,
+		},
+		CreatedAt: repo.Created,
+	}
+	// maintainer access unless the repository has more restricted permissions
+	level := 40
+	if repo.Perm != nil && !repo.Perm.Admin {
+		level = 30
+		if !repo.Perm.Push {
+			level = 20
+		}
+	}
+	out.Permissions.ProjectAccess = &gitlabAccess{AccessLevel: level}
+	return out
+}
+
+func gitlabCommitFrom(c *scm.Commit) *gitlabCommit {
+	title := c.Message
+	if i := strings.Index(title, "\n"); i >= 0 {
+		title = title[:i]
+	}
+	shortID := c.Sha
+	if len(shortID) > 8 {
+		shortID = shortID[:8]
+	}
+	return &gitlabCommit{
+		ID:             c.Sha,
+		ShortID:        shortID,
+		Title:          title,
+		Message:        c.Message,
+		AuthorName:     c.Author.Name,
+		AuthorEmail:    c.Author.Email,
+		AuthoredDate:   c.Author.Date,
+		CommitterName:  c.Committer.Name,
+		CommitterEmail: c.Committer.Email,
+		CommittedDate:  c.Committer.Date,
+		CreatedAt:      c.Committer.Date,
+		ParentIDs:      []string{},
+		WebURL:         c.Link,
+	}
+}
+
+func (s *Server) gitlabBranchFrom(repo string, ref *scm.Reference) *gitlabBranch {
+	out := &gitlabBranch{Name: ref.Name, Commit: &gitlabCommit{ID: ref.Sha}}
+	if c, ok := s.Data.Commits[ref.Sha]; ok {
+		out.Commit = gitlabCommitFrom(c)
+	}
+	if found, ok := s.findRepository(repo); ok {
+		out.Default = found.Branch == ref.Name
+	}
+	return out
+}
+
+func (s *Server) gitlabMergeRequestFrom(repo string, pr *scm.PullRequest) *gitlabMergeRequest {
+	state := "opened"
+	switch {
+	case pr.Merged:
+		state = "merged"
+	case pr.Closed:
+		state = "closed"
+	}
+	mergeStatus := "unchecked"
+	switch {
+	case pr.Mergeable || pr.MergeableState == scm.MergeableStateMergeable:
+		mergeStatus = "can_be_merged"
+	case pr.MergeableState == scm.MergeableStateConflicting:
+		mergeStatus = "cannot_be_merged"
+	}
+	projectID := 0
+	if found, ok := s.findRepository(repo); ok {
+		projectID = s.repositoryID(found)
+	}
+	sourceProjectID := projectID
+	if pr.Head.Repo.FullName != "" {
+		if found, ok := s.findRepository(pr.Head.Repo.FullName); ok {
+			sourceProjectID = s.repositoryID(found)
+		}
+	}
+	headSha := pr.Head.Sha
+	if headSha == "" {
+		headSha = pr.Sha
+	}
+	out := &gitlabMergeRequest{
+		ID:              pr.Number,
+		IID:             pr.Number,
+		ProjectID:       projectID,
+		Sha:             headSha,
+		MergeCommitSha:  pr.MergeSha,
+		Title:           pr.Title,
+		Description:     pr.Body,
+		State:           state,
+		SourceProjectID: sourceProjectID,
+		TargetProjectID: projectID,
+		SourceBranch:    pr.Head.Ref,
+		TargetBranch:    pr.Base.Ref,
+		Labels:          []string{},
+		WebURL:          fmt.Sprintf("%s/%s/-/merge_requests/%d", s.URL, repo, pr.Number),
+		WorkInProgress:  pr.Draft,
+		Draft:           pr.Draft,
+		Author:          gitlabUserFrom(&pr.Author),
+		Assignees:       gitlabUsersFrom(pr.Assignees),
+		Reviewers:       gitlabUsersFrom(pr.Reviewers),
+		MergeStatus:     mergeStatus,
+		CreatedAt:       pr.Created,
+		UpdatedAt:       pr.Updated,
+	}
+	out.DiffRefs.BaseSha = pr.Base.Sha
+	out.DiffRefs.HeadSha = headSha
+	for _, l := range pr.Labels {
+		out.Labels = append(out.Labels, l.Name)
+	}
+	return out
+}
+
+func (s *Server) gitlabIssueFrom(repo string, issue *scm.Issue) *gitlabIssue {
+	state := "opened"
+	if issue.Closed {
+		state = "closed"
+	}
+	projectID := 0
+	if found, ok := s.findRepository(repo); ok {
+		projectID = s.repositoryID(found)
+	}
+	out := &gitlabIssue{
+		ID:               issue.Number,
+		IID:              issue.Number,
+		ProjectID:        projectID,
+		State:            state,
+		Title:            issue.Title,
+		Description:      issue.Body,
+		WebURL:           fmt.Sprintf("%s/%s/-/issues/%d", s.URL, repo, issue.Number),
+		DiscussionLocked: issue.Locked,
+		Labels:           []string{},
+		Author:           gitlabUserFrom(&issue.Author),
+		Assignees:        gitlabUsersFrom(issue.Assignees),
+		CreatedAt:        issue.Created,
+		UpdatedAt:        issue.Updated,
+	}
+	out.Labels = append(out.Labels, issue.Labels...)
+	return out
+}
+
+func gitlabNoteFrom(number int, c *scm.Comment) *gitlabNote {
+	return &gitlabNote{
+		ID:          c.ID,
+		NoteableIID: number,
+		Author:      gitlabUserFrom(&c.Author),
+		Body:        c.Body,
+		CreatedAt:   c.Created,
+		UpdatedAt:   c.Updated,
+	}
+}
+
+func gitlabStatusFrom(id int, sha string, st *scm.Status) *gitlabStatus {
+	return &gitlabStatus{
+		ID:          id,
+		Sha:         sha,
+		Status:      gitlabFromState(st.State),
+		Name:        st.Label,
+		TargetURL:   st.Target,
+		Description: st.Desc,
+	}
+}
+
+func (s *Server) gitlabHookFrom(repo string, h *scm.Hook) *gitlabHook {
+	id, _ := strconv.Atoi(h.ID)
+	out := &gitlabHook{
+		ID:                    id,
+		URL:                   h.Target,
+		EnableSslVerification: !h.SkipVerify,
+	}
+	if found, ok := s.findRepository(repo); ok {
+		out.ProjectID = s.repositoryID(found)
+	}
+	for _, event := range h.Events {
+		switch event {
+		case "push_events":
+			out.PushEvents = true
+		case "issues_events":
+			out.IssuesEvents = true
+		case "merge_requests_events":
+			out.MergeRequestsEvents = true
+		case "tag_push_events":
+			out.TagPushEvents = true
+		case "note_events":
+			out.NoteEvents = true
+		case "releases_events":
+			out.ReleasesEvents = true
+		}
+	}
+	return out
+}
+
+func gitlabToState(s string) scm.State {
+	switch s {
+	case "pending":
+		return scm.StatePending
+	case "running":
+		return scm.StateRunning
+	case "success":
+		return scm.StateSuccess
+	case "failed":
+		return scm.StateFailure
+	case "canceled":
+		return scm.StateCanceled
+	default:
+		return scm.StateUnknown
+	}
+}
+
+func gitlabFromState(state scm.State) string {
+	switch state {
+	case scm.StatePending, scm.StateExpected:
+		return "pending"
+	case scm.StateRunning:
+		return "running"
+	case scm.StateSuccess:
+		return "success"
+	case scm.StateCanceled:
+		return "canceled"
+	default:
+		return "failed"
+	}
+}
+
+// splitList splits a comma separated list ignoring empty values
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
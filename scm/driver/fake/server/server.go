@@ -0,0 +1,353 @@
+// Package server provides an httptest based server which implements a subset
+// of the GitHub and GitLab REST APIs on top of the in memory fake driver.
+//
+// This lets the real drivers, along with their transports, authentication and
+// pagination, be tested end to end without mocking individual requests:
+//
+//	srv := server.NewGitHub()
+//	defer srv.Close()
+//	client, _ := github.New(srv.URL)
+//
+// The state of the server can be seeded and inspected via the Data of the
+// fake driver or by using the Client directly.
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/pkg/errors"
+)
+
+// Server is a fake git provider server backed by the fake driver
+type Server struct {
+	*httptest.Server
+
+	// Client the fake client which stores the state of the server
+	Client *scm.Client
+
+	// Data the data of the fake client
+	Data *fake.Data
+
+	// Token if specified is the token all requests must be authenticated with
+	Token string
+
+	mu          sync.Mutex
+	routes      []*route
+	defaultSize int
+	ids         map[string]int
+	namespaces  map[string]int
+}
+
+// params the named parameters of a matched route
+type params map[string]string
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, p params)
+
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+}
+
+// NewGitHub returns a started server which implements the GitHub REST API.
+// The caller should call Close when finished
+func NewGitHub() *Server {
+	s := newServer(30)
+	s.registerGitHub()
+	return s
+}
+
+// NewGitLab returns a started server which implements the GitLab REST API.
+// The caller should call Close when finished
+func NewGitLab() *Server {
+	s := newServer(20)
+	s.registerGitLab()
+	return s
+}
+
+func newServer(defaultSize int) *Server {
+	client, data := fake.NewDefault()
+	data.ContentDir = ""
+	s := &Server{
+		Client:      client,
+		Data:        data,
+		defaultSize: defaultSize,
+		ids:         map[string]int{},
+		namespaces:  map[string]int{},
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// handle registers the handler for the method and path pattern. Pattern
+// segments starting with ':' match a single path segment and a final segment
+// starting with '*' matches the rest of the path
+func (s *Server) handle(method, pattern string, handler handlerFunc) {
+	s.routes = append(s.routes, &route{
+		method:   method,
+		segments: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler:  handler,
+	})
+}
+
+// ServeHTTP authenticates the request and dispatches it to the matching route
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.authenticated(r) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Bad credentials"})
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")
+	for i, segment := range segments {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segments[i] = unescaped
+		}
+	}
+	// the contents APIs allow an empty trailing path
+	if strings.HasSuffix(r.URL.EscapedPath(), "/") {
+		segments = append(segments, "")
+	}
+
+	methodAllowed := true
+	for _, rt := range s.routes {
+		p, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		if rt.method != r.Method {
+			methodAllowed = false
+			continue
+		}
+		rt.handler(w, r, p)
+		return
+	}
+	if !methodAllowed {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"message": "Method Not Allowed"})
+		return
+	}
+	writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+}
+
+func (rt *route) match(segments []string) (params, bool) {
+	p := params{}
+	for i, pattern := range rt.segments {
+		if strings.HasPrefix(pattern, "*") {
+			if i > len(segments) {
+				return nil, false
+			}
+			p[pattern[1:]] = strings.Trim(strings.Join(segments[i:], "/"), "/")
+			return p, true
+		}
+		if i >= len(segments) {
+			return nil, false
+		}
+		switch {
+		case strings.HasPrefix(pattern, ":"):
+			if segments[i] == "" {
+				return nil, false
+			}
+			p[pattern[1:]] = segments[i]
+		case pattern != segments[i]:
+			return nil, false
+		}
+	}
+	return p, len(segments) == len(rt.segments)
+}
+
+// authenticated returns true if no token is required or the request includes
+// the token in any of the headers used by the drivers
+func (s *Server) authenticated(r *http.Request) bool {
+	if s.Token == "" {
+		return true
+	}
+	if r.Header.Get("Private-Token") == s.Token {
+		return true
+	}
+	auth := r.Header.Get("Authorization")
+	for _, prefix := range []string{"Bearer ", "token "} {
+		if auth == prefix+s.Token {
+			return true
+		}
+	}
+	return false
+}
+
+// repositoryID returns the numeric id of the repository, allocating one if
+// the repository does not have a numeric ID yet
+func (s *Server) repositoryID(repo *scm.Repository) int {
+	if id, err := strconv.Atoi(repo.ID); err == nil && id > 0 {
+		s.ids[repo.FullName] = id
+		return id
+	}
+	id, ok := s.ids[repo.FullName]
+	if !ok {
+		id = len(s.ids) + 1
+		s.ids[repo.FullName] = id
+	}
+	repo.ID = strconv.Itoa(id)
+	return id
+}
+
+// namespaceID returns the numeric id of the namespace
+func (s *Server) namespaceID(namespace string) int {
+	id, ok := s.namespaces[namespace]
+	if !ok {
+		id = len(s.namespaces) + 1
+		s.namespaces[namespace] = id
+	}
+	return id
+}
+
+// findRepository returns the repository with the given full name or numeric id
+func (s *Server) findRepository(name string) (*scm.Repository, bool) {
+	for _, repo := range s.Data.Repositories {
+		if repo.FullName == "" {
+			repo.FullName = scm.Join(repo.Namespace, repo.Name)
+		}
+		if repo.FullName == name || strconv.Itoa(s.repositoryID(repo)) == name {
+			return repo, true
+		}
+	}
+	return nil, false
+}
+
+// sha resolves the branch to its sha, otherwise the ref is assumed to be a sha
+func (s *Server) sha(r *http.Request, repo, ref string) string {
+	if branch, _, err := s.Client.Git.FindBranch(r.Context(), repo, ref); err == nil && branch != nil {
+		return branch.Sha
+	}
+	return ref
+}
+
+// commentNumber returns the issue or pull request number of the comment
+func (s *Server) commentNumber(id int) (int, bool) {
+	for _, comments := range []map[int][]*scm.Comment{s.Data.IssueComments, s.Data.PullRequestComments} {
+		for number, list := range comments {
+			for _, c := range list {
+				if c.ID == id {
+					return number, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+// isPullRequest returns true if the number is a pull request in the repository
+func (s *Server) isPullRequest(r *http.Request, repo string, number int) bool {
+	_, _, err := s.Client.PullRequests.Find(r.Context(), repo, number)
+	return err == nil
+}
+
+// writeList writes the page of the items slice requested via the page and
+// per_page query parameters along with the Link header used for pagination
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, items interface{}) {
+	v := reflect.ValueOf(items)
+	total := v.Len()
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	size, _ := strconv.Atoi(query.Get("per_page"))
+	if size < 1 {
+		size = s.defaultSize
+	}
+	last := (total + size - 1) / size
+	if last < 1 {
+		last = 1
+	}
+
+	var links []string
+	link := func(p int, rel string) {
+		query.Set("page", strconv.Itoa(p))
+		links = append(links, fmt.Sprintf(`<%s%s?%s>; rel="%s"`, s.URL, r.URL.EscapedPath(), query.Encode(), rel))
+	}
+	if page < last {
+		link(page+1, "next")
+		link(last, "last")
+	}
+	if page > 1 {
+		link(1, "first")
+		link(page-1, "prev")
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+	w.Header().Set("X-Total", strconv.Itoa(total))
+	w.Header().Set("X-Total-Pages", strconv.Itoa(last))
+
+	start := (page - 1) * size
+	if start > total {
+		start = total
+	}
+	end := start + size
+	if end > total {
+		end = total
+	}
+	writeJSON(w, http.StatusOK, v.Slice(start, end).Interface())
+}
+
+// writeJSON writes the value as the JSON body of the response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+// writeError writes the error returned by the fake driver using the status
+// of its response if there is one
+func writeError(w http.ResponseWriter, res *scm.Response, err error) {
+	status := http.StatusUnprocessableEntity
+	switch {
+	case res != nil && res.Status >= 400:
+		status = res.Status
+	case scm.IsScmNotFound(err):
+		status = http.StatusNotFound
+	case errors.Cause(err) == scm.ErrNotSupported:
+		status = http.StatusNotImplemented
+	}
+	writeJSON(w, status, map[string]string{"message": err.Error()})
+}
+
+// notFound writes a 404 response
+func notFound(w http.ResponseWriter, format string, args ...interface{}) {
+	writeJSON(w, http.StatusNotFound, map[string]string{"message": fmt.Sprintf(format, args...)})
+}
+
+// decode decodes the JSON body of the request
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.Body == nil || r.ContentLength == 0 {
+		return true
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return false
+	}
+	return true
+}
+
+// number parses the named numeric parameter
+func number(w http.ResponseWriter, p params, name string) (int, bool) {
+	n, err := strconv.Atoi(p[name])
+	if err != nil {
+		notFound(w, "invalid %s %q", name, p[name])
+		return 0, false
+	}
+	return n, true
+}
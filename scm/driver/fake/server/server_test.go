@@ -0,0 +1,211 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake/server"
+	"github.com/jenkins-x/go-scm/scm/driver/github"
+	"github.com/jenkins-x/go-scm/scm/driver/gitlab"
+	"github.com/jenkins-x/go-scm/scm/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubServer(t *testing.T) {
+	srv := server.NewGitHub()
+	defer srv.Close()
+
+	client, err := github.New(srv.URL)
+	require.NoError(t, err)
+	testServer(t, client, "myorg/github")
+}
+
+func TestGitLabServer(t *testing.T) {
+	srv := server.NewGitLab()
+	defer srv.Close()
+
+	client, err := gitlab.New(srv.URL)
+	require.NoError(t, err)
+	testServer(t, client, "myorg/gitlab")
+}
+
+// testServer exercises the services supported by the server using a real driver
+func testServer(t *testing.T, client *scm.Client, fullName string) {
+	ctx := context.Background()
+	namespace, name := scm.Split(fullName)
+
+	repo, _, err := client.Repositories.Create(ctx, &scm.RepositoryInput{Namespace: namespace, Name: name})
+	require.NoError(t, err, "failed to create repository")
+	assert.Equal(t, fullName, repo.FullName)
+
+	repo, _, err = client.Repositories.Find(ctx, fullName)
+	require.NoError(t, err, "failed to find repository")
+	assert.Equal(t, "master", repo.Branch)
+
+	_, _, err = client.Repositories.Find(ctx, "myorg/doesnotexist")
+	require.Error(t, err, "should not find a missing repository")
+
+	err = createContent(ctx, client, fullName, "README.md", "master", "hello")
+	require.NoError(t, err, "failed to create file on master")
+
+	c, _, err := client.Contents.Find(ctx, fullName, "README.md", "master")
+	require.NoError(t, err, "failed to find file")
+	assert.Equal(t, "hello", string(c.Data))
+
+	master, _, err := client.Git.FindBranch(ctx, fullName, "master")
+	require.NoError(t, err, "failed to find master")
+	_, _, err = client.Git.CreateRef(ctx, fullName, "refs/heads/feature", master.Sha)
+	require.NoError(t, err, "failed to create branch")
+	err = createContent(ctx, client, fullName, "docs/feature.md", "feature", "my feature")
+	require.NoError(t, err, "failed to create file on feature")
+
+	branches, _, err := client.Git.ListBranches(ctx, fullName, scm.ListOptions{})
+	require.NoError(t, err, "failed to list branches")
+	assert.Len(t, branches, 2)
+
+	entries, _, err := client.Contents.List(ctx, fullName, "docs", "feature")
+	require.NoError(t, err, "failed to list files")
+	require.Len(t, entries, 1)
+	assert.Equal(t, "feature.md", entries[0].Name)
+
+	// lets create enough pull requests to need a second page
+	for _, title := range []string{"first", "second", "third"} {
+		head := "feature"
+		if title != "first" {
+			head = title
+			_, _, err = client.Git.CreateRef(ctx, fullName, "refs/heads/"+head, master.Sha)
+			require.NoError(t, err, "failed to create branch %s", head)
+		}
+		_, _, err = client.PullRequests.Create(ctx, fullName, &scm.PullRequestInput{
+			Title: title,
+			Head:  head,
+			Base:  "master",
+		})
+		require.NoError(t, err, "failed to create pull request %s", title)
+	}
+	prs, res, err := client.PullRequests.List(ctx, fullName, scm.PullRequestListOptions{Open: true, Size: 2})
+	require.NoError(t, err, "failed to list pull requests")
+	assert.Len(t, prs, 2)
+	assert.Equal(t, 2, res.Page.Next, "next page")
+	prs, res, err = client.PullRequests.List(ctx, fullName, scm.PullRequestListOptions{Open: true, Page: 2, Size: 2})
+	require.NoError(t, err, "failed to list pull requests")
+	assert.Len(t, prs, 1)
+	assert.Equal(t, 0, res.Page.Next, "next page")
+
+	pr, _, err := client.PullRequests.Find(ctx, fullName, 1)
+	require.NoError(t, err, "failed to find pull request")
+	assert.Equal(t, "first", pr.Title)
+	assert.Equal(t, "feature", pr.Head.Ref)
+	assert.Equal(t, "master", pr.Base.Ref)
+	assert.False(t, pr.Closed)
+
+	commits, _, err := client.PullRequests.ListCommits(ctx, fullName, pr.Number, scm.ListOptions{})
+	require.NoError(t, err, "failed to list pull request commits")
+	require.Len(t, commits, 1)
+	assert.Equal(t, pr.Head.Sha, commits[0].Sha)
+
+	_, err = client.PullRequests.AddLabel(ctx, fullName, pr.Number, "lgtm")
+	require.NoError(t, err, "failed to add label")
+	labels, _, err := client.PullRequests.ListLabels(ctx, fullName, pr.Number, scm.ListOptions{})
+	require.NoError(t, err, "failed to list labels")
+	require.Len(t, labels, 1)
+	assert.Equal(t, "lgtm", labels[0].Name)
+
+	comment, _, err := client.PullRequests.CreateComment(ctx, fullName, pr.Number, &scm.CommentInput{Body: "looks good"})
+	require.NoError(t, err, "failed to comment on pull request")
+	_, _, err = client.PullRequests.EditComment(ctx, fullName, pr.Number, comment.ID, &scm.CommentInput{Body: "looks great"})
+	require.NoError(t, err, "failed to edit comment")
+	comments, _, err := client.PullRequests.ListComments(ctx, fullName, pr.Number, scm.ListOptions{})
+	require.NoError(t, err, "failed to list comments")
+	require.Len(t, comments, 1)
+	assert.Equal(t, "looks great", comments[0].Body)
+
+	_, _, err = client.Repositories.CreateStatus(ctx, fullName, pr.Head.Sha, &scm.StatusInput{
+		State: scm.StateSuccess,
+		Label: "ci",
+		Desc:  "all good",
+	})
+	require.NoError(t, err, "failed to create status")
+	statuses, _, err := client.Repositories.ListStatus(ctx, fullName, pr.Head.Sha, scm.ListOptions{})
+	require.NoError(t, err, "failed to list statuses")
+	require.Len(t, statuses, 1)
+	assert.Equal(t, scm.StateSuccess, statuses[0].State)
+	assert.Equal(t, "ci", statuses[0].Label)
+
+	_, err = client.PullRequests.Merge(ctx, fullName, pr.Number, &scm.PullRequestMergeOptions{})
+	require.NoError(t, err, "failed to merge pull request")
+	pr, _, err = client.PullRequests.Find(ctx, fullName, pr.Number)
+	require.NoError(t, err, "failed to find pull request")
+	assert.True(t, pr.Merged, "merged")
+
+	c, _, err = client.Contents.Find(ctx, fullName, "docs/feature.md", "master")
+	require.NoError(t, err, "the merge should bring the file to master")
+	assert.Equal(t, "my feature", string(c.Data))
+
+	_, err = client.PullRequests.Close(ctx, fullName, 2)
+	require.NoError(t, err, "failed to close pull request")
+	prs, _, err = client.PullRequests.List(ctx, fullName, scm.PullRequestListOptions{Open: true})
+	require.NoError(t, err, "failed to list pull requests")
+	assert.Len(t, prs, 1)
+
+	issue, _, err := client.Issues.Create(ctx, fullName, &scm.IssueInput{Title: "broken", Body: "it is broken"})
+	require.NoError(t, err, "failed to create issue")
+	assert.Equal(t, 4, issue.Number, "issues and pull requests share numbers")
+	_, _, err = client.Issues.CreateComment(ctx, fullName, issue.Number, &scm.CommentInput{Body: "me too"})
+	require.NoError(t, err, "failed to comment on issue")
+	_, err = client.Issues.AddLabel(ctx, fullName, issue.Number, "bug")
+	require.NoError(t, err, "failed to label issue")
+	_, err = client.Issues.Close(ctx, fullName, issue.Number)
+	require.NoError(t, err, "failed to close issue")
+	issue, _, err = client.Issues.Find(ctx, fullName, issue.Number)
+	require.NoError(t, err, "failed to find issue")
+	assert.True(t, issue.Closed, "closed")
+	assert.Equal(t, []string{"bug"}, issue.Labels)
+
+	hook, _, err := client.Repositories.CreateHook(ctx, fullName, &scm.HookInput{
+		Target: "https://example.com/hook",
+		Events: scm.HookEvents{Push: true},
+	})
+	require.NoError(t, err, "failed to create hook")
+	hooks, _, err := client.Repositories.ListHooks(ctx, fullName, scm.ListOptions{})
+	require.NoError(t, err, "failed to list hooks")
+	require.Len(t, hooks, 1)
+	assert.Equal(t, "https://example.com/hook", hooks[0].Target)
+	_, err = client.Repositories.DeleteHook(ctx, fullName, hook.ID)
+	require.NoError(t, err, "failed to delete hook")
+	hooks, _, err = client.Repositories.ListHooks(ctx, fullName, scm.ListOptions{})
+	require.NoError(t, err, "failed to list hooks")
+	assert.Empty(t, hooks)
+}
+
+// createContent creates the file using the content service of the client
+func createContent(ctx context.Context, client *scm.Client, repo, path, branch, text string) error {
+	_, err := client.Contents.Create(ctx, repo, path, &scm.ContentParams{
+		Branch:  branch,
+		Message: "add " + path,
+		Data:    []byte(text),
+	})
+	return err
+}
+
+func TestServerToken(t *testing.T) {
+	srv := server.NewGitLab()
+	defer srv.Close()
+	srv.Token = "mytoken"
+
+	client, err := gitlab.New(srv.URL)
+	require.NoError(t, err)
+	_, res, err := client.Users.Find(context.Background())
+	require.Error(t, err, "should fail without a token")
+	assert.Equal(t, http.StatusUnauthorized, res.Status)
+
+	client.Client = &http.Client{
+		Transport: &transport.PrivateToken{Token: "mytoken"},
+	}
+	user, _, err := client.Users.Find(context.Background())
+	require.NoError(t, err, "failed to find user with a token")
+	assert.Equal(t, "fakeuser", user.Login)
+}
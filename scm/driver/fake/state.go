@@ -0,0 +1,368 @@
+package fake
+
+import (
+	"crypto/sha1" // #nosec
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+const (
+	headsPrefix = "heads/"
+	tagsPrefix  = "tags/"
+
+	defaultBranch = "master"
+)
+
+// nextNumber returns the next free issue or pull request number.
+// Issues and pull requests share the same sequence like they do on GitHub
+// so that comments, labels and events keyed by number never collide
+func (d *Data) nextNumber(repo string) int {
+	for {
+		d.PullRequestID++
+		n := d.PullRequestID
+		if _, ok := d.PullRequests[n]; ok {
+			continue
+		}
+		if len(d.Issues[n]) > 0 {
+			continue
+		}
+		if d.IssueRepos == nil {
+			d.IssueRepos = map[int]string{}
+		}
+		d.IssueRepos[n] = repo
+		return n
+	}
+}
+
+// ownedBy returns false if the number is known to belong to a different repository
+func (d *Data) ownedBy(repo string, number int) bool {
+	owner := d.IssueRepos[number]
+	return owner == "" || repo == "" || owner == repo
+}
+
+// pullRequest returns the pull request in the given repository
+func (d *Data) pullRequest(repo string, number int) (*scm.PullRequest, error) {
+	pr := d.PullRequests[number]
+	if pr == nil || !d.ownedBy(repo, number) {
+		return nil, scm.ErrNotFound
+	}
+	fullName := pr.Base.Repo.FullName
+	if fullName == "" && pr.Base.Repo.Name != "" {
+		fullName = scm.Join(pr.Base.Repo.Namespace, pr.Base.Repo.Name)
+	}
+	if fullName != "" && repo != "" && fullName != repo {
+		return nil, scm.ErrNotFound
+	}
+	return pr, nil
+}
+
+// issue returns the issue in the given repository
+func (d *Data) issue(repo string, number int) (*scm.Issue, error) {
+	if !d.ownedBy(repo, number) {
+		return nil, scm.ErrNotFound
+	}
+	for _, issue := range d.Issues[number] {
+		if issue.Number == number {
+			return issue, nil
+		}
+	}
+	for _, slice := range d.Issues {
+		for _, issue := range slice {
+			if issue.Number == number {
+				return issue, nil
+			}
+		}
+	}
+	return nil, scm.ErrNotFound
+}
+
+// newSha generates a unique fake git sha
+func (d *Data) newSha(parts ...string) string {
+	parts = append(parts, fmt.Sprintf("%d", len(d.Commits)), time.Now().String())
+	sum := sha1.Sum([]byte(strings.Join(parts, "\n"))) // #nosec
+	return hex.EncodeToString(sum[:])
+}
+
+// refs returns the refs of the given repository
+func (d *Data) refs(repo string) map[string]string {
+	if d.Refs == nil {
+		d.Refs = map[string]map[string]string{}
+	}
+	m := d.Refs[repo]
+	if m == nil {
+		m = map[string]string{}
+		d.Refs[repo] = m
+	}
+	return m
+}
+
+// resolve returns the sha of a branch, tag, ref or sha in the given repository
+func (d *Data) resolve(repo, ref string) (string, bool) {
+	ref = strings.TrimPrefix(ref, "refs/")
+	refs := d.Refs[repo]
+	for _, name := range []string{ref, headsPrefix + ref, tagsPrefix + ref} {
+		if sha, ok := refs[name]; ok {
+			return sha, true
+		}
+	}
+	if _, ok := d.Commits[ref]; ok {
+		return ref, true
+	}
+	return "", false
+}
+
+// listRefs returns the references with the given prefix sorted by name
+func (d *Data) listRefs(repo, prefix string) []*scm.Reference {
+	var answer []*scm.Reference
+	for name, sha := range d.Refs[repo] {
+		if strings.HasPrefix(name, prefix) {
+			answer = append(answer, &scm.Reference{
+				Name: strings.TrimPrefix(name, prefix),
+				Path: "refs/" + name,
+				Sha:  sha,
+			})
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Name < answer[j].Name
+	})
+	return answer
+}
+
+// commit records a new commit on the given branch, creating the branch if
+// required, and moves the branch to point at it
+func (d *Data) commit(repo, branch, message string, parents ...string) *scm.Commit {
+	if branch == "" {
+		branch = defaultBranch
+	}
+	refs := d.refs(repo)
+	if len(parents) == 0 {
+		if head, ok := refs[headsPrefix+branch]; ok {
+			parents = []string{head}
+		}
+	}
+	now := time.Now()
+	author := scm.Signature{
+		Name:  d.CurrentUser.Name,
+		Email: d.CurrentUser.Email,
+		Login: d.CurrentUser.Login,
+		Date:  now,
+	}
+	sha := d.newSha(repo, branch, message)
+	c := &scm.Commit{
+		Sha:       sha,
+		Message:   message,
+		Author:    author,
+		Committer: author,
+		Link:      fmt.Sprintf("https://fake.com/%s/commit/%s", repo, sha),
+	}
+	if d.Commits == nil {
+		d.Commits = map[string]*scm.Commit{}
+	}
+	if d.CommitParents == nil {
+		d.CommitParents = map[string][]string{}
+	}
+	d.Commits[sha] = c
+	d.CommitParents[sha] = parents
+	d.files(sha, parents...)
+	refs[headsPrefix+branch] = sha
+	return c
+}
+
+// files records the files of a new commit as those of its parents. Files
+// from later parents win so merge commits include the merged changes
+func (d *Data) files(sha string, parents ...string) map[string][]byte {
+	if d.Files == nil {
+		d.Files = map[string]map[string][]byte{}
+	}
+	files := map[string][]byte{}
+	for _, parent := range parents {
+		for path, data := range d.Files[parent] {
+			files[path] = data
+		}
+	}
+	d.Files[sha] = files
+	return files
+}
+
+// history walks the first parent history of the given sha, newest first
+func (d *Data) history(sha string) []*scm.Commit {
+	var answer []*scm.Commit
+	seen := map[string]bool{}
+	for sha != "" && !seen[sha] {
+		seen[sha] = true
+		c := d.Commits[sha]
+		if c == nil {
+			break
+		}
+		answer = append(answer, c)
+		parents := d.CommitParents[sha]
+		if len(parents) == 0 {
+			break
+		}
+		sha = parents[0]
+	}
+	return answer
+}
+
+// matchesCommit returns true if the commit matches the filters of the options.
+// The history is always the first parent history so FirstParent is implied
+func (d *Data) matchesCommit(c *scm.Commit, opts scm.CommitListOptions) bool {
+	parents := d.CommitParents[c.Sha]
+	if opts.NoMerges && len(parents) > 1 {
+		return false
+	}
+	if opts.Author != "" && opts.Author != c.Author.Login && opts.Author != c.Author.Name && opts.Author != c.Author.Email {
+		return false
+	}
+	if !opts.Since.IsZero() && c.Author.Date.Before(opts.Since) {
+		return false
+	}
+	if !opts.Until.IsZero() && c.Author.Date.After(opts.Until) {
+		return false
+	}
+	if opts.Path != "" {
+		parent := ""
+		if len(parents) > 0 {
+			parent = parents[0]
+		}
+		prefix := strings.TrimSuffix(opts.Path, "/") + "/"
+		for _, change := range d.changes(parent, c.Sha) {
+			if change.Path == opts.Path || strings.HasPrefix(change.Path, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// changes returns the files changed between the two commits sorted by path
+func (d *Data) changes(from, to string) []*scm.Change {
+	before, after := d.Files[from], d.Files[to]
+	answer := []*scm.Change{}
+	for path, data := range after {
+		old, ok := before[path]
+		if ok && string(old) == string(data) {
+			continue
+		}
+		answer = append(answer, &scm.Change{Path: path, Added: !ok})
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			answer = append(answer, &scm.Change{Path: path, Deleted: true})
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Path < answer[j].Path
+	})
+	return answer
+}
+
+// pullRequestCommits returns the commits on the head of the pull request
+// which are not on its base, oldest first
+func (d *Data) pullRequestCommits(repo string, number int) []scm.Commit {
+	pr, err := d.pullRequest(repo, number)
+	if err != nil {
+		return nil
+	}
+	onBase := map[string]bool{}
+	for _, c := range d.history(pr.Base.Sha) {
+		onBase[c.Sha] = true
+	}
+	var answer []scm.Commit
+	for _, c := range d.history(pr.Head.Sha) {
+		if onBase[c.Sha] {
+			break
+		}
+		answer = append([]scm.Commit{*c}, answer...)
+	}
+	return answer
+}
+
+// addLabel adds the label to the list of labels if its not already present
+func addLabel(labels []*scm.Label, name string) []*scm.Label {
+	for _, l := range labels {
+		if l.Name == name {
+			return labels
+		}
+	}
+	return append(labels, &scm.Label{
+		ID:   int64(len(labels)),
+		Name: name,
+	})
+}
+
+// removeLabel removes the label from the list of labels
+func removeLabel(labels []*scm.Label, name string) []*scm.Label {
+	answer := labels[:0]
+	for _, l := range labels {
+		if l.Name != name {
+			answer = append(answer, l)
+		}
+	}
+	return answer
+}
+
+// addUsers adds the users with the given logins if they are not already present
+func addUsers(users []scm.User, logins []string) []scm.User {
+	for _, login := range logins {
+		found := false
+		for _, u := range users {
+			if u.Login == login {
+				found = true
+				break
+			}
+		}
+		if !found {
+			users = append(users, scm.User{Login: login})
+		}
+	}
+	return users
+}
+
+// removeUsers removes the users with the given logins
+func removeUsers(users []scm.User, logins []string) []scm.User {
+	var answer []scm.User
+	for _, u := range users {
+		remove := false
+		for _, login := range logins {
+			if u.Login == login {
+				remove = true
+				break
+			}
+		}
+		if !remove {
+			answer = append(answer, u)
+		}
+	}
+	return answer
+}
+
+// findComment returns the comment with the given id
+func findComment(comments []*scm.Comment, id int) *scm.Comment {
+	for _, c := range comments {
+		if c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+// labelEvent records a label event for the issue or pull request
+func (d *Data) labelEvent(number int, event, label string) {
+	if d.IssueEvents == nil {
+		d.IssueEvents = map[int][]*scm.ListedIssueEvent{}
+	}
+	d.IssueEvents[number] = append(d.IssueEvents[number], &scm.ListedIssueEvent{
+		Event:   event,
+		Actor:   d.CurrentUser,
+		Label:   scm.Label{Name: label},
+		Created: time.Now(),
+	})
+}
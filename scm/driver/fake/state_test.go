@@ -0,0 +1,263 @@
+package fake_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestLifecycle(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/lifecycle"
+
+	_, _, err := client.Repositories.Create(ctx, &scm.RepositoryInput{Namespace: "myorg", Name: "lifecycle"})
+	require.NoError(t, err, "failed to create repository")
+
+	master, _, err := client.Git.FindBranch(ctx, repo, "master")
+	require.NoError(t, err, "failed to find master branch")
+
+	_, _, err = client.Git.CreateRef(ctx, repo, "refs/heads/feature", master.Sha)
+	require.NoError(t, err, "failed to create feature branch")
+
+	data.ContentDir, err = ioutil.TempDir("", "test-fake-content-")
+	require.NoError(t, err, "failed to create temp dir")
+	defer os.RemoveAll(data.ContentDir)
+	err = os.MkdirAll(filepath.Join(data.ContentDir, repo), 0755)
+	require.NoError(t, err, "failed to create repository dir")
+
+	_, err = client.Contents.Create(ctx, repo, "README.md", &scm.ContentParams{Branch: "feature", Message: "add readme", Data: []byte("hello")})
+	require.NoError(t, err, "failed to create file")
+
+	feature, _, err := client.Git.FindBranch(ctx, repo, "feature")
+	require.NoError(t, err, "failed to find feature branch")
+	assert.NotEqual(t, master.Sha, feature.Sha, "feature branch should have moved")
+
+	pr, _, err := client.PullRequests.Create(ctx, repo, &scm.PullRequestInput{
+		Title: "my change",
+		Head:  "feature",
+		Base:  "master",
+	})
+	require.NoError(t, err, "failed to create pull request")
+	assert.Equal(t, feature.Sha, pr.Head.Sha, "pull request head sha")
+	assert.Equal(t, master.Sha, pr.Base.Sha, "pull request base sha")
+	assert.Equal(t, "open", pr.State)
+
+	_, err = client.PullRequests.AddLabel(ctx, repo, pr.Number, "approved")
+	require.NoError(t, err, "failed to add label")
+	_, _, err = client.PullRequests.CreateComment(ctx, repo, pr.Number, &scm.CommentInput{Body: "/lgtm"})
+	require.NoError(t, err, "failed to comment")
+
+	found, _, err := client.PullRequests.Find(ctx, repo, pr.Number)
+	require.NoError(t, err, "failed to find pull request")
+	require.Len(t, found.Labels, 1)
+	assert.Equal(t, "approved", found.Labels[0].Name)
+
+	labels, _, err := client.PullRequests.ListLabels(ctx, repo, pr.Number, scm.ListOptions{})
+	require.NoError(t, err, "failed to list labels")
+	require.Len(t, labels, 1)
+	assert.Equal(t, "approved", labels[0].Name)
+	assert.Equal(t, []string{repo + "#1:approved"}, data.PullRequestLabelsAdded)
+
+	comments, _, err := client.PullRequests.ListComments(ctx, repo, pr.Number, scm.ListOptions{})
+	require.NoError(t, err, "failed to list comments")
+	require.Len(t, comments, 1)
+	assert.Equal(t, "/lgtm", comments[0].Body)
+
+	_, _, err = client.PullRequests.Find(ctx, "myorg/other", pr.Number)
+	assert.True(t, scm.IsScmNotFound(err), "pull request should not be found in another repository")
+
+	_, err = client.PullRequests.Merge(ctx, repo, pr.Number, nil)
+	require.NoError(t, err, "failed to merge")
+
+	found, _, err = client.PullRequests.Find(ctx, repo, pr.Number)
+	require.NoError(t, err, "failed to find pull request")
+	assert.True(t, found.Merged, "pull request should be merged")
+	assert.True(t, found.Closed, "pull request should be closed")
+
+	master, _, err = client.Git.FindBranch(ctx, repo, "master")
+	require.NoError(t, err, "failed to find master branch")
+	assert.Equal(t, found.MergeSha, master.Sha, "master should point at the merge commit")
+
+	commits, _, err := client.Git.ListCommits(ctx, repo, scm.CommitListOptions{Ref: "master"})
+	require.NoError(t, err, "failed to list commits")
+	require.Len(t, commits, 2)
+	assert.Equal(t, master.Sha, commits[0].Sha)
+
+	open, _, err := client.PullRequests.List(ctx, repo, scm.PullRequestListOptions{Open: true})
+	require.NoError(t, err, "failed to list pull requests")
+	assert.Empty(t, open, "should have no open pull requests")
+}
+
+func TestIssueLifecycle(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/issues"
+
+	issue, _, err := client.Issues.Create(ctx, repo, &scm.IssueInput{Title: "bug", Body: "it broke"})
+	require.NoError(t, err, "failed to create issue")
+
+	_, err = client.Issues.AddLabel(ctx, repo, issue.Number, "kind/bug")
+	require.NoError(t, err, "failed to add label")
+
+	labels, _, err := client.Issues.ListLabels(ctx, repo, issue.Number, scm.ListOptions{})
+	require.NoError(t, err, "failed to list labels")
+	require.Len(t, labels, 1)
+	assert.Equal(t, "kind/bug", labels[0].Name)
+
+	_, err = client.Issues.DeleteLabel(ctx, repo, issue.Number, "kind/bug")
+	require.NoError(t, err, "failed to remove label")
+
+	found, _, err := client.Issues.Find(ctx, repo, issue.Number)
+	require.NoError(t, err, "failed to find issue")
+	assert.Empty(t, found.Labels, "label should have been removed")
+	assert.Equal(t, []string{repo + "#1:kind/bug"}, data.IssueLabelsRemoved)
+
+	_, err = client.Issues.Close(ctx, repo, issue.Number)
+	require.NoError(t, err, "failed to close issue")
+
+	issues, _, err := client.Issues.List(ctx, repo, scm.IssueListOptions{Closed: true})
+	require.NoError(t, err, "failed to list issues")
+	require.Len(t, issues, 1)
+	assert.True(t, issues[0].Closed)
+
+	events, _, err := client.Issues.ListEvents(ctx, repo, issue.Number, scm.ListOptions{})
+	require.NoError(t, err, "failed to list events")
+	require.Len(t, events, 2)
+	assert.Equal(t, "labeled", events[0].Event)
+	assert.Equal(t, "unlabeled", events[1].Event)
+
+	pr, _, err := client.PullRequests.Create(ctx, repo, &scm.PullRequestInput{Title: "fix", Head: "fix", Base: "master"})
+	require.NoError(t, err, "failed to create pull request")
+	assert.NotEqual(t, issue.Number, pr.Number, "issues and pull requests should share numbering")
+}
+
+func TestPullRequestUpdateBranch(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/update"
+	// lets keep the file contents in memory so each commit has its own files
+	data.ContentDir = ""
+
+	_, _, err := client.Repositories.Create(ctx, &scm.RepositoryInput{Namespace: "myorg", Name: "update"})
+	require.NoError(t, err, "failed to create repository")
+
+	master, _, err := client.Git.FindBranch(ctx, repo, "master")
+	require.NoError(t, err, "failed to find master branch")
+	_, _, err = client.Git.CreateRef(ctx, repo, "refs/heads/feature", master.Sha)
+	require.NoError(t, err, "failed to create feature branch")
+
+	_, err = client.Contents.Create(ctx, repo, "feature.txt", &scm.ContentParams{Branch: "feature", Message: "add feature", Data: []byte("feature")})
+	require.NoError(t, err, "failed to create feature file")
+	_, err = client.Contents.Create(ctx, repo, "master.txt", &scm.ContentParams{Branch: "master", Message: "add master", Data: []byte("master")})
+	require.NoError(t, err, "failed to create master file")
+
+	pr, _, err := client.PullRequests.Create(ctx, repo, &scm.PullRequestInput{
+		Title: "my change",
+		Head:  "feature",
+		Base:  "master",
+		Draft: true,
+	})
+	require.NoError(t, err, "failed to create pull request")
+	assert.True(t, pr.Draft, "pull request should be a draft")
+	before := pr.Head.Sha
+
+	_, err = client.PullRequests.UpdateBranch(ctx, repo, pr.Number, "merge")
+	require.NoError(t, err, "failed to update branch")
+
+	found, _, err := client.PullRequests.Find(ctx, repo, pr.Number)
+	require.NoError(t, err, "failed to find pull request")
+	assert.NotEqual(t, before, found.Head.Sha, "head should have moved")
+	for _, path := range []string{"feature.txt", "master.txt"} {
+		_, _, err = client.Contents.Find(ctx, repo, path, found.Head.Sha)
+		assert.NoError(t, err, "head should contain %s", path)
+	}
+
+	_, err = client.PullRequests.UpdateBranch(ctx, repo, pr.Number, "squash")
+	assert.Error(t, err, "squash is not an update method")
+
+	_, err = client.PullRequests.MarkReady(ctx, repo, pr.Number)
+	require.NoError(t, err, "failed to mark pull request ready")
+	found, _, err = client.PullRequests.Find(ctx, repo, pr.Number)
+	require.NoError(t, err, "failed to find pull request")
+	assert.False(t, found.Draft, "pull request should be ready for review")
+
+	_, err = client.PullRequests.ConvertToDraft(ctx, repo, pr.Number)
+	require.NoError(t, err, "failed to convert pull request to draft")
+	found, _, err = client.PullRequests.Find(ctx, repo, pr.Number)
+	require.NoError(t, err, "failed to find pull request")
+	assert.True(t, found.Draft, "pull request should be a draft again")
+}
+
+func TestGitCompare(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/compare"
+	data.ContentDir = ""
+
+	_, _, err := client.Repositories.Create(ctx, &scm.RepositoryInput{Namespace: "myorg", Name: "compare"})
+	require.NoError(t, err, "failed to create repository")
+
+	master, _, err := client.Git.FindBranch(ctx, repo, "master")
+	require.NoError(t, err, "failed to find master branch")
+	_, _, err = client.Git.CreateRef(ctx, repo, "refs/heads/release", master.Sha)
+	require.NoError(t, err, "failed to create release branch")
+
+	_, err = client.Contents.Create(ctx, repo, "fix.txt", &scm.ContentParams{Branch: "release", Message: "add fix", Data: []byte("fix")})
+	require.NoError(t, err, "failed to create release file")
+	for _, name := range []string{"a.txt", "b.txt"} {
+		_, err = client.Contents.Create(ctx, repo, name, &scm.ContentParams{Branch: "master", Message: "add " + name, Data: []byte(name)})
+		require.NoError(t, err, "failed to create master file")
+	}
+
+	got, _, err := client.Git.Compare(ctx, repo, "release", "master", scm.ListOptions{})
+	require.NoError(t, err, "failed to compare")
+	assert.Equal(t, master.Sha, got.MergeBase, "merge base")
+	assert.Equal(t, 2, got.Ahead, "ahead")
+	assert.Equal(t, 1, got.Behind, "behind")
+	require.Len(t, got.Commits, 2, "commits")
+	assert.Equal(t, "add a.txt", got.Commits[0].Message)
+	assert.Equal(t, "add b.txt", got.Commits[1].Message)
+
+	changes, _, err := client.Git.CompareCommits(ctx, repo, "release", "master", scm.ListOptions{})
+	require.NoError(t, err, "failed to compare commits")
+	assert.Equal(t, []*scm.Change{{Path: "a.txt", Added: true}, {Path: "b.txt", Added: true}}, changes)
+}
+
+func TestGitListCommitsFilters(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/monorepo"
+	data.ContentDir = ""
+
+	_, _, err := client.Repositories.Create(ctx, &scm.RepositoryInput{Namespace: "myorg", Name: "monorepo"})
+	require.NoError(t, err, "failed to create repository")
+
+	since := time.Now()
+	for _, path := range []string{"apps/frontend/main.go", "apps/backend/main.go", "apps/frontend/README.md"} {
+		_, err = client.Contents.Create(ctx, repo, path, &scm.ContentParams{Message: "add " + path, Data: []byte(path)})
+		require.NoError(t, err, "failed to create %s", path)
+	}
+
+	commits, _, err := client.Git.ListCommits(ctx, repo, scm.CommitListOptions{Ref: "master", Path: "apps/frontend"})
+	require.NoError(t, err, "failed to list commits")
+	require.Len(t, commits, 2, "commits changing apps/frontend")
+	assert.Equal(t, "add apps/frontend/README.md", commits[0].Message)
+	assert.Equal(t, "add apps/frontend/main.go", commits[1].Message)
+
+	commits, _, err = client.Git.ListCommits(ctx, repo, scm.CommitListOptions{Ref: "master", Since: since, Author: data.CurrentUser.Login})
+	require.NoError(t, err, "failed to list commits")
+	assert.Len(t, commits, 3, "commits since the repository was created")
+
+	commits, _, err = client.Git.ListCommits(ctx, repo, scm.CommitListOptions{Ref: "master", Author: "somebody-else"})
+	require.NoError(t, err, "failed to list commits")
+	assert.Empty(t, commits, "commits by another author")
+}
@@ -0,0 +1,217 @@
+package fake
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/pkg/hmac"
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type webhookService struct {
+	client *wrapper
+}
+
+// Parse parses a JSON serialised scm.WebhookWrapper such as the ones created by
+// scm.NewWebhookWrapper for the webhooks emitted by the fake driver
+func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
+	data, err := ioutil.ReadAll(
+		io.LimitReader(req.Body, 10000000),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapper := &scm.WebhookWrapper{}
+	err = json.Unmarshal(data, wrapper)
+	if err != nil {
+		return nil, err
+	}
+	hook, err := wrapper.ToWebhook()
+	if err != nil {
+		return nil, err
+	}
+
+	// get the signature key to verify the payload
+	// signature. If no key is provided, no validation
+	// is performed.
+	key, err := fn(hook)
+	if err != nil {
+		return hook, err
+	} else if key == "" {
+		return hook, nil
+	}
+
+	sig := req.Header.Get("X-Hub-Signature")
+	if !hmac.ValidatePrefix(data, []byte(key), sig) {
+		return hook, scm.ErrSignatureInvalid
+	}
+	return hook, nil
+}
+
+// emit sends the webhook to the registered webhook listener and channel
+func (d *Data) emit(hook scm.Webhook) {
+	if d.WebhookListener != nil {
+		d.WebhookListener(hook)
+	}
+	if d.WebhookChannel != nil {
+		d.WebhookChannel <- hook
+	}
+}
+
+// emitting returns true if there is somewhere to send webhooks to
+func (d *Data) emitting() bool {
+	return d.WebhookListener != nil || d.WebhookChannel != nil
+}
+
+// repository returns the repository with the given full name
+func (d *Data) repository(fullName string) scm.Repository {
+	for _, repo := range d.Repositories {
+		if repo.FullName == fullName {
+			return *repo
+		}
+	}
+	namespace, name := scm.Split(fullName)
+	return scm.Repository{
+		Namespace: namespace,
+		Name:      name,
+		FullName:  fullName,
+		Branch:    defaultBranch,
+		Link:      "https://fake.com/" + fullName,
+		Clone:     "https://fake.com/" + fullName + ".git",
+	}
+}
+
+func (d *Data) emitPullRequest(repo string, action scm.Action, pr *scm.PullRequest, label string) {
+	if !d.emitting() {
+		return
+	}
+	d.emit(&scm.PullRequestHook{
+		Action:      action,
+		Repo:        d.repository(repo),
+		Label:       scm.Label{Name: label},
+		PullRequest: *pr,
+		Sender:      d.CurrentUser,
+	})
+}
+
+func (d *Data) emitIssue(repo string, action scm.Action, number int) {
+	if !d.emitting() {
+		return
+	}
+	issue, err := d.issue(repo, number)
+	if err != nil {
+		return
+	}
+	d.emit(&scm.IssueHook{
+		Action: action,
+		Repo:   d.repository(repo),
+		Issue:  *issue,
+		Sender: d.CurrentUser,
+	})
+}
+
+func (d *Data) emitComment(repo string, action scm.Action, number int, comment *scm.Comment) {
+	if !d.emitting() {
+		return
+	}
+	hook := &scm.IssueCommentHook{
+		Action:  action,
+		Repo:    d.repository(repo),
+		Comment: *comment,
+		Sender:  d.CurrentUser,
+	}
+	if pr, err := d.pullRequest(repo, number); err == nil {
+		hook.Issue = scm.Issue{
+			Number:      pr.Number,
+			Title:       pr.Title,
+			Body:        pr.Body,
+			Link:        pr.Link,
+			State:       pr.State,
+			Closed:      pr.Closed,
+			Author:      pr.Author,
+			PullRequest: true,
+			Created:     pr.Created,
+			Updated:     pr.Updated,
+		}
+	} else if issue, err := d.issue(repo, number); err == nil {
+		hook.Issue = *issue
+	} else {
+		hook.Issue = scm.Issue{Number: number}
+	}
+	d.emit(hook)
+}
+
+func (d *Data) emitRef(repo string, action scm.Action, name, sha string) {
+	if !d.emitting() {
+		return
+	}
+	ref := scm.Reference{
+		Name: strings.TrimPrefix(strings.TrimPrefix(name, headsPrefix), tagsPrefix),
+		Path: "refs/" + name,
+		Sha:  sha,
+	}
+	if strings.HasPrefix(name, tagsPrefix) {
+		d.emit(&scm.TagHook{
+			Ref:    ref,
+			Repo:   d.repository(repo),
+			Action: action,
+			Sender: d.CurrentUser,
+		})
+		return
+	}
+	d.emit(&scm.BranchHook{
+		Ref:    ref,
+		Repo:   d.repository(repo),
+		Action: action,
+		Sender: d.CurrentUser,
+	})
+}
+
+// push records a new commit on the branch, emits the push webhook and
+// synchronizes any open pull requests from the branch. If edit is not nil it
+// is invoked with the files of the new commit before any webhooks are emitted
+func (d *Data) push(repo, branch, message string, changes scm.PushCommit, edit func(files map[string][]byte), parents ...string) *scm.Commit {
+	if branch == "" {
+		branch = defaultBranch
+	}
+	before, exists := d.refs(repo)[headsPrefix+branch]
+	c := d.commit(repo, branch, message, parents...)
+	if edit != nil {
+		edit(d.Files[c.Sha])
+	}
+
+	if d.emitting() {
+		if !exists {
+			before = scm.EmptyCommit
+		}
+		changes.ID = c.Sha
+		changes.Message = c.Message
+		d.emit(&scm.PushHook{
+			Ref:     "refs/heads/" + branch,
+			Repo:    d.repository(repo),
+			Before:  before,
+			After:   c.Sha,
+			Created: !exists,
+			Commits: []scm.PushCommit{changes},
+			Commit:  *c,
+			Sender:  d.CurrentUser,
+		})
+	}
+
+	for _, pr := range d.PullRequests {
+		if pr.Closed || pr.Head.Ref != branch {
+			continue
+		}
+		if _, err := d.pullRequest(repo, pr.Number); err != nil {
+			continue
+		}
+		pr.Sha = c.Sha
+		pr.Head.Sha = c.Sha
+		d.emitPullRequest(repo, scm.ActionSync, pr, "")
+	}
+	return c
+}
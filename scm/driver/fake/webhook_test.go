@@ -0,0 +1,121 @@
+package fake_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooksEmitted(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+	repo := "myorg/hooks"
+
+	var hooks []scm.Webhook
+	data.WebhookListener = func(hook scm.Webhook) {
+		hooks = append(hooks, hook)
+	}
+
+	_, _, err := client.Repositories.Create(ctx, &scm.RepositoryInput{Namespace: "myorg", Name: "hooks"})
+	require.NoError(t, err, "failed to create repository")
+
+	pr, _, err := client.PullRequests.Create(ctx, repo, &scm.PullRequestInput{Title: "my change", Head: "feature", Base: "master"})
+	require.NoError(t, err, "failed to create pull request")
+
+	_, err = client.PullRequests.AddLabel(ctx, repo, pr.Number, "approved")
+	require.NoError(t, err, "failed to add label")
+
+	_, _, err = client.PullRequests.CreateComment(ctx, repo, pr.Number, &scm.CommentInput{Body: "/lgtm"})
+	require.NoError(t, err, "failed to comment")
+
+	_, err = client.PullRequests.Merge(ctx, repo, pr.Number, nil)
+	require.NoError(t, err, "failed to merge")
+
+	var kinds []scm.WebhookKind
+	for _, hook := range hooks {
+		kinds = append(kinds, hook.Kind())
+		assert.Equal(t, repo, hook.Repository().FullName, "repository of %s hook", hook.Kind())
+	}
+	assert.Equal(t, []scm.WebhookKind{
+		scm.WebhookKindRepository,
+		scm.WebhookKindPullRequest,
+		scm.WebhookKindPullRequest,
+		scm.WebhookKindIssueComment,
+		scm.WebhookKindPush,
+		scm.WebhookKindPullRequest,
+	}, kinds)
+
+	opened := hooks[1].(*scm.PullRequestHook)
+	assert.Equal(t, scm.ActionOpen, opened.Action)
+
+	labeled := hooks[2].(*scm.PullRequestHook)
+	assert.Equal(t, scm.ActionLabel, labeled.Action)
+	assert.Equal(t, "approved", labeled.Label.Name)
+
+	comment := hooks[3].(*scm.IssueCommentHook)
+	assert.True(t, comment.Issue.PullRequest, "comment should be on a pull request")
+	assert.Equal(t, "/lgtm", comment.Comment.Body)
+
+	push := hooks[4].(*scm.PushHook)
+	assert.Equal(t, "refs/heads/master", push.Ref)
+
+	merged := hooks[5].(*scm.PullRequestHook)
+	assert.Equal(t, scm.ActionClose, merged.Action)
+	assert.True(t, merged.PullRequest.Merged, "pull request should be merged")
+	assert.Equal(t, push.After, merged.PullRequest.MergeSha)
+}
+
+func TestWebhookChannel(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+
+	ch := make(chan scm.Webhook, 10)
+	data.WebhookChannel = ch
+
+	_, _, err := client.Issues.Create(ctx, "myorg/hooks", &scm.IssueInput{Title: "bug"})
+	require.NoError(t, err, "failed to create issue")
+
+	require.Len(t, ch, 1)
+	hook := <-ch
+	issue, ok := hook.(*scm.IssueHook)
+	require.True(t, ok, "expected an issue hook but got %T", hook)
+	assert.Equal(t, scm.ActionOpen, issue.Action)
+	assert.Equal(t, "bug", issue.Issue.Title)
+}
+
+func TestWebhookParse(t *testing.T) {
+	wrapper, err := scm.NewWebhookWrapper(&scm.PullRequestHook{
+		Action: scm.ActionOpen,
+		Repo: scm.Repository{
+			Namespace: "myorg",
+			Name:      "hooks",
+			FullName:  "myorg/hooks",
+		},
+		PullRequest: scm.PullRequest{Number: 1, Title: "my change"},
+	})
+	require.NoError(t, err, "failed to wrap webhook")
+
+	data, err := json.Marshal(wrapper)
+	require.NoError(t, err, "failed to marshal webhook")
+
+	req, err := http.NewRequest(http.MethodPost, "/hook", bytes.NewReader(data))
+	require.NoError(t, err, "failed to create request")
+
+	hook, err := fake.NewWebHookService().Parse(req, func(scm.Webhook) (string, error) {
+		return "", nil
+	})
+	require.NoError(t, err, "failed to parse webhook")
+
+	pr, ok := hook.(*scm.PullRequestHook)
+	require.True(t, ok, "expected a pull request hook but got %T", hook)
+	assert.Equal(t, scm.ActionOpen, pr.Action)
+	assert.Equal(t, "myorg/hooks", pr.Repo.FullName)
+	assert.Equal(t, "my change", pr.PullRequest.Title)
+}
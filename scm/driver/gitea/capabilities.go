@@ -0,0 +1,23 @@
+package gitea
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// unsupported lists the features of the services of the
+// client which return scm.ErrNotSupported.
+//
+// Pull requests never report their AutoMerge as Gitea does not
+// return it, although EnableAutoMerge and DisableAutoMerge are supported.
+var unsupported = []scm.Feature{
+	"Contents.Blame",
+	"Contents.Delete",
+	"Organizations.AcceptOrganizationInvitation",
+	"Organizations.ListMemberships",
+	"Organizations.ListPendingInvitations",
+	"Repositories.UpdateHook",
+	"Reviews.ResolveThread",
+	"Reviews.UnresolveThread",
+	"Search.Code",
+	"Search.Commits",
+	"Users.AcceptInvitation",
+	"Users.ListInvitations",
+}
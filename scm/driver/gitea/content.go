@@ -91,10 +91,14 @@ func (s *contentService) Update(ctx context.Context, repo, path string, params *
 	return toSCMResponse(resp), err
 }
 
-func (s *contentService) Delete(ctx context.Context, repo, path, ref string) (*scm.Response, error) {
+func (s *contentService) Delete(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
 	return nil, scm.ErrNotSupported
 }
 
+func (s *contentService) Blame(ctx context.Context, repo, path, ref string) ([]*scm.BlameRange, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
 func convertEntryList(out []*gitea.ContentsResponse) []*scm.FileEntry {
 	answer := make([]*scm.FileEntry, 0, len(out))
 	for _, o := range out {
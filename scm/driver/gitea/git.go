@@ -8,12 +8,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
 	"code.gitea.io/sdk/gitea"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/mergebase"
 )
 
 type gitService struct {
@@ -37,7 +39,19 @@ func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *sc
 }
 
 func (s *gitService) CreateRef(ctx context.Context, repo, ref, sha string) (*scm.Reference, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	// branches can only be created from a commit rather than a branch since 1.22
+	if err := s.client.checkVersion(">= 1.22.0"); err != nil {
+		return nil, nil, err
+	}
+	ref = strings.TrimPrefix(strings.TrimPrefix(ref, "refs/"), "heads/")
+	path := fmt.Sprintf("api/v1/repos/%s/branches", repo)
+	in := &branchInput{
+		Name: ref,
+		Ref:  sha,
+	}
+	out := new(gitea.Branch)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertBranch(out), res, err
 }
 
 func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Response, error) {
@@ -66,7 +80,13 @@ func (s *gitService) FindCommit(ctx context.Context, repo, ref string) (*scm.Com
 }
 
 func (s *gitService) FindTag(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	if err := s.client.checkVersion(">= 1.15.0"); err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/tags/%s", repo, url.PathEscape(name))
+	out := new(gitea.Tag)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertTag(out), res, err
 }
 
 func (s *gitService) ListBranches(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
@@ -76,17 +96,11 @@ func (s *gitService) ListBranches(ctx context.Context, repo string, opts scm.Lis
 }
 
 func (s *gitService) ListCommits(ctx context.Context, repo string, opts scm.CommitListOptions) ([]*scm.Commit, *scm.Response, error) {
-	namespace, name := scm.Split(repo)
-
-	listOpts := gitea.ListCommitOptions{
-		ListOptions: gitea.ListOptions{
-			Page:     opts.Page,
-			PageSize: opts.Size,
-		},
-		SHA: opts.Sha,
-	}
-	out, resp, err := s.client.GiteaClient.ListRepoCommits(namespace, name, listOpts)
-	return convertCommitList(out), toSCMResponse(resp), err
+	// the sdk does not support filtering the commits so lets make the request directly
+	path := fmt.Sprintf("api/v1/repos/%s/commits?%s", repo, encodeCommitListOptions(opts))
+	out := []*gitea.Commit{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertCommitList(filterCommits(out, opts.Author)), res, err
 }
 
 func (s *gitService) ListTags(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
@@ -97,11 +111,78 @@ func (s *gitService) ListTags(ctx context.Context, repo string, opts scm.ListOpt
 }
 
 func (s *gitService) ListChanges(ctx context.Context, repo, ref string, _ scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	// the files affected by a commit are reported since 1.15
+	if err := s.client.checkVersion(">= 1.15.0"); err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/git/commits/%s", repo, ref)
+	out := new(compareCommit)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertCompareChanges([]*compareCommit{out}), res, nil
 }
 
-func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, _ scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	out, res, err := s.Compare(ctx, repo, ref1, ref2, opts)
+	if err != nil {
+		return nil, res, err
+	}
+	return out.Changes, res, nil
+}
+
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, _ scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
+	ahead, res, err := s.compare(ctx, repo, base, head)
+	if err != nil {
+		return nil, res, err
+	}
+	// gitea only reports the commits of the head so lets compare
+	// the other way around to find the commits of the base
+	behind, res, err := s.compare(ctx, repo, head, base)
+	if err != nil {
+		return nil, res, err
+	}
+	out := &scm.Comparison{
+		Ahead:   ahead.total(),
+		Behind:  behind.total(),
+		Commits: []*scm.Commit{},
+		Changes: convertCompareChanges(ahead.Commits),
+	}
+	for _, v := range ahead.Commits {
+		out.Commits = append(out.Commits, convertCommit(&v.Commit))
+	}
+	// gitea does not report the merge base so it is found from the
+	// commits of both sides, or else is the ref of the side without any.
+	// It may be an older common ancestor if gitea truncated the commits
+	out.MergeBase = mergebase.Find(toMergeBaseCommits(ahead), toMergeBaseCommits(behind))
+	ref := ""
+	switch {
+	case len(ahead.Commits) == 0:
+		ref = head
+	case len(behind.Commits) == 0:
+		ref = base
+	}
+	if ref != "" {
+		commit, res, err := s.FindCommit(ctx, repo, ref)
+		if err != nil {
+			return nil, res, err
+		}
+		out.MergeBase = commit.Sha
+	}
+	return out, res, nil
+}
+
+// compare returns the commits of the head which are not on the base, oldest first
+func (s *gitService) compare(ctx context.Context, repo, base, head string) (*comparison, *scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/compare/%s...%s", repo, base, head)
+	out := new(comparison)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	// gitea lists the commits newest first
+	for i, j := 0, len(out.Commits)-1; i < j; i, j = i+1, j-1 {
+		out.Commits[i], out.Commits[j] = out.Commits[j], out.Commits[i]
+	}
+	return out, res, err
 }
 
 //
@@ -120,6 +201,30 @@ type (
 		Timestamp time.Time `json:"timestamp"`
 	}
 
+	// gitea branch input object.
+	branchInput struct {
+		Name string `json:"new_branch_name"`
+		Ref  string `json:"old_ref_name"`
+	}
+
+	// gitea comparison object.
+	comparison struct {
+		TotalCommits int              `json:"total_commits"`
+		Commits      []*compareCommit `json:"commits"`
+	}
+
+	// gitea commit object of a comparison.
+	compareCommit struct {
+		gitea.Commit
+		Files []*compareFile `json:"files"`
+	}
+
+	// gitea file affected by a commit.
+	compareFile struct {
+		Filename string `json:"filename"`
+		Status   string `json:"status"`
+	}
+
 	// gitea signature object.
 	signature struct {
 		Name     string `json:"name"`
@@ -170,6 +275,60 @@ func convertTag(src *gitea.Tag) *scm.Reference {
 	}
 }
 
+func (c *comparison) total() int {
+	if c.TotalCommits > len(c.Commits) {
+		return c.TotalCommits
+	}
+	return len(c.Commits)
+}
+
+func toMergeBaseCommits(from *comparison) []mergebase.Commit {
+	to := []mergebase.Commit{}
+	for _, v := range from.Commits {
+		c := mergebase.Commit{Sha: v.SHA}
+		for _, p := range v.Parents {
+			c.Parents = append(c.Parents, p.SHA)
+		}
+		to = append(to, c)
+	}
+	return to
+}
+
+// convertCompareChanges combines the files affected by each of the commits,
+// oldest first, into the changes between the merge base and the last commit
+func convertCompareChanges(src []*compareCommit) []*scm.Change {
+	type state struct {
+		path          string
+		before, after bool
+	}
+	var files []*state
+	seen := map[string]*state{}
+	for _, commit := range src {
+		for _, file := range commit.Files {
+			f, ok := seen[file.Filename]
+			if !ok {
+				f = &state{path: file.Filename, before: file.Status != "added"}
+				seen[file.Filename] = f
+				files = append(files, f)
+			}
+			f.after = file.Status != "removed" && file.Status != "deleted"
+		}
+	}
+	dst := []*scm.Change{}
+	for _, f := range files {
+		// files added and then removed again have not changed
+		if !f.before && !f.after {
+			continue
+		}
+		dst = append(dst, &scm.Change{
+			Path:    f.path,
+			Added:   !f.before,
+			Deleted: !f.after,
+		})
+	}
+	return dst
+}
+
 func convertCommitList(src []*gitea.Commit) []*scm.Commit {
 	dst := []*scm.Commit{}
 	for _, v := range src {
@@ -178,6 +337,27 @@ func convertCommitList(src []*gitea.Commit) []*scm.Commit {
 	return dst
 }
 
+// filterCommits filters the commits by the author which is not supported
+// by the gitea API, matching the login of the account or the name or email
+// of the git author
+func filterCommits(commits []*gitea.Commit, author string) []*gitea.Commit {
+	if author == "" {
+		return commits
+	}
+	dst := []*gitea.Commit{}
+	for _, c := range commits {
+		switch {
+		case c == nil:
+		case c.Author != nil && strings.EqualFold(c.Author.UserName, author):
+			dst = append(dst, c)
+		case c.RepoCommit != nil && c.RepoCommit.Author != nil &&
+			(strings.EqualFold(c.RepoCommit.Author.Name, author) || strings.EqualFold(c.RepoCommit.Author.Email, author)):
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
+
 func convertCommit(src *gitea.Commit) *scm.Commit {
 	if src == nil || src.RepoCommit == nil {
 		return nil
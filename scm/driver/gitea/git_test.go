@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -80,7 +81,81 @@ func TestCommitList(t *testing.T) {
 	}
 }
 
+func TestCommitListFilters(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/commits").
+		MatchParam("sha", "master").
+		MatchParam("path", "docs").
+		MatchParam("since", "2018-09-01T00:00:00Z").
+		MatchParam("limit", "10").
+		Reply(200).
+		Type("application/json").
+		File("testdata/commits.json")
+
+	client, _ := New("https://try.gitea.io")
+	opts := scm.CommitListOptions{
+		Ref:   "master",
+		Path:  "docs",
+		Since: time.Date(2018, time.September, 1, 0, 0, 0, 0, time.UTC),
+		Size:  10,
+	}
+	got, _, err := client.Git.ListCommits(context.Background(), "go-gitea/gitea", opts)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var want []*scm.Commit
+	raw, _ := ioutil.ReadFile("testdata/commits.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestCommitListAuthor(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/commits").
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		File("testdata/commits.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Git.ListCommits(context.Background(), "go-gitea/gitea", scm.CommitListOptions{Author: "lewiscowles@me.com"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(got) != 1 {
+		t.Errorf("Want the commit by the author, got %d commits", len(got))
+	}
+
+	got, _, err = client.Git.ListCommits(context.Background(), "go-gitea/gitea", scm.CommitListOptions{Author: "lunny"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(got) != 0 {
+		t.Errorf("Want no commits by the committer, got %d commits", len(got))
+	}
+}
+
 func TestChangeList(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
 	client, _ := New("https://try.gitea.io")
 	_, _, err := client.Git.ListChanges(context.Background(), "go-gitea/gitea", "f05f642b892d59a0a9ef6a31f6c905a24b5db13a", scm.ListOptions{})
 	if err != scm.ErrNotSupported {
@@ -88,14 +163,93 @@ func TestChangeList(t *testing.T) {
 	}
 }
 
+func TestChangeList_Latest(t *testing.T) {
+	defer gock.Off()
+
+	mockLatestServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/git/commits/f05f642b892d59a0a9ef6a31f6c905a24b5db13a").
+		Reply(200).
+		Type("application/json").
+		File("testdata/changes.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Git.ListChanges(context.Background(), "go-gitea/gitea", "f05f642b892d59a0a9ef6a31f6c905a24b5db13a", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Change{}
+	raw, _ := ioutil.ReadFile("testdata/changes.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestCompareCommits(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+	mockCompare()
+
 	client, _ := New("https://try.gitea.io")
-	_, _, err := client.Git.CompareCommits(context.Background(), "go-gitea/gitea", "21cf205dc770d637a9ba636644cf8bf690cc100d", "63aeb0a859499623becc1d1e7c8a2ad57439e139", scm.ListOptions{})
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	got, _, err := client.Git.CompareCommits(context.Background(), "go-gitea/gitea", "21cf205dc770d637a9ba636644cf8bf690cc100d", "63aeb0a859499623becc1d1e7c8a2ad57439e139", scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Change{
+		{Path: "routers/api/v1/repo/branch.go"},
+		{Path: "README.md"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+	mockCompare()
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Git.Compare(context.Background(), "go-gitea/gitea", "21cf205dc770d637a9ba636644cf8bf690cc100d", "63aeb0a859499623becc1d1e7c8a2ad57439e139", scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := ioutil.ReadFile("testdata/compare.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
 
+func mockCompare() {
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/compare/21cf205dc770d637a9ba636644cf8bf690cc100d...63aeb0a859499623becc1d1e7c8a2ad57439e139").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare.json")
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/compare/63aeb0a859499623becc1d1e7c8a2ad57439e139...21cf205dc770d637a9ba636644cf8bf690cc100d").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare_behind.json")
+}
+
 //
 // branch sub-tests
 //
@@ -157,15 +311,74 @@ func TestBranchList(t *testing.T) {
 	t.Run("Page", testPage(res))
 }
 
+func TestBranchCreate(t *testing.T) {
+	defer gock.Off()
+
+	mockLatestServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Post("/api/v1/repos/go-gitea/gitea/branches").
+		File("testdata/branch_create.json").
+		Reply(201).
+		Type("application/json").
+		File("testdata/branch.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Git.CreateRef(context.Background(), "go-gitea/gitea", "refs/heads/feature", "c43399cad8766ee521b873a32c1652407c5a4630")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Reference)
+	raw, _ := ioutil.ReadFile("testdata/branch.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestBranchCreate_OldVersion(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	client, _ := New("https://try.gitea.io")
+	_, _, err := client.Git.CreateRef(context.Background(), "go-gitea/gitea", "refs/heads/feature", "c43399cad8766ee521b873a32c1652407c5a4630")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
+
 //
 // tag sub-tests
 //
 
 func TestTagFind(t *testing.T) {
+	defer gock.Off()
+
+	mockLatestServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/tags/v1.0.0").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tag.json")
+
 	client, _ := New("https://try.gitea.io")
-	_, _, err := client.Git.FindTag(context.Background(), "go-gitea/gitea", "v1.0.0")
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	got, _, err := client.Git.FindTag(context.Background(), "go-gitea/gitea", "v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Reference)
+	raw, _ := ioutil.ReadFile("testdata/tag.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
 
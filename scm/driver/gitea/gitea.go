@@ -13,7 +13,9 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/jenkins-x/go-scm/scm"
@@ -55,9 +57,11 @@ func NewWithToken(uri string, token string) (*scm.Client, error) {
 	client.PullRequests = &pullService{&issueService{client}}
 	client.Repositories = &repositoryService{client}
 	client.Reviews = &reviewService{client}
+	client.Search = &searchService{client}
 	client.Releases = &releaseService{client}
 	client.Users = &userService{client}
 	client.Webhooks = &webhookService{client}
+	client.Unsupported = unsupported
 	return client.Client, nil
 }
 
@@ -87,8 +91,10 @@ func NewWithBasicAuth(uri string, user, password string) (*scm.Client, error) {
 	client.PullRequests = &pullService{&issueService{client}}
 	client.Repositories = &repositoryService{client}
 	client.Reviews = &reviewService{client}
+	client.Search = &searchService{client}
 	client.Users = &userService{client}
 	client.Webhooks = &webhookService{client}
+	client.Unsupported = unsupported
 	return client.Client, nil
 }
 
@@ -148,6 +154,15 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	return res, json.NewDecoder(res.Body).Decode(out)
 }
 
+// checkVersion returns scm.ErrNotSupported if the server does not
+// satisfy the version constraint of an api endpoint.
+func (c *wrapper) checkVersion(constraint string) error {
+	if err := c.GiteaClient.CheckServerVersionConstraint(constraint); err != nil {
+		return scm.ErrNotSupported
+	}
+	return nil
+}
+
 // toSCMResponse creates a new Response for the provided
 // http.Response. r must not be nil.
 func toSCMResponse(r *gitea.Response) *scm.Response {
@@ -163,6 +178,45 @@ func toSCMResponse(r *gitea.Response) *scm.Response {
 	return res
 }
 
+// encodeListOptions encodes the list options as query parameters for
+// requests which are not supported by the sdk
+func encodeListOptions(opts scm.ListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("limit", strconv.Itoa(opts.Size))
+	}
+	return params.Encode()
+}
+
+// encodeCommitListOptions encodes the commit list options as query parameters
+func encodeCommitListOptions(opts scm.CommitListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("limit", strconv.Itoa(opts.Size))
+	}
+	if opts.Sha != "" {
+		params.Set("sha", opts.Sha)
+	} else if opts.Ref != "" {
+		params.Set("sha", opts.Ref)
+	}
+	if opts.Path != "" {
+		params.Set("path", opts.Path)
+	}
+	if !opts.Since.IsZero() {
+		params.Set("since", opts.Since.UTC().Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		params.Set("until", opts.Until.UTC().Format(time.RFC3339))
+	}
+	return params.Encode()
+}
+
 func toGiteaListOptions(in scm.ListOptions) gitea.ListOptions {
 	return gitea.ListOptions{
 		Page:     in.Page,
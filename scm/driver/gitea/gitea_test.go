@@ -82,3 +82,13 @@ func mockServerVersion() {
 		Type("application/json").
 		File("testdata/version.json")
 }
+
+// mockLatestServerVersion mocks a server which exposes all of the api
+// endpoints the driver uses, including those only recently added.
+func mockLatestServerVersion() {
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/version").
+		Reply(200).
+		Type("application/json").
+		File("testdata/version_1_22.json")
+}
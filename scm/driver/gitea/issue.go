@@ -7,6 +7,9 @@ package gitea
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/jenkins-x/go-scm/scm"
@@ -18,9 +21,42 @@ type issueService struct {
 	client *wrapper
 }
 
-func (s *issueService) Search(context.Context, scm.SearchOptions) ([]*scm.SearchIssue, *scm.Response, error) {
-	// TODO implemment
-	return nil, nil, scm.ErrNotSupported
+// Search parses the GitHub search syntax of the query into the search
+// options of the gitea API, returning scm.ErrNotSupported for qualifiers
+// which cannot be mapped. The api does not sort the results so each page
+// is sorted as it is listed.
+func (s *issueService) Search(ctx context.Context, opts scm.SearchOptions) ([]*scm.SearchIssue, *scm.Response, error) {
+	q, kind, err := parseSearchQuery(opts.Query)
+	if err != nil {
+		return nil, nil, err
+	}
+	less, err := issueLess(opts.Sort)
+	if err != nil {
+		return nil, nil, err
+	}
+	search := &searchService{client: s.client}
+	out, res, err := search.searchIssues(ctx, q, kind)
+	if err != nil {
+		return nil, res, err
+	}
+	dst := []*gitea.Issue{}
+	for _, v := range out {
+		switch {
+		case q.State == "merged" && (v.PullRequest == nil || !v.PullRequest.HasMerged):
+		case q.Author != "" && (v.Poster == nil || !strings.EqualFold(v.Poster.UserName, q.Author)):
+		default:
+			dst = append(dst, v)
+		}
+	}
+	if less != nil {
+		sort.SliceStable(dst, func(i, j int) bool {
+			if opts.Ascending {
+				return less(dst[i], dst[j])
+			}
+			return less(dst[j], dst[i])
+		})
+	}
+	return convertSearchIssueList(dst), res, nil
 }
 
 func (s *issueService) AssignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
@@ -68,8 +104,14 @@ func (s *issueService) UnassignIssue(ctx context.Context, repo string, number in
 	return toSCMResponse(giteaResp), err
 }
 
-func (s *issueService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *issueService) ListEvents(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
+	if err := s.client.checkVersion(">= 1.16.0"); err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/timeline?%s", repo, number, encodeListOptions(opts))
+	out := []*timelineComment{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertTimelineComments(out), res, err
 }
 
 func (s *issueService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
@@ -108,30 +150,36 @@ func (s *issueService) lookupLabel(ctx context.Context, repo string, lbl string)
 }
 
 func (s *issueService) AddLabel(ctx context.Context, repo string, number int, lbl string) (*scm.Response, error) {
-	labelID, res, err := s.lookupLabel(ctx, repo, lbl)
+	labelID, res, err := s.findOrCreateLabel(ctx, repo, lbl)
 	if err != nil {
 		return res, err
 	}
 	namespace, name := scm.Split(repo)
 
-	if labelID == -1 {
-		lblInput := gitea.CreateLabelOption{
-			Color:       "#00aabb",
-			Description: "",
-			Name:        lbl,
-		}
-		newLabel, giteaResp, err := s.client.GiteaClient.CreateLabel(namespace, name, lblInput)
-		if err != nil {
-			return toSCMResponse(giteaResp), errors.Wrapf(err, "failed to create label %s in repository %s", lbl, repo)
-		}
-		labelID = newLabel.ID
-	}
-
 	in := gitea.IssueLabelsOption{Labels: []int64{labelID}}
 	_, giteaResp, err := s.client.GiteaClient.AddIssueLabels(namespace, name, int64(number), in)
 	return toSCMResponse(giteaResp), err
 }
 
+// findOrCreateLabel returns the id of the label, creating it if it does not exist
+func (s *issueService) findOrCreateLabel(ctx context.Context, repo string, lbl string) (int64, *scm.Response, error) {
+	labelID, res, err := s.lookupLabel(ctx, repo, lbl)
+	if err != nil || labelID != -1 {
+		return labelID, res, err
+	}
+	namespace, name := scm.Split(repo)
+	lblInput := gitea.CreateLabelOption{
+		Color:       "#00aabb",
+		Description: "",
+		Name:        lbl,
+	}
+	newLabel, giteaResp, err := s.client.GiteaClient.CreateLabel(namespace, name, lblInput)
+	if err != nil {
+		return 0, toSCMResponse(giteaResp), errors.Wrapf(err, "failed to create label %s in repository %s", lbl, repo)
+	}
+	return newLabel.ID, toSCMResponse(giteaResp), nil
+}
+
 func (s *issueService) DeleteLabel(ctx context.Context, repo string, number int, lbl string) (*scm.Response, error) {
 	labelID, res, err := s.lookupLabel(ctx, repo, lbl)
 	if err != nil {
@@ -254,11 +302,19 @@ func (s *issueService) Reopen(ctx context.Context, repo string, number int) (*sc
 }
 
 func (s *issueService) Lock(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	if err := s.client.checkVersion(">= 1.22.0"); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/lock", repo, number)
+	return s.client.do(ctx, "PUT", path, &lockInput{}, nil)
 }
 
 func (s *issueService) Unlock(ctx context.Context, repo string, number int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	if err := s.client.checkVersion(">= 1.22.0"); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/lock", repo, number)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
 func (s *issueService) SetMilestone(ctx context.Context, repo string, issueID int, number int) (*scm.Response, error) {
@@ -278,10 +334,112 @@ func (s *issueService) ClearMilestone(ctx context.Context, repo string, id int)
 	return toSCMResponse(resp), err
 }
 
+//
+// native data structures
+//
+
+type (
+	// gitea issue lock input object.
+	lockInput struct {
+		Reason string `json:"lock_reason"`
+	}
+
+	// gitea timeline comment object.
+	timelineComment struct {
+		Type            string       `json:"type"`
+		Body            string       `json:"body"`
+		User            *gitea.User  `json:"user"`
+		Label           *gitea.Label `json:"label"`
+		RemovedAssignee bool         `json:"removed_assignee"`
+		Created         time.Time    `json:"created_at"`
+	}
+)
+
 //
 // native data structure conversion
 //
 
+// issueLess returns the ordering of the issues by the field the GitHub
+// search sorts by, which is nil for the best match when it is empty
+func issueLess(field string) (func(a, b *gitea.Issue) bool, error) {
+	switch field {
+	case "":
+		return nil, nil
+	case "created":
+		return func(a, b *gitea.Issue) bool { return a.Created.Before(b.Created) }, nil
+	case "updated":
+		return func(a, b *gitea.Issue) bool { return a.Updated.Before(b.Updated) }, nil
+	case "comments":
+		return func(a, b *gitea.Issue) bool { return a.Comments < b.Comments }, nil
+	default:
+		return nil, scm.ErrNotSupported
+	}
+}
+
+func convertSearchIssueList(from []*gitea.Issue) []*scm.SearchIssue {
+	to := []*scm.SearchIssue{}
+	for _, v := range from {
+		to = append(to, &scm.SearchIssue{
+			Issue:      *convertIssue(v),
+			Repository: convertRepositoryMeta(v.Repository),
+		})
+	}
+	return to
+}
+
+func convertTimelineComments(from []*timelineComment) []*scm.ListedIssueEvent {
+	to := []*scm.ListedIssueEvent{}
+	for _, v := range from {
+		to = append(to, convertTimelineComment(v))
+	}
+	return to
+}
+
+// convertTimelineComment converts the timeline comment to an event
+// named like the issue events of GitHub.
+func convertTimelineComment(from *timelineComment) *scm.ListedIssueEvent {
+	to := &scm.ListedIssueEvent{
+		Event:   from.Type,
+		Created: from.Created,
+	}
+	switch from.Type {
+	case "label":
+		// gitea marks the labels which were added with a body of 1
+		to.Event = "unlabeled"
+		if from.Body == "1" {
+			to.Event = "labeled"
+		}
+	case "assignees":
+		to.Event = "assigned"
+		if from.RemovedAssignee {
+			to.Event = "unassigned"
+		}
+	case "close":
+		to.Event = "closed"
+	case "reopen":
+		to.Event = "reopened"
+	case "lock":
+		to.Event = "locked"
+	case "unlock":
+		to.Event = "unlocked"
+	case "merge_pull":
+		to.Event = "merged"
+	}
+	if user := convertUser(from.User); user != nil {
+		to.Actor = *user
+	}
+	if from.Label != nil {
+		to.Label = scm.Label{
+			ID:          from.Label.ID,
+			Name:        from.Label.Name,
+			Description: from.Label.Description,
+			URL:         from.Label.URL,
+			Color:       from.Label.Color,
+		}
+	}
+	return to
+}
+
 func convertIssueList(from []*gitea.Issue) []*scm.Issue {
 	to := []*scm.Issue{}
 	for _, v := range from {
@@ -291,6 +449,9 @@ func convertIssueList(from []*gitea.Issue) []*scm.Issue {
 }
 
 func convertIssue(from *gitea.Issue) *scm.Issue {
+	if from == nil {
+		return nil
+	}
 	return &scm.Issue{
 		Number:    int(from.Index),
 		Title:     from.Title,
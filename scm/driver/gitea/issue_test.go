@@ -148,16 +148,173 @@ func TestIssueReopen(t *testing.T) {
 }
 
 func TestIssueLock(t *testing.T) {
+	defer gock.Off()
+
+	mockLatestServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Put("/api/v1/repos/go-gitea/gitea/issues/1/lock").
+		Reply(204)
+
+	client, _ := New("https://try.gitea.io")
+	_, err := client.Issues.Lock(context.Background(), "go-gitea/gitea", 1)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the issue to be locked")
+	}
+}
+
+func TestIssueLock_OldVersion(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
 	client, _ := New("https://try.gitea.io")
-	_, err := client.Issues.Lock(context.Background(), "gogits/go-gogs-client", 1)
+	_, err := client.Issues.Lock(context.Background(), "go-gitea/gitea", 1)
 	if err != scm.ErrNotSupported {
 		t.Errorf("Expect Not Supported error")
 	}
 }
 
 func TestIssueUnlock(t *testing.T) {
+	defer gock.Off()
+
+	mockLatestServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Delete("/api/v1/repos/go-gitea/gitea/issues/1/lock").
+		Reply(204)
+
+	client, _ := New("https://try.gitea.io")
+	_, err := client.Issues.Unlock(context.Background(), "go-gitea/gitea", 1)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the issue to be unlocked")
+	}
+}
+
+func TestIssueSearch(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/issues/search").
+		MatchParam("q", "bug").
+		MatchParam("state", "all").
+		MatchParam("type", "issues").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issues.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Issues.Search(context.Background(), scm.SearchOptions{Query: "bug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.SearchIssue{}
+	raw, _ := ioutil.ReadFile("testdata/issues_search.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueSearch_Qualifiers(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/issues").
+		MatchParam("q", "^fix$").
+		MatchParam("type", "pulls").
+		MatchParam("state", "open").
+		MatchParam("labels", "help wanted").
+		MatchParam("since", "2017-09-01T00:00:00Z").
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		File("testdata/issues.json")
+
+	client, _ := New("https://try.gitea.io")
+	query := `fix repo:go-gitea/gitea is:pr state:open label:"help wanted" updated:>=2017-09-01`
+	got, _, err := client.Issues.Search(context.Background(), scm.SearchOptions{Query: query + " author:janedoe", Sort: "created"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Repository.FullName != "go-gitea/gitea" {
+		t.Errorf("Want the issue of the author in the repository, got %+v", got)
+	}
+
+	got, _, err = client.Issues.Search(context.Background(), scm.SearchOptions{Query: query + " author:johndoe"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Want the issues of other authors filtered out, got %d", len(got))
+	}
+}
+
+func TestIssueSearch_NotSupported(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	client, _ := New("https://try.gitea.io")
+	_, _, err := client.Issues.Search(context.Background(), scm.SearchOptions{Query: "repo:go-gitea/gitea involves:janedoe"})
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error for an unmapped qualifier, got %v", err)
+	}
+	_, _, err = client.Issues.Search(context.Background(), scm.SearchOptions{Query: "bug", Sort: "reactions"})
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error for an unmapped sort, got %v", err)
+	}
+}
+
+func TestIssueListEvents(t *testing.T) {
+	defer gock.Off()
+
+	mockLatestServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/issues/1/timeline").
+		MatchParam("page", "1").
+		MatchParam("limit", "30").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue_timeline.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Issues.ListEvents(context.Background(), "go-gitea/gitea", 1, scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.ListedIssueEvent{}
+	raw, _ := ioutil.ReadFile("testdata/issue_timeline.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueListEvents_OldVersion(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
 	client, _ := New("https://try.gitea.io")
-	_, err := client.Issues.Unlock(context.Background(), "gogits/go-gogs-client", 1)
+	_, _, err := client.Issues.ListEvents(context.Background(), "go-gitea/gitea", 1, scm.ListOptions{})
 	if err != scm.ErrNotSupported {
 		t.Errorf("Expect Not Supported error")
 	}
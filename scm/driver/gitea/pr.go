@@ -8,10 +8,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/bluekeyes/go-gitdiff/gitdiff"
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/draft"
 )
 
 type pullService struct {
@@ -24,6 +26,38 @@ func (s *pullService) Find(ctx context.Context, repo string, index int) (*scm.Pu
 	return convertPullRequest(out), toSCMResponse(resp), err
 }
 
+func (s *pullService) FindMergeability(ctx context.Context, repo string, index int) (*scm.Mergeability, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	out, resp, err := s.client.GiteaClient.GetPullRequest(namespace, name, int64(index))
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	pr := convertPullRequest(out)
+
+	// a branch without protection has no merge rules, if the protection
+	// cannot be read then all reported statuses are required
+	var rules *scm.MergeRules
+	protection, resp, err := s.client.GiteaClient.GetBranchProtection(namespace, name, pr.Base.Ref)
+	switch {
+	case err == nil:
+		rules = &scm.MergeRules{RequiredApprovals: int(protection.RequiredApprovals)}
+		if protection.EnableStatusCheck {
+			rules.RequiredContexts = protection.StatusCheckContexts
+		}
+	case resp != nil && resp.StatusCode == 404:
+		rules = &scm.MergeRules{}
+	}
+
+	m, res, err := scm.FindMergeability(ctx, s.client.Client, repo, pr, rules)
+	if err != nil {
+		return nil, res, err
+	}
+	if !pr.Closed && !out.Mergeable {
+		m.Block(scm.MergeBlockerConflicts)
+	}
+	return m, res, nil
+}
+
 func (s *pullService) List(ctx context.Context, repo string, opts scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	in := gitea.ListPullRequestsOptions{
@@ -37,8 +71,16 @@ func (s *pullService) List(ctx context.Context, repo string, opts scm.PullReques
 	} else if opts.Closed && !opts.Open {
 		in.State = gitea.StateClosed
 	}
+	switch {
+	case opts.Sort == "created" && opts.Direction == "asc":
+		in.Sort = "oldest"
+	case opts.Sort == "updated" && opts.Direction == "asc":
+		in.Sort = "leastupdate"
+	case opts.Sort == "updated":
+		in.Sort = "recentupdate"
+	}
 	out, resp, err := s.client.GiteaClient.ListRepoPullRequests(namespace, name, in)
-	return convertPullRequests(out), toSCMResponse(resp), err
+	return filterPullRequests(convertPullRequests(out), opts), toSCMResponse(resp), err
 }
 
 // TODO: Maybe contribute to gitea/go-sdk with .patch function?
@@ -76,6 +118,14 @@ func (s *pullService) ListChanges(ctx context.Context, repo string, number int,
 	return changes, res, nil
 }
 
+func (s *pullService) ListCommits(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Commit, *scm.Response, error) {
+	// the sdk does not support listing the commits of a pull request
+	path := fmt.Sprintf("api/v1/repos/%s/pulls/%d/commits?%s", repo, number, encodeListOptions(opts))
+	out := []*gitea.Commit{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertCommitList(out), res, err
+}
+
 func (s *pullService) Merge(ctx context.Context, repo string, index int, options *scm.PullRequestMergeOptions) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	in := gitea.MergePullRequestOption{}
@@ -83,12 +133,78 @@ func (s *pullService) Merge(ctx context.Context, repo string, index int, options
 	if options != nil {
 		in.Style = convertMergeMethodToMergeStyle(options.MergeMethod)
 		in.Title = options.CommitTitle
+		in.Message = options.CommitMessage
 	}
 
 	_, resp, err := s.client.GiteaClient.MergePullRequest(namespace, name, int64(index), in)
 	return toSCMResponse(resp), err
 }
 
+func (s *pullService) EnableAutoMerge(ctx context.Context, repo string, index int, options *scm.PullRequestMergeOptions) (*scm.Response, error) {
+	// the sdk does not support scheduling a merge once all checks succeed
+	in := &mergeOption{
+		Style:                  gitea.MergeStyleMerge,
+		MergeWhenChecksSucceed: true,
+	}
+	if options != nil {
+		in.Style = convertMergeMethodToMergeStyle(options.MergeMethod)
+		in.Title = options.CommitTitle
+		in.Message = options.CommitMessage
+		in.HeadCommitID = options.SHA
+		in.DeleteBranchAfterMerge = options.DeleteSourceBranch
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/pulls/%d/merge", repo, index)
+	return s.client.do(ctx, "POST", path, in, nil)
+}
+
+func (s *pullService) DisableAutoMerge(ctx context.Context, repo string, index int) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/pulls/%d/merge", repo, index)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+func (s *pullService) UpdateBranch(ctx context.Context, repo string, index int, method string) (*scm.Response, error) {
+	// the sdk does not support updating the head branch of a pull request
+	style := "merge"
+	switch method {
+	case "", "merge":
+	case "rebase":
+		style = method
+	default:
+		return nil, fmt.Errorf("unsupported update method %q", method)
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/pulls/%d/update?style=%s", repo, index, style)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
+func (s *pullService) MarkReady(ctx context.Context, repo string, index int) (*scm.Response, error) {
+	return s.setDraft(repo, index, false)
+}
+
+func (s *pullService) ConvertToDraft(ctx context.Context, repo string, index int) (*scm.Response, error) {
+	return s.setDraft(repo, index, true)
+}
+
+// setDraft adds or removes the work in progress prefix of the pull request title
+func (s *pullService) setDraft(repo string, index int, draft bool) (*scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	out, resp, err := s.client.GiteaClient.GetPullRequest(namespace, name, int64(index))
+	if err != nil {
+		return toSCMResponse(resp), err
+	}
+	title := draftPrefixes.Trim(out.Title)
+	if draft {
+		title = "WIP: " + title
+	}
+	if title == out.Title {
+		return toSCMResponse(resp), nil
+	}
+	in := gitea.EditPullRequestOption{
+		Title: title,
+	}
+	_, resp, err = s.client.GiteaClient.EditPullRequest(namespace, name, int64(index), in)
+	return toSCMResponse(resp), err
+}
+
 func (s *pullService) Update(ctx context.Context, repo string, number int, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	in := gitea.EditPullRequestOption{
@@ -123,13 +239,37 @@ func (s *pullService) Reopen(ctx context.Context, repo string, number int) (*scm
 func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	in := gitea.CreatePullRequestOption{
-		Head:  input.Head,
-		Base:  input.Base,
-		Title: input.Title,
-		Body:  input.Body,
+		Head:      input.Head,
+		Base:      input.Base,
+		Title:     input.Title,
+		Body:      input.Body,
+		Assignees: input.Assignees,
+		Milestone: int64(input.Milestone),
+	}
+	if input.HeadRepo != "" && input.HeadRepo != repo && !strings.Contains(in.Head, ":") {
+		owner, _ := scm.Split(input.HeadRepo)
+		in.Head = owner + ":" + in.Head
+	}
+	// gitea marks pull requests as drafts using a work in progress prefix
+	if input.Draft && !draftPrefixes.Match(in.Title) {
+		in.Title = "WIP: " + in.Title
+	}
+	for _, lbl := range input.Labels {
+		labelID, res, err := s.findOrCreateLabel(ctx, repo, lbl)
+		if err != nil {
+			return nil, res, err
+		}
+		in.Labels = append(in.Labels, labelID)
 	}
 	out, resp, err := s.client.GiteaClient.CreatePullRequest(namespace, name, in)
-	return convertPullRequest(out), toSCMResponse(resp), err
+	if err != nil || len(input.Reviewers) == 0 {
+		return convertPullRequest(out), toSCMResponse(resp), err
+	}
+	resp, err = s.client.GiteaClient.CreateReviewRequests(namespace, name, out.Index, gitea.PullReviewRequestOptions{Reviewers: input.Reviewers})
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	return s.Find(ctx, repo, int(out.Index))
 }
 
 func (s *pullService) RequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
@@ -144,6 +284,46 @@ func (s *pullService) UnrequestReview(ctx context.Context, repo string, number i
 // native data structure conversion
 //
 
+// mergeOption is the merge input of the gitea API including the
+// option to merge once the checks succeed, which the sdk lacks
+type mergeOption struct {
+	Style                  gitea.MergeStyle `json:"Do"`
+	Title                  string           `json:"MergeTitleField,omitempty"`
+	Message                string           `json:"MergeMessageField,omitempty"`
+	HeadCommitID           string           `json:"head_commit_id,omitempty"`
+	DeleteBranchAfterMerge bool             `json:"delete_branch_after_merge,omitempty"`
+	MergeWhenChecksSucceed bool             `json:"merge_when_checks_succeed"`
+}
+
+// filterPullRequests filters the pull requests by the head, base and author
+// of the options which are not supported by the gitea API
+func filterPullRequests(prs []*scm.PullRequest, opts scm.PullRequestListOptions) []*scm.PullRequest {
+	if opts.Head == "" && opts.Base == "" && opts.Author == "" {
+		return prs
+	}
+	owner, head := "", opts.Head
+	if i := strings.Index(head, ":"); i >= 0 {
+		owner, head = head[:i], head[i+1:]
+	}
+	dst := []*scm.PullRequest{}
+	for _, pr := range prs {
+		switch {
+		case pr == nil:
+		case head != "" && pr.Head.Ref != head:
+		case owner != "" && !strings.EqualFold(pr.Head.Repo.Namespace, owner):
+		case opts.Base != "" && pr.Base.Ref != opts.Base:
+		case opts.Author != "" && !strings.EqualFold(pr.Author.Login, opts.Author):
+		default:
+			dst = append(dst, pr)
+		}
+	}
+	return dst
+}
+
+// draftPrefixes are the default gitea title prefixes which mark
+// a pull request as a work in progress
+var draftPrefixes = draft.Prefixes{"wip:", "[wip]"}
+
 func convertPullRequests(src []*gitea.PullRequest) []*scm.PullRequest {
 	dst := []*scm.PullRequest{}
 	for _, v := range src {
@@ -160,6 +340,7 @@ func convertPullRequest(src *gitea.PullRequest) *scm.PullRequest {
 		Number:    int(src.Index),
 		Title:     src.Title,
 		Body:      src.Body,
+		Draft:     draftPrefixes.Match(src.Title),
 		Labels:    convertLabels(src.Labels),
 		Sha:       src.Head.Sha,
 		Ref:       fmt.Sprintf("refs/pull/%d/head", src.Index),
@@ -78,6 +78,147 @@ func TestPullRequestList(t *testing.T) {
 	t.Run("Page", testPage(res))
 }
 
+func TestPullEnableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Post("/api/v1/repos/go-gitea/gitea/pulls/1/merge").
+		JSON(map[string]interface{}{
+			"Do":                        "squash",
+			"MergeTitleField":           "Add the new feature",
+			"head_commit_id":            "4d47d1f3bb2a8a4ff6b4e4d6bb2e3e4bb2a9b3b4",
+			"merge_when_checks_succeed": true,
+		}).
+		Reply(200)
+
+	client, _ := New("https://try.gitea.io")
+	options := &scm.PullRequestMergeOptions{
+		MergeMethod: "squash",
+		CommitTitle: "Add the new feature",
+		SHA:         "4d47d1f3bb2a8a4ff6b4e4d6bb2e3e4bb2a9b3b4",
+	}
+	_, err := client.PullRequests.EnableAutoMerge(context.Background(), "go-gitea/gitea", 1, options)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPullDisableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Delete("/api/v1/repos/go-gitea/gitea/pulls/1/merge").
+		Reply(204)
+
+	client, _ := New("https://try.gitea.io")
+	_, err := client.PullRequests.DisableAutoMerge(context.Background(), "go-gitea/gitea", 1)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPullUpdateBranch(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Post("/api/v1/repos/go-gitea/gitea/pulls/1/update").
+		MatchParam("style", "rebase").
+		Reply(200)
+
+	client, _ := New("https://try.gitea.io")
+	_, err := client.PullRequests.UpdateBranch(context.Background(), "go-gitea/gitea", 1, "rebase")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if _, err := client.PullRequests.UpdateBranch(context.Background(), "go-gitea/gitea", 1, "squash"); err == nil {
+		t.Errorf("Expect error for an unsupported update method")
+	}
+}
+
+func TestPullMarkReady(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/pulls/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_draft.json")
+
+	gock.New("https://try.gitea.io").
+		Patch("/api/v1/repos/go-gitea/gitea/pulls/1").
+		File("testdata/mark_ready_pr.json").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	client, _ := New("https://try.gitea.io")
+	_, err := client.PullRequests.MarkReady(context.Background(), "go-gitea/gitea", 1)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the pull request title to be updated")
+	}
+}
+
+func TestPullConvertToDraft(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/pulls/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	gock.New("https://try.gitea.io").
+		Patch("/api/v1/repos/go-gitea/gitea/pulls/1").
+		File("testdata/convert_to_draft_pr.json").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_draft.json")
+
+	client, _ := New("https://try.gitea.io")
+	_, err := client.PullRequests.ConvertToDraft(context.Background(), "go-gitea/gitea", 1)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the pull request title to be updated")
+	}
+}
+
+func TestConvertPullRequest_Draft(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/pulls/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_draft.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.PullRequests.Find(context.Background(), "go-gitea/gitea", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Draft {
+		t.Errorf("Expect a work in progress pull request to be a draft")
+	}
+}
+
 func TestPullClose(t *testing.T) {
 	defer gock.Off()
 
@@ -165,6 +306,43 @@ func TestPullRequestChanges(t *testing.T) {
 	}
 }
 
+func TestPullRequestListFilters(t *testing.T) {
+	tests := []struct {
+		opts scm.PullRequestListOptions
+		want int
+	}{
+		{scm.PullRequestListOptions{Head: "feature", Base: "master"}, 1},
+		{scm.PullRequestListOptions{Head: "jcitizen:feature"}, 1},
+		{scm.PullRequestListOptions{Head: "someone:feature"}, 0},
+		{scm.PullRequestListOptions{Base: "develop"}, 0},
+		{scm.PullRequestListOptions{Author: "JCitizen"}, 1},
+		{scm.PullRequestListOptions{Author: "someone"}, 0},
+	}
+	for _, test := range tests {
+		func() {
+			defer gock.Off()
+
+			mockServerVersion()
+
+			gock.New("https://try.gitea.io").
+				Get("/api/v1/repos/jcitizen/my-repo/pulls").
+				Reply(200).
+				Type("application/json").
+				SetHeaders(mockPageHeaders).
+				File("testdata/prs.json")
+
+			client, _ := New("https://try.gitea.io")
+			got, _, err := client.PullRequests.List(context.Background(), "jcitizen/my-repo", test.opts)
+			if err != nil {
+				t.Error(err)
+			}
+			if len(got) != test.want {
+				t.Errorf("Want %d pull requests for %+v, got %d", test.want, test.opts, len(got))
+			}
+		}()
+	}
+}
+
 func TestPullCreate(t *testing.T) {
 	defer gock.Off()
 
@@ -199,3 +377,33 @@ func TestPullCreate(t *testing.T) {
 		t.Log(diff)
 	}
 }
+
+func TestPullListCommits(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/pulls/1/commits").
+		MatchParam("page", "1").
+		MatchParam("limit", "30").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_commits.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.PullRequests.ListCommits(context.Background(), "go-gitea/gitea", 1, scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+	}
+
+	var want []*scm.Commit
+	raw, _ := ioutil.ReadFile("testdata/pr_commits.json.golden")
+	err = json.Unmarshal(raw, &want)
+	assert.NoError(t, err)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
@@ -148,6 +148,9 @@ func ConvertAPIURLToHTMLURL(apiURL string, tagName string) string {
 }
 
 func convertRelease(from *gitea.Release) *scm.Release {
+	if from == nil {
+		return nil
+	}
 	return &scm.Release{
 		ID:          int(from.ID),
 		Title:       from.Title,
@@ -280,6 +280,9 @@ func convertHookList(src []*gitea.Hook) []*scm.Hook {
 }
 
 func convertHook(from *gitea.Hook) *scm.Hook {
+	if from == nil {
+		return nil
+	}
 	return &scm.Hook{
 		ID:     strconv.FormatInt(from.ID, 10),
 		Active: from.Active,
@@ -327,11 +330,16 @@ func convertStatusList(src []*gitea.Status) []*scm.Status {
 }
 
 func convertStatus(from *gitea.Status) *scm.Status {
+	if from == nil {
+		return nil
+	}
 	return &scm.Status{
-		State:  convertState(from.State),
-		Label:  from.Context,
-		Desc:   from.Description,
-		Target: from.TargetURL,
+		State:   convertState(from.State),
+		Label:   from.Context,
+		Desc:    from.Description,
+		Target:  from.TargetURL,
+		Created: from.Created,
+		Updated: from.Updated,
 	}
 }
 
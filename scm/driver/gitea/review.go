@@ -6,6 +6,8 @@ package gitea
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/jenkins-x/go-scm/scm"
@@ -72,9 +74,108 @@ func (s *reviewService) Submit(ctx context.Context, repo string, prID int, revie
 	return convertReview(review), toSCMResponse(resp), err
 }
 
-// TODO: Figure out whether this actually is a _thing_ exactly in Gitea. I don't think it is.
 func (s *reviewService) Dismiss(ctx context.Context, repo string, prID int, reviewID int, msg string) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	if err := s.client.checkVersion(">= 1.14.0"); err != nil {
+		return nil, nil, err
+	}
+	namespace, name := scm.Split(repo)
+	in := gitea.DismissPullReviewOptions{Message: msg}
+	resp, err := s.client.GiteaClient.DismissPullReview(namespace, name, int64(prID), int64(reviewID), in)
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	return s.Find(ctx, repo, prID, reviewID)
+}
+
+func (s *reviewService) CreateComment(ctx context.Context, repo string, number int, input *scm.ReviewCommentInput) (*scm.ReviewComment, *scm.Response, error) {
+	// the api does not support replying to a thread
+	if input.ThreadID != "" {
+		return nil, nil, scm.ErrNotSupported
+	}
+	namespace, name := scm.Split(repo)
+	in := gitea.CreatePullReviewOptions{
+		State:    gitea.ReviewStateComment,
+		CommitID: input.Sha,
+		Comments: toCreatePullRequestComments([]*scm.ReviewCommentInput{input}),
+	}
+	review, resp, err := s.client.GiteaClient.CreatePullReview(namespace, name, int64(number), in)
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	comments, resp, err := s.client.GiteaClient.ListPullReviewComments(namespace, name, int64(number), review.ID)
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	if len(comments) == 0 {
+		return nil, toSCMResponse(resp), scm.ErrNotFound
+	}
+	comment := convertReviewComment(comments[0])
+	comment.ThreadID = strconv.FormatInt(comments[0].ID, 10)
+	return comment, toSCMResponse(resp), nil
+}
+
+// ListThreads groups the comments of the reviews on the pull request into
+// threads by the line they are on like the conversations in the Gitea UI
+func (s *reviewService) ListThreads(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.ReviewThread, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	reviews, resp, err := s.client.GiteaClient.ListPullReviews(namespace, name, int64(number), gitea.ListPullReviewsOptions{ListOptions: toGiteaListOptions(opts)})
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	res := toSCMResponse(resp)
+
+	threads := []*scm.ReviewThread{}
+	byLine := map[string]*scm.ReviewThread{}
+	for _, review := range reviews {
+		if review.CodeCommentsCount == 0 {
+			continue
+		}
+		// the sdk does not expose who resolved a comment
+		path := fmt.Sprintf("api/v1/repos/%s/pulls/%d/reviews/%d/comments", repo, number, review.ID)
+		out := []*reviewComment{}
+		if _, err := s.client.do(ctx, "GET", path, nil, &out); err != nil {
+			return nil, res, err
+		}
+		for _, c := range out {
+			key := fmt.Sprintf("%s:%d:%d", c.Path, c.LineNum, c.OldLineNum)
+			thread := byLine[key]
+			if thread == nil {
+				thread = &scm.ReviewThread{
+					ID:   strconv.FormatInt(c.ID, 10),
+					Path: c.Path,
+				}
+				thread.Line, thread.Side = lineAndSide(&c.PullReviewComment)
+				byLine[key] = thread
+				threads = append(threads, thread)
+			}
+			comment := convertReviewComment(&c.PullReviewComment)
+			comment.ThreadID = thread.ID
+			thread.Comments = append(thread.Comments, comment)
+			thread.Resolved = thread.Resolved || c.Resolver != nil
+		}
+	}
+	return threads, res, nil
+}
+
+func (s *reviewService) ResolveThread(ctx context.Context, repo string, number int, threadID string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *reviewService) UnresolveThread(ctx context.Context, repo string, number int, threadID string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+type reviewComment struct {
+	gitea.PullReviewComment
+	Resolver *gitea.User `json:"resolver"`
+}
+
+// lineAndSide returns the line of the comment and the side of the diff it is on
+func lineAndSide(src *gitea.PullReviewComment) (int, string) {
+	if src.LineNum == 0 && src.OldLineNum != 0 {
+		return int(src.OldLineNum), scm.ReviewSideLeft
+	}
+	return int(src.LineNum), scm.ReviewSideRight
 }
 
 func convertReviewList(from []*gitea.PullReview) []*scm.Review {
@@ -109,26 +210,36 @@ func convertReviewCommentList(src []*gitea.PullReviewComment) []*scm.ReviewComme
 }
 
 func convertReviewComment(src *gitea.PullReviewComment) *scm.ReviewComment {
+	line, side := lineAndSide(src)
 	return &scm.ReviewComment{
 		ID:      int(src.ID),
 		Body:    src.Body,
 		Path:    src.Path,
 		Sha:     src.CommitID,
-		Line:    int(src.LineNum),
+		Line:    line,
 		Link:    src.HTMLURL,
 		Author:  *convertUser(src.Reviewer),
 		Created: src.Created,
 		Updated: src.Updated,
+		Side:    side,
 	}
 }
+
+// toCreatePullRequestComments converts the review comments which can
+// only address a single line on either side of the diff
 func toCreatePullRequestComments(src []*scm.ReviewCommentInput) []gitea.CreatePullReviewComment {
 	var out []gitea.CreatePullReviewComment
 	for _, c := range src {
-		out = append(out, gitea.CreatePullReviewComment{
-			Path:       c.Path,
-			Body:       c.Body,
-			NewLineNum: int64(c.Line),
-		})
+		comment := gitea.CreatePullReviewComment{
+			Path: c.Path,
+			Body: c.Body,
+		}
+		if c.Side == scm.ReviewSideLeft {
+			comment.OldLineNum = int64(c.Line)
+		} else {
+			comment.NewLineNum = int64(c.Line)
+		}
+		out = append(out, comment)
 	}
 	return out
 }
@@ -156,3 +156,85 @@ func TestReviewDelete(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestReviewDismiss(t *testing.T) {
+	defer gock.Off()
+
+	mockLatestServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Post("/api/v1/repos/jcitizen/my-repo/pulls/1/reviews/1/dismissals").
+		BodyString(`"message":"outdated"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/review.json")
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1/reviews/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/review.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Reviews.Dismiss(context.Background(), "jcitizen/my-repo", 1, 1, "outdated")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Review)
+	raw, _ := ioutil.ReadFile("testdata/review.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReviewListThreads(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1/reviews").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockPageHeaders).
+		File("testdata/reviews_with_comments.json")
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1/reviews/1/comments").
+		Reply(200).
+		Type("application/json").
+		File("testdata/review_comments.json")
+
+	client, _ := New("https://try.gitea.io")
+	got, _, err := client.Reviews.ListThreads(context.Background(), "jcitizen/my-repo", 1, scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.ReviewThread{}
+	raw, _ := ioutil.ReadFile("testdata/review_threads.json.golden")
+	err = json.Unmarshal(raw, &want)
+	assert.NoError(t, err)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReviewCreateComment_Reply(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	client, _ := New("https://try.gitea.io")
+	_, _, err := client.Reviews.CreateComment(context.Background(), "jcitizen/my-repo", 1, &scm.ReviewCommentInput{ThreadID: "4"})
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
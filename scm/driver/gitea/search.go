@@ -0,0 +1,328 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type searchService struct {
+	client *wrapper
+}
+
+type repositorySearchResults struct {
+	OK   bool                `json:"ok"`
+	Data []*gitea.Repository `json:"data"`
+}
+
+func (s *searchService) Repositories(ctx context.Context, q scm.SearchQuery) (*scm.RepositorySearchResults, *scm.Response, error) {
+	// repositories are scoped by the id of their owner
+	owner, terms := q.Org, q.Terms
+	if q.Repo != "" {
+		var name string
+		owner, name = scm.Split(q.Repo)
+		terms = strings.TrimSpace(name + " " + terms)
+	}
+	params := url.Values{}
+	params.Set("q", terms)
+	if owner != "" {
+		out, resp, err := s.client.GiteaClient.GetUserInfo(owner)
+		if err != nil {
+			return nil, toSCMResponse(resp), err
+		}
+		params.Set("uid", strconv.FormatInt(out.ID, 10))
+		params.Set("exclusive", "true")
+	}
+	encodeSearchPage(params, q)
+	if q.Sort != "" {
+		params.Set("sort", q.Sort)
+		params.Set("order", searchOrder(q))
+	}
+	out := new(repositorySearchResults)
+	res, err := s.client.do(ctx, "GET", "api/v1/repos/search?"+params.Encode(), nil, out)
+	return &scm.RepositorySearchResults{
+		Total: searchTotal(res, len(out.Data)),
+		Items: convertRepositoryList(out.Data),
+	}, res, err
+}
+
+func (s *searchService) Issues(ctx context.Context, q scm.SearchQuery) (*scm.IssueSearchResults, *scm.Response, error) {
+	out, res, err := s.searchIssues(ctx, q, "issues")
+	results := &scm.IssueSearchResults{
+		Total: searchTotal(res, len(out)),
+	}
+	for _, v := range out {
+		if q.Author != "" && (v.Poster == nil || v.Poster.UserName != q.Author) {
+			continue
+		}
+		results.Items = append(results.Items, &scm.SearchIssue{
+			Issue:      *convertIssue(v),
+			Repository: convertRepositoryMeta(v.Repository),
+		})
+	}
+	// the total of the server includes the results filtered out
+	results.Incomplete = len(results.Items) < len(out)
+	return results, res, err
+}
+
+func (s *searchService) PullRequests(ctx context.Context, q scm.SearchQuery) (*scm.PullRequestSearchResults, *scm.Response, error) {
+	out, res, err := s.searchIssues(ctx, q, "pulls")
+	results := &scm.PullRequestSearchResults{
+		Total: searchTotal(res, len(out)),
+	}
+	for _, v := range out {
+		merged := v.PullRequest != nil && v.PullRequest.HasMerged
+		if q.State == "merged" && !merged {
+			continue
+		}
+		if q.Author != "" && (v.Poster == nil || v.Poster.UserName != q.Author) {
+			continue
+		}
+		results.Items = append(results.Items, convertSearchPullRequest(v))
+	}
+	// the total of the server includes the results filtered out
+	results.Incomplete = len(results.Items) < len(out)
+	return results, res, err
+}
+
+func (s *searchService) Code(context.Context, scm.SearchQuery) (*scm.CodeSearchResults, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *searchService) Commits(context.Context, scm.SearchQuery) (*scm.CommitSearchResults, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+// searchIssues searches the issues or pull requests of the repository
+// of the query, or else of all the repositories the user can access.
+func (s *searchService) searchIssues(ctx context.Context, q scm.SearchQuery, kind string) ([]*gitea.Issue, *scm.Response, error) {
+	params := url.Values{}
+	params.Set("q", q.Terms)
+	params.Set("type", kind)
+	switch q.State {
+	case "":
+		params.Set("state", "all")
+	case "merged":
+		params.Set("state", "closed")
+	default:
+		params.Set("state", q.State)
+	}
+	if len(q.Labels) > 0 {
+		params.Set("labels", strings.Join(q.Labels, ","))
+	}
+	if !q.UpdatedAfter.IsZero() {
+		params.Set("since", q.UpdatedAfter.UTC().Format(scm.SearchTimeFormat))
+	}
+	if !q.UpdatedBefore.IsZero() {
+		params.Set("before", q.UpdatedBefore.UTC().Format(scm.SearchTimeFormat))
+	}
+	encodeSearchPage(params, q)
+
+	path := "api/v1/repos/issues/search"
+	if q.Repo != "" {
+		path = fmt.Sprintf("api/v1/repos/%s/issues", q.Repo)
+	} else if q.Org != "" {
+		params.Set("owner", q.Org)
+	}
+	out := []*gitea.Issue{}
+	res, err := s.client.do(ctx, "GET", path+"?"+params.Encode(), nil, &out)
+	// issues listed from a single repository do not include it
+	if q.Repo != "" {
+		namespace, name := scm.Split(q.Repo)
+		for _, v := range out {
+			if v.Repository == nil {
+				v.Repository = &gitea.RepositoryMeta{Owner: namespace, Name: name, FullName: q.Repo}
+			}
+		}
+	}
+	return out, res, err
+}
+
+// parseSearchQuery parses the GitHub search syntax of the query into the
+// search query and the kind of issues to search, returning
+// scm.ErrNotSupported for the qualifiers which have no gitea equivalent.
+func parseSearchQuery(query string) (scm.SearchQuery, string, error) {
+	q := scm.SearchQuery{}
+	kind := "issues"
+	var terms []string
+	for _, token := range splitSearchQuery(query) {
+		i := strings.Index(token, ":")
+		if i < 0 || strings.HasPrefix(token, `"`) {
+			terms = append(terms, strings.Trim(token, `"`))
+			continue
+		}
+		key, value := token[:i], strings.Trim(token[i+1:], `"`)
+		switch {
+		case key == "repo":
+			q.Repo = value
+		case key == "org" || key == "user":
+			q.Org = value
+		case key == "author":
+			q.Author = value
+		case key == "label":
+			q.Labels = append(q.Labels, value)
+		case (key == "is" || key == "type") && (value == "pr" || value == "pull-request"):
+			kind = "pulls"
+		case (key == "is" || key == "type") && value == "issue":
+			kind = "issues"
+		case (key == "is" || key == "state") && (value == "open" || value == "closed"):
+			q.State = value
+		case key == "is" && value == "merged":
+			q.State = value
+			kind = "pulls"
+		case key == "updated":
+			if err := parseSearchRange(value, &q); err != nil {
+				return q, kind, err
+			}
+		default:
+			return q, kind, scm.ErrNotSupported
+		}
+	}
+	q.Terms = strings.Join(terms, " ")
+	return q, kind, nil
+}
+
+// parseSearchRange parses the updated range of the query which is one of
+// ">=time", "<=time" or "time..time"
+func parseSearchRange(value string, q *scm.SearchQuery) error {
+	var after, before string
+	switch {
+	case strings.HasPrefix(value, ">="):
+		after = value[2:]
+	case strings.HasPrefix(value, "<="):
+		before = value[2:]
+	case strings.Contains(value, ".."):
+		i := strings.Index(value, "..")
+		after, before = value[:i], value[i+2:]
+	default:
+		return scm.ErrNotSupported
+	}
+	var err error
+	if after != "" && after != "*" {
+		if q.UpdatedAfter, err = parseSearchTime(after); err != nil {
+			return err
+		}
+	}
+	if before != "" && before != "*" {
+		if q.UpdatedBefore, err = parseSearchTime(before); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseSearchTime parses the time of the query as a date or a time
+func parseSearchTime(value string) (time.Time, error) {
+	if t, err := time.Parse(scm.SearchTimeFormat, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return t, fmt.Errorf("invalid search time %q", value)
+	}
+	return t, nil
+}
+
+// splitSearchQuery splits the query on whitespace keeping quoted
+// values such as label:"help wanted" together
+func splitSearchQuery(query string) []string {
+	var tokens []string
+	var token strings.Builder
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			token.WriteRune(r)
+		case (r == ' ' || r == '\t') && !quoted:
+			if token.Len() > 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+		default:
+			token.WriteRune(r)
+		}
+	}
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+	return tokens
+}
+
+func encodeSearchPage(params url.Values, q scm.SearchQuery) {
+	if q.Page != 0 {
+		params.Set("page", strconv.Itoa(q.Page))
+	}
+	if q.Size != 0 {
+		params.Set("limit", strconv.Itoa(q.Size))
+	}
+}
+
+func searchOrder(q scm.SearchQuery) string {
+	if q.Ascending {
+		return "asc"
+	}
+	return "desc"
+}
+
+// searchTotal returns the total number of results of the search
+func searchTotal(res *scm.Response, count int) int {
+	if res != nil {
+		if total, err := strconv.Atoi(res.Header.Get("X-Total-Count")); err == nil {
+			return total
+		}
+	}
+	return count
+}
+
+func convertRepositoryMeta(from *gitea.RepositoryMeta) scm.Repository {
+	if from == nil {
+		return scm.Repository{}
+	}
+	repo := scm.Repository{
+		Namespace: from.Owner,
+		Name:      from.Name,
+		FullName:  from.FullName,
+	}
+	if from.ID != 0 {
+		repo.ID = strconv.FormatInt(from.ID, 10)
+	}
+	return repo
+}
+
+func convertSearchPullRequest(from *gitea.Issue) *scm.SearchPullRequest {
+	issue := convertIssue(from)
+	repo := convertRepositoryMeta(from.Repository)
+	pr := &scm.SearchPullRequest{
+		PullRequest: scm.PullRequest{
+			Number:    issue.Number,
+			Title:     issue.Title,
+			Body:      issue.Body,
+			Labels:    convertLabels(from.Labels),
+			State:     string(from.State),
+			Closed:    issue.Closed,
+			Draft:     draftPrefixes.Match(from.Title),
+			Author:    issue.Author,
+			Assignees: issue.Assignees,
+			Link:      from.HTMLURL,
+			Base:      scm.PullRequestBranch{Repo: repo},
+			Created:   issue.Created,
+			Updated:   issue.Updated,
+		},
+		Repository: repo,
+	}
+	if from.PullRequest != nil {
+		pr.Merged = from.PullRequest.HasMerged
+	}
+	return pr
+}
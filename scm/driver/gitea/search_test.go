@@ -0,0 +1,161 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSearchRepositories(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/users/jcitizen").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/search").
+		MatchParam("q", "my-repo").
+		MatchParam("uid", "1").
+		MatchParam("exclusive", "true").
+		Reply(200).
+		Type("application/json").
+		SetHeader("X-Total-Count", "1").
+		File("testdata/search_repos.json")
+
+	client, _ := New("https://try.gitea.io")
+	query := scm.SearchQuery{Terms: "my-repo", Org: "jcitizen"}
+	got, _, err := client.Search.Repositories(context.Background(), query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items := []*scm.Repository{}
+	raw, _ := ioutil.ReadFile("testdata/repos.json.golden")
+	json.Unmarshal(raw, &items)
+	want := &scm.RepositorySearchResults{Total: 1, Items: items}
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchIssues(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/go-gitea/gitea/issues").
+		MatchParam("q", "bug").
+		MatchParam("type", "issues").
+		MatchParam("state", "open").
+		MatchParam("labels", "bug").
+		MatchParam("since", "2018-01-02T15:04:05Z").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issues.json")
+
+	client, _ := New("https://try.gitea.io")
+	query := scm.SearchQuery{
+		Terms:        "bug",
+		Repo:         "go-gitea/gitea",
+		State:        "open",
+		Labels:       []string{"bug"},
+		UpdatedAfter: time.Date(2018, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+	got, _, err := client.Search.Issues(context.Background(), query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues := []*scm.Issue{}
+	raw, _ := ioutil.ReadFile("testdata/issues.json.golden")
+	json.Unmarshal(raw, &issues)
+	want := &scm.IssueSearchResults{Total: 1}
+	for _, issue := range issues {
+		want.Items = append(want.Items, &scm.SearchIssue{
+			Issue: *issue,
+			Repository: scm.Repository{
+				Namespace: "go-gitea",
+				Name:      "gitea",
+				FullName:  "go-gitea/gitea",
+			},
+		})
+	}
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchPullRequests(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://try.gitea.io").
+		Get("/api/v1/repos/issues/search").
+		MatchParam("q", "search").
+		MatchParam("type", "pulls").
+		MatchParam("state", "closed").
+		MatchParam("owner", "go-gitea").
+		Reply(200).
+		Type("application/json").
+		SetHeader("X-Total-Count", "2").
+		File("testdata/search_pulls.json")
+
+	client, _ := New("https://try.gitea.io")
+	query := scm.SearchQuery{Terms: "search", Org: "go-gitea", State: "merged"}
+	got, _, err := client.Search.PullRequests(context.Background(), query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Total != 2 || !got.Incomplete {
+		t.Errorf("Want the server total with a result filtered out, got total %d", got.Total)
+	}
+	if len(got.Items) != 1 {
+		t.Fatalf("Want 1 merged pull request, got %d", len(got.Items))
+	}
+	pr := got.Items[0]
+	if pr.Number != 2 || !pr.Merged || !pr.Closed {
+		t.Errorf("Want merged pull request 2, got %+v", pr.PullRequest)
+	}
+	if pr.Repository.FullName != "go-gitea/gitea" || pr.Base.Repo.FullName != "go-gitea/gitea" {
+		t.Errorf("Want repository go-gitea/gitea, got %q", pr.Repository.FullName)
+	}
+	if pr.Link != "https://try.gitea.io/go-gitea/gitea/pulls/2" {
+		t.Errorf("Unexpected link %q", pr.Link)
+	}
+}
+
+func TestSearchCode(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	client, _ := New("https://try.gitea.io")
+	if _, _, err := client.Search.Code(context.Background(), scm.SearchQuery{Terms: "main"}); err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+	if _, _, err := client.Search.Commits(context.Background(), scm.SearchQuery{Terms: "fix"}); err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
@@ -0,0 +1,13 @@
+package github
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// unsupported lists the features of the services of the
+// client which return scm.ErrNotSupported.
+var unsupported = []scm.Feature{
+	"Git.FindTag",
+	"Organizations.Create",
+	"Organizations.Delete",
+	"Users.CreateToken",
+	"Users.DeleteToken",
+}
@@ -21,12 +21,29 @@ func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm
 	endpoint := fmt.Sprintf("repos/%s/contents/%s?ref=%s", repo, path, ref)
 	out := new(content)
 	res, err := s.client.do(ctx, "GET", endpoint, nil, out)
-	raw, _ := base64.StdEncoding.DecodeString(out.Content)
-	return &scm.Content{
-		Path: out.Path,
-		Data: raw,
-		Sha:  out.Sha,
-	}, res, err
+	if err != nil {
+		return convertContent(out), res, err
+	}
+	// files larger than 1MB are returned without their content
+	// which then has to be read from the git blob instead
+	if out.Encoding == "none" {
+		blob, res, err := s.findBlob(ctx, repo, out.Sha)
+		if err != nil {
+			return convertContent(out), res, err
+		}
+		out.Content = blob.Content
+		out.Encoding = blob.Encoding
+	}
+	return convertContent(out), res, nil
+}
+
+// findBlob returns the git blob by sha which unlike the contents
+// api supports files of up to 100MB.
+func (s *contentService) findBlob(ctx context.Context, repo, sha string) (*blob, *scm.Response, error) {
+	endpoint := fmt.Sprintf("repos/%s/git/blobs/%s", repo, sha)
+	out := new(blob)
+	res, err := s.client.do(ctx, "GET", endpoint, nil, out)
+	return out, res, err
 }
 
 func (s *contentService) List(ctx context.Context, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
@@ -39,9 +56,11 @@ func (s *contentService) List(ctx context.Context, repo, path, ref string) ([]*s
 func (s *contentService) Create(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
 	endpoint := fmt.Sprintf("repos/%s/contents/%s", repo, path)
 	body := &contentBody{
-		Message: params.Message,
-		Content: params.Data,
-		Branch:  params.Branch,
+		Message:   params.Message,
+		Content:   params.Data,
+		Branch:    params.Branch,
+		Author:    convertSignatureInput(params.Author),
+		Committer: convertSignatureInput(params.Committer),
 	}
 
 	return s.client.do(ctx, "PUT", endpoint, &body, nil)
@@ -50,24 +69,139 @@ func (s *contentService) Create(ctx context.Context, repo, path string, params *
 func (s *contentService) Update(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
 	endpoint := fmt.Sprintf("repos/%s/contents/%s", repo, path)
 	body := &contentBody{
-		Message: params.Message,
-		Content: params.Data,
-		Branch:  params.Branch,
-		Sha:     params.Sha,
+		Message:   params.Message,
+		Content:   params.Data,
+		Branch:    params.Branch,
+		Sha:       params.Sha,
+		Author:    convertSignatureInput(params.Author),
+		Committer: convertSignatureInput(params.Committer),
 	}
 
 	return s.client.do(ctx, "PUT", endpoint, &body, nil)
 }
 
-func (s *contentService) Delete(ctx context.Context, repo, path, ref string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+// Delete deletes the file, which github only does while the sha of the
+// params is the sha of the file on the branch.
+func (s *contentService) Delete(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	endpoint := fmt.Sprintf("repos/%s/contents/%s", repo, path)
+	body := &contentDeleteBody{
+		Message:   params.Message,
+		Sha:       params.Sha,
+		Branch:    params.Branch,
+		Author:    convertSignatureInput(params.Author),
+		Committer: convertSignatureInput(params.Committer),
+	}
+	return s.client.do(ctx, "DELETE", endpoint, &body, nil)
+}
+
+func (s *contentService) Blame(ctx context.Context, repo, path, ref string) ([]*scm.BlameRange, *scm.Response, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	owner, name := scm.Split(repo)
+	vars := map[string]interface{}{
+		"owner": owner,
+		"name":  name,
+		"ref":   ref,
+		"path":  path,
+	}
+	out := new(blameResult)
+	res, err := s.client.graphql(ctx, blameQuery, vars, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if out.Repository.Object == nil {
+		return nil, res, scm.ErrNotFound
+	}
+	return convertBlameRangeList(out.Repository.Object.Blame.Ranges), res, nil
+}
+
+const blameQuery = `query($owner: String!, $name: String!, $ref: String!, $path: String!) {
+  repository(owner: $owner, name: $name) {
+    object(expression: $ref) {
+      ... on Commit {
+        blame(path: $path) {
+          ranges {
+            startingLine
+            endingLine
+            commit {
+              oid
+              message
+              url
+              author {
+                name
+                email
+                date
+                user {
+                  login
+                  avatarUrl
+                }
+              }
+              committer {
+                name
+                email
+                date
+                user {
+                  login
+                  avatarUrl
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+type blameResult struct {
+	Repository struct {
+		Object *struct {
+			Blame struct {
+				Ranges []*blameRange `json:"ranges"`
+			} `json:"blame"`
+		} `json:"object"`
+	} `json:"repository"`
+}
+
+type blameRange struct {
+	StartingLine int `json:"startingLine"`
+	EndingLine   int `json:"endingLine"`
+	Commit       struct {
+		Oid       string         `json:"oid"`
+		Message   string         `json:"message"`
+		URL       string         `json:"url"`
+		Author    blameSignature `json:"author"`
+		Committer blameSignature `json:"committer"`
+	} `json:"commit"`
+}
+
+type blameSignature struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+	User  *struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatarUrl"`
+	} `json:"user"`
 }
 
 type content struct {
-	Name    string `json:"name"`
-	Path    string `json:"path"`
-	Sha     string `json:"sha"`
-	Content string `json:"content"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Sha         string `json:"sha"`
+	Size        int    `json:"size"`
+	HTMLURL     string `json:"html_url"`
+	DownloadURL string `json:"download_url"`
+	Content     string `json:"content"`
+	Encoding    string `json:"encoding"`
+}
+
+type blob struct {
+	Sha      string `json:"sha"`
+	Size     int    `json:"size"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
 }
 
 type entry struct {
@@ -96,10 +230,53 @@ type contentUpdate struct {
 }
 
 type contentBody struct {
-	Message string `json:"message"`
-	Content []byte `json:"content"`
-	Sha     string `json:"sha,omitempty"`
-	Branch  string `json:"branch,omitempty"`
+	Message   string          `json:"message"`
+	Content   []byte          `json:"content"`
+	Sha       string          `json:"sha,omitempty"`
+	Branch    string          `json:"branch,omitempty"`
+	Author    *signatureInput `json:"author,omitempty"`
+	Committer *signatureInput `json:"committer,omitempty"`
+}
+
+type contentDeleteBody struct {
+	Message   string          `json:"message"`
+	Sha       string          `json:"sha"`
+	Branch    string          `json:"branch,omitempty"`
+	Author    *signatureInput `json:"author,omitempty"`
+	Committer *signatureInput `json:"committer,omitempty"`
+}
+
+type signatureInput struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Date  string `json:"date,omitempty"`
+}
+
+func convertContent(from *content) *scm.Content {
+	raw, _ := base64.StdEncoding.DecodeString(from.Content)
+	return &scm.Content{
+		Path:         from.Path,
+		Data:         raw,
+		Sha:          from.Sha,
+		Size:         from.Size,
+		Encoding:     from.Encoding,
+		Link:         from.HTMLURL,
+		DownloadLink: from.DownloadURL,
+	}
+}
+
+func convertSignatureInput(from *scm.Signature) *signatureInput {
+	if from == nil {
+		return nil
+	}
+	to := &signatureInput{
+		Name:  from.Name,
+		Email: from.Email,
+	}
+	if !from.Date.IsZero() {
+		to.Date = from.Date.UTC().Format(time.RFC3339)
+	}
+	return to
 }
 
 func convertEntryList(out []*entry) []*scm.FileEntry {
@@ -120,3 +297,34 @@ func convertEntry(from *entry) *scm.FileEntry {
 		Link: from.URL,
 	}
 }
+
+func convertBlameRangeList(from []*blameRange) []*scm.BlameRange {
+	to := []*scm.BlameRange{}
+	for _, v := range from {
+		to = append(to, &scm.BlameRange{
+			StartLine: v.StartingLine,
+			EndLine:   v.EndingLine,
+			Commit: scm.Commit{
+				Sha:       v.Commit.Oid,
+				Message:   v.Commit.Message,
+				Link:      v.Commit.URL,
+				Author:    convertBlameSignature(v.Commit.Author),
+				Committer: convertBlameSignature(v.Commit.Committer),
+			},
+		})
+	}
+	return to
+}
+
+func convertBlameSignature(from blameSignature) scm.Signature {
+	to := scm.Signature{
+		Name:  from.Name,
+		Email: from.Email,
+		Date:  from.Date,
+	}
+	if from.User != nil {
+		to.Login = from.User.Login
+		to.Avatar = from.User.AvatarURL
+	}
+	return to
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/jenkins-x/go-scm/scm"
@@ -52,6 +53,45 @@ func TestContentFind(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestContentFindLarge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/contents/data.csv").
+		MatchParam("ref", "7fd1a60b01f91b314f59955a4e4d4e80d8edf11d").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/content_large.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/git/blobs/3d21ec53a331a6f037a91c368710b99387d012c1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/blob.json")
+
+	client := NewDefault()
+	got, _, err := client.Contents.Find(
+		context.Background(),
+		"octocat/hello-world",
+		"data.csv",
+		"7fd1a60b01f91b314f59955a4e4d4e80d8edf11d",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Content)
+	raw, _ := ioutil.ReadFile("testdata/content_large.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestContentList(t *testing.T) {
 	defer gock.Off()
 
@@ -121,6 +161,44 @@ func TestContentCreate(t *testing.T) {
 	}
 }
 
+func TestContentCreateAuthor(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Put("/repos/octocat/hello-world/contents/README").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"message":   "just a test message",
+			"content":   encode([]byte("testing")),
+			"author":    map[string]string{"name": "Monalisa Octocat", "email": "octocat@github.com"},
+			"committer": map[string]string{"name": "Jenkins X", "email": "bot@jenkins-x.io", "date": "2021-06-01T10:00:00Z"},
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/content.json")
+
+	params := &scm.ContentParams{
+		Message: "just a test message",
+		Data:    []byte("testing"),
+		Author: &scm.Signature{
+			Name:  "Monalisa Octocat",
+			Email: "octocat@github.com",
+		},
+		Committer: &scm.Signature{
+			Name:  "Jenkins X",
+			Email: "bot@jenkins-x.io",
+			Date:  time.Date(2021, time.June, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	client := NewDefault()
+	_, err := client.Contents.Create(context.Background(), "octocat/hello-world", "README", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestContentUpdate(t *testing.T) {
 	defer gock.Off()
 	message := "just a test message"
@@ -153,13 +231,87 @@ func TestContentUpdate(t *testing.T) {
 }
 
 func TestContentDelete(t *testing.T) {
-	content := new(contentService)
-	_, err := content.Delete(context.Background(), "octocat/hello-world", "README", "master")
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Delete("/repos/octocat/hello-world/contents/README").
+		MatchType("json").
+		JSON(map[string]string{"message": "remove the readme", "sha": "980a0d5f19a64b4b30a87d4206aade58726b60e3", "branch": "master"}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	params := &scm.ContentParams{
+		Branch:  "master",
+		Message: "remove the readme",
+		Sha:     "980a0d5f19a64b4b30a87d4206aade58726b60e3",
+	}
+	res, err := client.Contents.Delete(context.Background(), "octocat/hello-world", "README", params)
+	if err != nil {
+		t.Fatal(err)
 	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
 }
 
 func encode(b []byte) string {
 	return base64.StdEncoding.EncodeToString([]byte(b))
 }
+
+func TestContentBlame(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		JSON(map[string]interface{}{
+			"query": blameQuery,
+			"variables": map[string]interface{}{
+				"owner": "octocat",
+				"name":  "hello-world",
+				"ref":   "master",
+				"path":  "README",
+			},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/content_blame.json")
+
+	client := NewDefault()
+	got, res, err := client.Contents.Blame(context.Background(), "octocat/hello-world", "README", "master")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.BlameRange{}
+	raw, _ := ioutil.ReadFile("testdata/content_blame.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestContentBlameNotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"data": {"repository": {"object": null}}}`)
+
+	client := NewDefault()
+	_, _, err := client.Contents.Blame(context.Background(), "octocat/hello-world", "README", "missing")
+	if err != scm.ErrNotFound {
+		t.Errorf("Expect Not Found error, got %v", err)
+	}
+}
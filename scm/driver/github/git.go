@@ -108,11 +108,30 @@ func (s *gitService) ListChanges(ctx context.Context, repo, ref string, _ scm.Li
 	return convertChangeList(out.Files), res, err
 }
 
-func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, _ scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
-	path := fmt.Sprintf("/repos/%s/compare/%s...%s", repo, ref1, ref2)
-	out := new(commit)
+func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	out, res, err := s.Compare(ctx, repo, ref1, ref2, opts)
+	if err != nil {
+		return nil, res, err
+	}
+	return out.Changes, res, nil
+}
+
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, opts scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/compare/%s...%s", repo, base, head)
+	if params := encodeListOptions(opts); params != "" {
+		path += "?" + params
+	}
+	out := new(comparison)
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
-	return convertChangeList(out.Files), res, err
+	return convertComparison(out), res, err
+}
+
+type comparison struct {
+	AheadBy         int       `json:"ahead_by"`
+	BehindBy        int       `json:"behind_by"`
+	MergeBaseCommit commit    `json:"merge_base_commit"`
+	Commits         []*commit `json:"commits"`
+	Files           []*file   `json:"files"`
 }
 
 type branch struct {
@@ -160,6 +179,16 @@ func convertCommitList(from []*commit) []*scm.Commit {
 	return to
 }
 
+func convertComparison(from *comparison) *scm.Comparison {
+	return &scm.Comparison{
+		MergeBase: from.MergeBaseCommit.Sha,
+		Ahead:     from.AheadBy,
+		Behind:    from.BehindBy,
+		Commits:   convertCommitList(from.Commits),
+		Changes:   convertChangeList(from.Files),
+	}
+}
+
 func convertCommit(from *commit) *scm.Commit {
 	return &scm.Commit{
 		Message: from.Commit.Message,
@@ -54,8 +54,10 @@ func New(uri string) (*scm.Client, error) {
 	client.PullRequests = &pullService{&issueService{client}}
 	client.Repositories = &repositoryService{client}
 	client.Reviews = &reviewService{client}
+	client.Search = &searchService{client}
 	client.Users = &userService{client}
 	client.Webhooks = &webhookService{client}
+	client.Unsupported = unsupported
 	client.Apps = &appService{client}
 
 	graphqlEndpoint := scm.URLJoin(uri, "/graphql")
@@ -175,6 +177,34 @@ func (c *wrapper) doRequest(ctx context.Context, req *scm.Request, in, out inter
 	return res, json.NewDecoder(res.Body).Decode(out)
 }
 
+// graphql posts the GraphQL query or mutation and unmarshals
+// the data of the response.
+func (c *wrapper) graphql(ctx context.Context, query string, vars map[string]interface{}, out interface{}) (*scm.Response, error) {
+	in := &graphqlRequest{
+		Query:     query,
+		Variables: vars,
+	}
+	resp := &graphqlResponse{Data: out}
+	res, err := c.do(ctx, "POST", c.GraphQLURL.String(), in, resp)
+	if err != nil {
+		return res, err
+	}
+	if len(resp.Errors) > 0 {
+		return res, &resp.Errors[0]
+	}
+	return res, nil
+}
+
+type graphqlRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphqlResponse struct {
+	Data   interface{} `json:"data"`
+	Errors []Error     `json:"errors"`
+}
+
 // Error represents a Github error.
 type Error struct {
 	Message string `json:"message"`
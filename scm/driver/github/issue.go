@@ -28,6 +28,7 @@ type searchIssue struct {
 	CommentsURL       string  `json:"comments_url"`
 	EventsURL         string  `json:"events_url"`
 	Comments          int     `json:"comments"`
+	Draft             bool    `json:"draft"`
 }
 
 type searchResults struct {
@@ -266,7 +267,9 @@ type issue struct {
 	UpdatedAt time.Time `json:"updated_at"`
 
 	// This will be non-nil if it is a pull request.
-	PullRequest *struct{} `json:"pull_request,omitempty"`
+	PullRequest *struct {
+		MergedAt *time.Time `json:"merged_at"`
+	} `json:"pull_request,omitempty"`
 }
 
 type issueInput struct {
@@ -58,7 +58,9 @@ func (s *milestoneService) Create(ctx context.Context, repo string, input *scm.M
 		Title:       input.Title,
 		State:       input.State,
 		Description: input.Description,
-		DueOn:       *input.DueDate,
+	}
+	if input.DueDate != nil {
+		in.DueOn = *input.DueDate
 	}
 	out := new(milestone)
 	res, err := s.client.do(ctx, "POST", path, in, out)
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
@@ -32,11 +33,86 @@ func (s *pullService) Find(ctx context.Context, repo string, number int) (*scm.P
 	return convertPullRequest(out), res, err
 }
 
+func (s *pullService) FindMergeability(ctx context.Context, repo string, number int) (*scm.Mergeability, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d", repo, number)
+	out := new(pr)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	from := convertPullRequest(out)
+	rules, res, err := s.findMergeRules(ctx, repo, from.Base.Ref)
+	if err != nil {
+		return nil, res, err
+	}
+	m, res, err := scm.FindMergeability(ctx, s.client.Client, repo, from, rules)
+	if err != nil {
+		return nil, res, err
+	}
+	m.State = out.MergeableState
+	switch out.MergeableState {
+	case "dirty":
+		m.Block(scm.MergeBlockerConflicts)
+	case "behind":
+		m.Block(scm.MergeBlockerBehindBase)
+	case "draft":
+		m.Block(scm.MergeBlockerDraft)
+	case "blocked":
+		if len(m.Blockers) == 0 {
+			m.Block(scm.MergeBlockerBlocked)
+		}
+	case "unknown":
+		if len(m.Blockers) == 0 {
+			m.Block(scm.MergeBlockerUnknown)
+		}
+	}
+	return m, res, nil
+}
+
+// findMergeRules returns the merge rules of the protected branch. If the
+// branch is not protected no rules apply, if the protection cannot be read
+// (e.g. due to missing permissions) nil rules are returned.
+func (s *pullService) findMergeRules(ctx context.Context, repo, branch string) (*scm.MergeRules, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/branches/%s/protection", repo, url.PathEscape(branch))
+	out := new(branchProtection)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		if res != nil && res.Status == http.StatusNotFound {
+			return &scm.MergeRules{}, res, nil
+		}
+		return nil, res, nil
+	}
+	rules := &scm.MergeRules{
+		RequiredApprovals: out.RequiredPullRequestReviews.RequiredApprovingReviewCount,
+	}
+	if out.RequiredStatusChecks != nil {
+		rules.RequiredContexts = out.RequiredStatusChecks.Contexts
+	}
+	return rules, res, nil
+}
+
 func (s *pullService) List(ctx context.Context, repo string, opts scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
+	if opts.Head != "" && !strings.Contains(opts.Head, ":") {
+		// the head must be qualified with the owner of the repository
+		owner, _ := scm.Split(repo)
+		opts.Head = owner + ":" + opts.Head
+	}
 	path := fmt.Sprintf("repos/%s/pulls?%s", repo, encodePullRequestListOptions(opts))
 	out := []*pr{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
-	return convertPullRequestList(out), res, err
+	prs := convertPullRequestList(out)
+	if opts.Author != "" {
+		// the pulls API cannot filter by author so filter the page,
+		// leaving the pagination of the response as it is
+		filtered := []*scm.PullRequest{}
+		for _, pr := range prs {
+			if NormLogin(pr.Author.Login) == NormLogin(opts.Author) {
+				filtered = append(filtered, pr)
+			}
+		}
+		prs = filtered
+	}
+	return prs, res, err
 }
 
 func (s *pullService) ListChanges(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
@@ -46,12 +122,126 @@ func (s *pullService) ListChanges(ctx context.Context, repo string, number int,
 	return convertChangeList(out), res, err
 }
 
+func (s *pullService) ListCommits(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Commit, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d/commits?%s", repo, number, encodeListOptions(opts))
+	out := []*commit{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertCommitList(out), res, err
+}
+
 func (s *pullService) Merge(ctx context.Context, repo string, number int, options *scm.PullRequestMergeOptions) (*scm.Response, error) {
 	path := fmt.Sprintf("repos/%s/pulls/%d/merge", repo, number)
 	res, err := s.client.do(ctx, "PUT", path, encodePullRequestMergeOptions(options), nil)
 	return res, err
 }
 
+func (s *pullService) EnableAutoMerge(ctx context.Context, repo string, number int, options *scm.PullRequestMergeOptions) (*scm.Response, error) {
+	nodeID, res, err := s.nodeID(ctx, repo, number)
+	if err != nil {
+		return res, err
+	}
+	// auto-merge can only be enabled with the GraphQL API
+	vars := map[string]interface{}{"pr": nodeID}
+	if options != nil {
+		if options.MergeMethod != "" {
+			vars["method"] = strings.ToUpper(options.MergeMethod)
+		}
+		if options.CommitTitle != "" {
+			vars["title"] = options.CommitTitle
+		}
+		if options.CommitMessage != "" {
+			vars["body"] = options.CommitMessage
+		}
+		if options.SHA != "" {
+			vars["head"] = options.SHA
+		}
+	}
+	return s.client.graphql(ctx, enableAutoMergeMutation, vars, nil)
+}
+
+func (s *pullService) DisableAutoMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	nodeID, res, err := s.nodeID(ctx, repo, number)
+	if err != nil {
+		return res, err
+	}
+	vars := map[string]interface{}{"pr": nodeID}
+	return s.client.graphql(ctx, disableAutoMergeMutation, vars, nil)
+}
+
+func (s *pullService) UpdateBranch(ctx context.Context, repo string, number int, method string) (*scm.Response, error) {
+	switch method {
+	case "", "merge":
+		path := fmt.Sprintf("repos/%s/pulls/%d/update-branch", repo, number)
+		return s.client.do(ctx, "PUT", path, nil, nil)
+	case "rebase":
+		// the REST API only supports merging the base branch
+		nodeID, res, err := s.nodeID(ctx, repo, number)
+		if err != nil {
+			return res, err
+		}
+		vars := map[string]interface{}{"pr": nodeID, "method": "REBASE"}
+		return s.client.graphql(ctx, updateBranchMutation, vars, nil)
+	default:
+		return nil, fmt.Errorf("unsupported update method %q", method)
+	}
+}
+
+func (s *pullService) MarkReady(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	nodeID, res, err := s.nodeID(ctx, repo, number)
+	if err != nil {
+		return res, err
+	}
+	vars := map[string]interface{}{"pr": nodeID}
+	return s.client.graphql(ctx, markReadyMutation, vars, nil)
+}
+
+func (s *pullService) ConvertToDraft(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	nodeID, res, err := s.nodeID(ctx, repo, number)
+	if err != nil {
+		return res, err
+	}
+	vars := map[string]interface{}{"pr": nodeID}
+	return s.client.graphql(ctx, convertToDraftMutation, vars, nil)
+}
+
+// nodeID returns the GraphQL node id of the pull request
+func (s *pullService) nodeID(ctx context.Context, repo string, number int) (string, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d", repo, number)
+	out := new(pr)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out.NodeID, res, err
+}
+
+const enableAutoMergeMutation = `mutation($pr: ID!, $method: PullRequestMergeMethod, $title: String, $body: String, $head: GitObjectID) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $pr, mergeMethod: $method, commitHeadline: $title, commitBody: $body, expectedHeadOid: $head}) {
+    clientMutationId
+  }
+}`
+
+const disableAutoMergeMutation = `mutation($pr: ID!) {
+  disablePullRequestAutoMerge(input: {pullRequestId: $pr}) {
+    clientMutationId
+  }
+}`
+
+const updateBranchMutation = `mutation($pr: ID!, $method: PullRequestBranchUpdateMethod) {
+  updatePullRequestBranch(input: {pullRequestId: $pr, updateMethod: $method}) {
+    clientMutationId
+  }
+}`
+
+const markReadyMutation = `mutation($pr: ID!) {
+  markPullRequestReadyForReview(input: {pullRequestId: $pr}) {
+    clientMutationId
+  }
+}`
+
+const convertToDraftMutation = `mutation($pr: ID!) {
+  convertPullRequestToDraft(input: {pullRequestId: $pr}) {
+    clientMutationId
+  }
+}`
+
 func (s *pullService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
 	path := fmt.Sprintf("repos/%s/pulls/%d", repo, number)
 	data := map[string]string{"state": "closed"}
@@ -69,15 +259,52 @@ func (s *pullService) Reopen(ctx context.Context, repo string, number int) (*scm
 func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
 	path := fmt.Sprintf("repos/%s/pulls", repo)
 	in := &prInput{
-		Title: input.Title,
-		Head:  input.Head,
-		Base:  input.Base,
-		Body:  input.Body,
+		Title:               input.Title,
+		Head:                input.Head,
+		Base:                input.Base,
+		Body:                input.Body,
+		Draft:               input.Draft,
+		MaintainerCanModify: input.MaintainerCanModify,
+	}
+	if input.HeadRepo != "" && input.HeadRepo != repo && !strings.Contains(input.Head, ":") {
+		owner, _ := scm.Split(input.HeadRepo)
+		in.Head = owner + ":" + input.Head
 	}
 
 	out := new(pr)
 	res, err := s.client.do(ctx, "POST", path, in, out)
-	return convertPullRequest(out), res, err
+	if err != nil {
+		return convertPullRequest(out), res, err
+	}
+
+	// labels, assignees and the milestone can only be set via the issues API
+	updated := false
+	if len(input.Labels) > 0 || len(input.Assignees) > 0 || input.Milestone != 0 {
+		path := fmt.Sprintf("repos/%s/issues/%d", repo, out.Number)
+		in := &prIssueInput{
+			Labels:    input.Labels,
+			Assignees: input.Assignees,
+			Milestone: input.Milestone,
+		}
+		if res, err := s.client.do(ctx, "PATCH", path, in, nil); err != nil {
+			return convertPullRequest(out), res, err
+		}
+		updated = true
+	}
+	if len(input.Reviewers) > 0 {
+		if res, err := s.RequestReview(ctx, repo, out.Number, input.Reviewers); err != nil {
+			return convertPullRequest(out), res, err
+		}
+		updated = true
+	}
+	if updated {
+		pr, res, err := s.Find(ctx, repo, out.Number)
+		if err != nil {
+			return convertPullRequest(out), res, err
+		}
+		return pr, res, nil
+	}
+	return convertPullRequest(out), res, nil
 }
 
 func (s *pullService) Update(ctx context.Context, repo string, number int, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
@@ -207,6 +434,7 @@ type prBranch struct {
 }
 
 type pr struct {
+	NodeID             string      `json:"node_id"`
 	Number             int         `json:"number"`
 	State              string      `json:"state"`
 	Title              string      `json:"title"`
@@ -229,6 +457,23 @@ type pr struct {
 	MergedAt           null.String `json:"merged_at"`
 	CreatedAt          time.Time   `json:"created_at"`
 	UpdatedAt          time.Time   `json:"updated_at"`
+	AutoMerge          *autoMerge  `json:"auto_merge"`
+}
+
+type autoMerge struct {
+	EnabledBy     user   `json:"enabled_by"`
+	MergeMethod   string `json:"merge_method"`
+	CommitTitle   string `json:"commit_title"`
+	CommitMessage string `json:"commit_message"`
+}
+
+type branchProtection struct {
+	RequiredStatusChecks *struct {
+		Contexts []string `json:"contexts"`
+	} `json:"required_status_checks"`
+	RequiredPullRequestReviews struct {
+		RequiredApprovingReviewCount int `json:"required_approving_review_count"`
+	} `json:"required_pull_request_reviews"`
 }
 
 type file struct {
@@ -244,10 +489,18 @@ type file struct {
 }
 
 type prInput struct {
-	Title string `json:"title,omitempty"`
-	Body  string `json:"body,omitempty"`
-	Head  string `json:"head,omitempty"`
-	Base  string `json:"base,omitempty"`
+	Title               string `json:"title,omitempty"`
+	Body                string `json:"body,omitempty"`
+	Head                string `json:"head,omitempty"`
+	Base                string `json:"base,omitempty"`
+	Draft               bool   `json:"draft,omitempty"`
+	MaintainerCanModify bool   `json:"maintainer_can_modify,omitempty"`
+}
+
+type prIssueInput struct {
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Milestone int      `json:"milestone,omitempty"`
 }
 
 func convertPullRequestList(from []*pr) []*scm.PullRequest {
@@ -286,6 +539,19 @@ func convertPullRequest(from *pr) *scm.PullRequest {
 		Reviewers:      convertUsers(from.RequestedReviewers),
 		Created:        from.CreatedAt,
 		Updated:        from.UpdatedAt,
+		AutoMerge:      convertAutoMerge(from.AutoMerge),
+	}
+}
+
+func convertAutoMerge(from *autoMerge) *scm.AutoMerge {
+	if from == nil {
+		return nil
+	}
+	return &scm.AutoMerge{
+		EnabledBy:     *convertUser(&from.EnabledBy),
+		MergeMethod:   from.MergeMethod,
+		CommitTitle:   from.CommitTitle,
+		CommitMessage: from.CommitMessage,
 	}
 }
 
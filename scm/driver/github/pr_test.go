@@ -46,6 +46,99 @@ func TestPullFind(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestPullFindMergeability(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/branches/master/protection").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/branch_protection.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e/status").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/combined_status.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347/reviews").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/reviews_list.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.FindMergeability(context.Background(), "octocat/hello-world", 1347)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Mergeability)
+	raw, _ := ioutil.ReadFile("testdata/pr_mergeability.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullFindMergeability_Unprotected(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/branches/master/protection").
+		Reply(404).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/error.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e/status").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/combined_status.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347/reviews").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/reviews_list.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.FindMergeability(context.Background(), "octocat/hello-world", 1347)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !got.Mergeable {
+		t.Errorf("Expected pull request to be mergeable, got blockers %v", got.Blockers)
+	}
+	if got, want := got.Approvals, 1; got != want {
+		t.Errorf("Want %d approvals, got %d", want, got)
+	}
+}
+
 func TestPullList(t *testing.T) {
 	defer gock.Off()
 
@@ -113,6 +206,40 @@ func TestPullListChanges(t *testing.T) {
 	t.Run("Page", testPage(res))
 }
 
+func TestPullListCommits(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347/commits").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/pr_commits.json")
+
+	client := NewDefault()
+	got, res, err := client.PullRequests.ListCommits(context.Background(), "octocat/hello-world", 1347, scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Commit{}
+	raw, _ := ioutil.ReadFile("testdata/pr_commits.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+	t.Run("Page", testPage(res))
+}
+
 func TestPullMerge(t *testing.T) {
 	defer gock.Off()
 
@@ -137,6 +264,215 @@ func TestPullMerge(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestPullFind_AutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_auto_merge.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.Find(context.Background(), "octocat/hello-world", 1347)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := &scm.AutoMerge{
+		EnabledBy:     got.Author,
+		MergeMethod:   "squash",
+		CommitTitle:   "Add the new feature (#1347)",
+		CommitMessage: "Includes the new feature",
+	}
+	if diff := cmp.Diff(got.AutoMerge, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullEnableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_auto_merge.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		JSON(map[string]interface{}{
+			"query": enableAutoMergeMutation,
+			"variables": map[string]interface{}{
+				"pr":     "MDExOlB1bGxSZXF1ZXN0MQ==",
+				"method": "SQUASH",
+				"title":  "Add the new feature (#1347)",
+			},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"data": {"enablePullRequestAutoMerge": {"clientMutationId": null}}}`)
+
+	client := NewDefault()
+	options := &scm.PullRequestMergeOptions{
+		MergeMethod: "squash",
+		CommitTitle: "Add the new feature (#1347)",
+	}
+	res, err := client.PullRequests.EnableAutoMerge(context.Background(), "octocat/hello-world", 1347, options)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestPullDisableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_auto_merge.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		JSON(map[string]interface{}{
+			"query":     disableAutoMergeMutation,
+			"variables": map[string]interface{}{"pr": "MDExOlB1bGxSZXF1ZXN0MQ=="},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"data": {"disablePullRequestAutoMerge": {"clientMutationId": null}}}`)
+
+	client := NewDefault()
+	_, err := client.PullRequests.DisableAutoMerge(context.Background(), "octocat/hello-world", 1347)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPullUpdateBranch(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Put("/repos/octocat/hello-world/pulls/1347/update-branch").
+		Reply(202).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"message": "Updating pull request branch.", "url": "https://github.com/repos/octocat/hello-world/pulls/1347"}`)
+
+	client := NewDefault()
+	res, err := client.PullRequests.UpdateBranch(context.Background(), "octocat/hello-world", 1347, "merge")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestPullUpdateBranch_Rebase(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_auto_merge.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		JSON(map[string]interface{}{
+			"query": updateBranchMutation,
+			"variables": map[string]interface{}{
+				"pr":     "MDExOlB1bGxSZXF1ZXN0MQ==",
+				"method": "REBASE",
+			},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"data": {"updatePullRequestBranch": {"clientMutationId": null}}}`)
+
+	client := NewDefault()
+	_, err := client.PullRequests.UpdateBranch(context.Background(), "octocat/hello-world", 1347, "rebase")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if _, err := client.PullRequests.UpdateBranch(context.Background(), "octocat/hello-world", 1347, "squash"); err == nil {
+		t.Errorf("Expect error for an unsupported update method")
+	}
+}
+
+func TestPullMarkReady(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_auto_merge.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		JSON(map[string]interface{}{
+			"query":     markReadyMutation,
+			"variables": map[string]interface{}{"pr": "MDExOlB1bGxSZXF1ZXN0MQ=="},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"data": {"markPullRequestReadyForReview": {"clientMutationId": null}}}`)
+
+	client := NewDefault()
+	_, err := client.PullRequests.MarkReady(context.Background(), "octocat/hello-world", 1347)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPullConvertToDraft(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_auto_merge.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		JSON(map[string]interface{}{
+			"query":     convertToDraftMutation,
+			"variables": map[string]interface{}{"pr": "MDExOlB1bGxSZXF1ZXN0MQ=="},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"data": {"convertPullRequestToDraft": {"clientMutationId": null}}}`)
+
+	client := NewDefault()
+	_, err := client.PullRequests.ConvertToDraft(context.Background(), "octocat/hello-world", 1347)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestPullClose(t *testing.T) {
 	defer gock.Off()
 
@@ -216,6 +552,132 @@ func TestPullCreate(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestPullCreate_Extended(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/pulls").
+		JSON(map[string]interface{}{
+			"title":                 "Amazing new feature",
+			"head":                  "jdoe:new-feature",
+			"base":                  "master",
+			"draft":                 true,
+			"maintainer_can_modify": true,
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_create.json")
+
+	gock.New("https://api.github.com").
+		Patch("/repos/octocat/hello-world/issues/1347").
+		JSON(map[string]interface{}{
+			"labels":    []string{"enhancement"},
+			"assignees": []string{"octocat"},
+			"milestone": 1,
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/issue.json")
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/pulls/1347/requested_reviewers").
+		JSON(map[string]interface{}{
+			"reviewers": []string{"hubot"},
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_create.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_create.json")
+
+	input := &scm.PullRequestInput{
+		Title:               "Amazing new feature",
+		Head:                "new-feature",
+		HeadRepo:            "jdoe/hello-world",
+		Base:                "master",
+		Draft:               true,
+		MaintainerCanModify: true,
+		Labels:              []string{"enhancement"},
+		Assignees:           []string{"octocat"},
+		Reviewers:           []string{"hubot"},
+		Milestone:           1,
+	}
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.Create(context.Background(), "octocat/hello-world", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.PullRequest)
+	raw, _ := ioutil.ReadFile("testdata/pr_create.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks %v", gock.Pending())
+	}
+}
+
+func TestPullListFilters(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls").
+		MatchParam("head", "octocat:new-topic").
+		MatchParam("base", "master").
+		MatchParam("sort", "updated").
+		MatchParam("direction", "desc").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pulls.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.List(context.Background(), "octocat/hello-world", scm.PullRequestListOptions{
+		Open:      true,
+		Head:      "new-topic",
+		Base:      "master",
+		Author:    "octocat",
+		Sort:      "updated",
+		Direction: "desc",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Errorf("Want 1 pull request, got %d", len(got))
+	}
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pulls.json")
+
+	got, _, err = client.PullRequests.List(context.Background(), "octocat/hello-world", scm.PullRequestListOptions{
+		Author: "someone-else",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Want pull requests filtered by author, got %d", len(got))
+	}
+}
+
 func TestPullUpdate(t *testing.T) {
 	defer gock.Off()
 
@@ -289,28 +289,20 @@ func (s *repositoryService) Fork(ctx context.Context, input *scm.RepositoryInput
 // CreateHook creates a new repository webhook.
 func (s *repositoryService) CreateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
 	path := fmt.Sprintf("repos/%s/hooks", repo)
-	in := new(hook)
-	in.Active = true
-	in.Name = "web"
-	in.Config.Secret = input.Secret
-	in.Config.ContentType = "json"
-	in.Config.URL = input.Target
-	if input.SkipVerify {
-		in.Config.InsecureSSL = "1"
-	} else {
-		in.Config.InsecureSSL = "0"
-	}
-	in.Events = append(
-		input.NativeEvents,
-		convertHookEvents(input.Events)...,
-	)
+	in := convertHookInput(input)
 	out := new(hook)
 	res, err := s.client.do(ctx, "POST", path, in, out)
 	return convertHook(out), res, err
 }
 
+// UpdateHook updates the repository webhook with the id of the name
+// of the input.
 func (s *repositoryService) UpdateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("repos/%s/hooks/%s", repo, input.Name)
+	in := convertHookInput(input)
+	out := new(hook)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertHook(out), res, err
 }
 
 // CreateStatus creates a new commit status.
@@ -382,6 +374,25 @@ func convertHookList(from []*hook) []*scm.Hook {
 	return to
 }
 
+func convertHookInput(from *scm.HookInput) *hook {
+	to := new(hook)
+	to.Active = true
+	to.Name = "web"
+	to.Config.Secret = from.Secret
+	to.Config.ContentType = "json"
+	to.Config.URL = from.Target
+	if from.SkipVerify {
+		to.Config.InsecureSSL = "1"
+	} else {
+		to.Config.InsecureSSL = "0"
+	}
+	to.Events = append(
+		from.NativeEvents,
+		convertHookEvents(from.Events)...,
+	)
+	return to
+}
+
 func convertHook(from *hook) *scm.Hook {
 
 	skipVerify := false
@@ -478,11 +489,13 @@ func convertStatusList(from []*status) []*scm.Status {
 
 func convertStatus(from *status) *scm.Status {
 	return &scm.Status{
-		State:  convertState(from.State),
-		Label:  from.Context,
-		Desc:   from.Description,
-		Target: from.TargetURL,
-		Link:   from.URL,
+		State:   convertState(from.State),
+		Label:   from.Context,
+		Desc:    from.Description,
+		Target:  from.TargetURL,
+		Link:    from.URL,
+		Created: from.CreatedAt,
+		Updated: from.UpdatedAt,
 	}
 }
 
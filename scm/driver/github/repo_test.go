@@ -392,6 +392,55 @@ func TestRepositoryHookCreate(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestRepositoryHookUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Patch("/repos/octocat/hello-world/hooks/1").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"name":   "web",
+			"active": true,
+			"events": []string{"push"},
+			"config": map[string]string{
+				"url":          "https://example.com",
+				"secret":       "topsecret",
+				"content_type": "json",
+				"insecure_ssl": "0",
+			},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/hook.json")
+
+	in := &scm.HookInput{
+		Name:   "1",
+		Target: "https://example.com",
+		Secret: "topsecret",
+		Events: scm.HookEvents{Push: true},
+	}
+
+	client := NewDefault()
+	got, res, err := client.Repositories.UpdateHook(context.Background(), "octocat/hello-world", in)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Hook)
+	raw, _ := ioutil.ReadFile("testdata/hook.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
 func TestRepositoryCreate(t *testing.T) {
 	defer gock.Off()
 
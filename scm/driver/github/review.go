@@ -38,11 +38,21 @@ func (s *reviewService) Create(ctx context.Context, repo string, number int, inp
 		Event:    input.Event,
 	}
 	for _, c := range input.Comments {
-		in.Comments = append(in.Comments, &reviewCommentInput{
-			Body:     c.Body,
-			Path:     c.Path,
-			Position: c.Line,
-		})
+		comment := &reviewCommentInput{
+			Body: c.Body,
+			Path: c.Path,
+		}
+		// comments without a side or range keep addressing
+		// the line by its position in the diff
+		if c.Side == "" && c.StartLine == 0 {
+			comment.Position = c.Line
+		} else {
+			comment.Line = c.Line
+			comment.Side = c.Side
+			comment.StartLine = c.StartLine
+			comment.StartSide = c.StartSide
+		}
+		in.Comments = append(in.Comments, comment)
 	}
 	out := new(review)
 	res, err := s.client.do(ctx, "POST", path, in, out)
@@ -96,12 +106,142 @@ func (s *reviewService) Dismiss(ctx context.Context, repo string, prID int, revi
 	return convertReview(out), res, err
 }
 
+func (s *reviewService) CreateComment(ctx context.Context, repo string, number int, input *scm.ReviewCommentInput) (*scm.ReviewComment, *scm.Response, error) {
+	if input.ThreadID != "" {
+		return s.replyThread(ctx, input.ThreadID, input.Body)
+	}
+	sha := input.Sha
+	if sha == "" {
+		pr, res, err := s.client.PullRequests.Find(ctx, repo, number)
+		if err != nil {
+			return nil, res, err
+		}
+		sha = pr.Head.Sha
+	}
+	side := input.Side
+	if side == "" {
+		side = scm.ReviewSideRight
+	}
+	in := &reviewCommentInput{
+		Body:      input.Body,
+		Path:      input.Path,
+		CommitID:  sha,
+		Line:      input.Line,
+		Side:      side,
+		StartLine: input.StartLine,
+	}
+	if input.StartLine != 0 {
+		in.StartSide = input.StartSide
+		if in.StartSide == "" {
+			in.StartSide = side
+		}
+	}
+	path := fmt.Sprintf("repos/%s/pulls/%d/comments", repo, number)
+	out := new(reviewComment)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertReviewComment(out), res, err
+}
+
+func (s *reviewService) replyThread(ctx context.Context, threadID, body string) (*scm.ReviewComment, *scm.Response, error) {
+	vars := map[string]interface{}{
+		"thread": threadID,
+		"body":   body,
+	}
+	out := new(reviewThreadReply)
+	res, err := s.client.graphql(ctx, reviewThreadReplyMutation, vars, out)
+	if err != nil {
+		return nil, res, err
+	}
+	comment := out.AddPullRequestReviewThreadReply.Comment
+	if comment == nil {
+		return nil, res, scm.ErrNotFound
+	}
+	return convertReviewThreadComment(comment, threadID, ""), res, nil
+}
+
+// ListThreads returns all the review threads of the pull request as
+// threads are only available from the GraphQL API which does not
+// support page numbers. The page size is used for each query.
+func (s *reviewService) ListThreads(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.ReviewThread, *scm.Response, error) {
+	owner, name := scm.Split(repo)
+	size := opts.Size
+	if size <= 0 || size > 100 {
+		size = 100
+	}
+	vars := map[string]interface{}{
+		"owner":  owner,
+		"name":   name,
+		"number": number,
+		"first":  size,
+	}
+	var threads []*scm.ReviewThread
+	for {
+		out := new(reviewThreads)
+		res, err := s.client.graphql(ctx, reviewThreadsQuery, vars, out)
+		if err != nil {
+			return nil, res, err
+		}
+		page := out.Repository.PullRequest.ReviewThreads
+		threads = append(threads, convertReviewThreadList(page.Nodes)...)
+		if !page.PageInfo.HasNextPage {
+			return threads, res, nil
+		}
+		vars["after"] = page.PageInfo.EndCursor
+	}
+}
+
+func (s *reviewService) ResolveThread(ctx context.Context, repo string, number int, threadID string) (*scm.Response, error) {
+	vars := map[string]interface{}{"thread": threadID}
+	return s.client.graphql(ctx, resolveReviewThreadMutation, vars, nil)
+}
+
+func (s *reviewService) UnresolveThread(ctx context.Context, repo string, number int, threadID string) (*scm.Response, error) {
+	vars := map[string]interface{}{"thread": threadID}
+	return s.client.graphql(ctx, unresolveReviewThreadMutation, vars, nil)
+}
+
+const reviewThreadCommentFields = `databaseId body path line startLine url
+  author { login avatarUrl } commit { oid } replyTo { databaseId }
+  createdAt updatedAt`
+
+const reviewThreadsQuery = `query($owner: String!, $name: String!, $number: Int!, $first: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewThreads(first: $first, after: $after) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          id path line startLine diffSide isResolved isOutdated
+          comments(first: 100) { nodes { ` + reviewThreadCommentFields + ` } }
+        }
+      }
+    }
+  }
+}`
+
+const reviewThreadReplyMutation = `mutation($thread: ID!, $body: String!) {
+  addPullRequestReviewThreadReply(input: {pullRequestReviewThreadId: $thread, body: $body}) {
+    comment { ` + reviewThreadCommentFields + ` }
+  }
+}`
+
+const resolveReviewThreadMutation = `mutation($thread: ID!) {
+  resolveReviewThread(input: {threadId: $thread}) { thread { id } }
+}`
+
+const unresolveReviewThreadMutation = `mutation($thread: ID!) {
+  unresolveReviewThread(input: {threadId: $thread}) { thread { id } }
+}`
+
 type reviewComment struct {
-	ID       int    `json:"id"`
-	CommitID string `json:"commit_id"`
-	Position int    `json:"position"`
-	Path     string `json:"path"`
-	User     struct {
+	ID          int    `json:"id"`
+	CommitID    string `json:"commit_id"`
+	Position    int    `json:"position"`
+	Line        int    `json:"line"`
+	StartLine   int    `json:"start_line"`
+	Side        string `json:"side"`
+	InReplyToID int    `json:"in_reply_to_id"`
+	Path        string `json:"path"`
+	User        struct {
 		ID        int    `json:"id"`
 		Login     string `json:"login"`
 		AvatarURL string `json:"avatar_url"`
@@ -120,9 +260,68 @@ type reviewInput struct {
 }
 
 type reviewCommentInput struct {
-	Body     string `json:"body"`
-	Path     string `json:"path"`
-	Position int    `json:"position"`
+	Body      string `json:"body"`
+	Path      string `json:"path"`
+	CommitID  string `json:"commit_id,omitempty"`
+	Position  int    `json:"position,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Side      string `json:"side,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	StartSide string `json:"start_side,omitempty"`
+}
+
+type reviewThreads struct {
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []*reviewThread `json:"nodes"`
+			} `json:"reviewThreads"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+type reviewThread struct {
+	ID         string `json:"id"`
+	Path       string `json:"path"`
+	Line       int    `json:"line"`
+	StartLine  int    `json:"startLine"`
+	DiffSide   string `json:"diffSide"`
+	IsResolved bool   `json:"isResolved"`
+	IsOutdated bool   `json:"isOutdated"`
+	Comments   struct {
+		Nodes []*reviewThreadComment `json:"nodes"`
+	} `json:"comments"`
+}
+
+type reviewThreadComment struct {
+	DatabaseID int    `json:"databaseId"`
+	Body       string `json:"body"`
+	Path       string `json:"path"`
+	Line       int    `json:"line"`
+	StartLine  int    `json:"startLine"`
+	URL        string `json:"url"`
+	Author     struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatarUrl"`
+	} `json:"author"`
+	Commit struct {
+		Oid string `json:"oid"`
+	} `json:"commit"`
+	ReplyTo struct {
+		DatabaseID int `json:"databaseId"`
+	} `json:"replyTo"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type reviewThreadReply struct {
+	AddPullRequestReviewThreadReply struct {
+		Comment *reviewThreadComment `json:"comment"`
+	} `json:"addPullRequestReviewThreadReply"`
 }
 
 type reviewUpdateInput struct {
@@ -184,18 +383,70 @@ func convertReviewCommentList(from []*reviewComment) []*scm.ReviewComment {
 }
 
 func convertReviewComment(from *reviewComment) *scm.ReviewComment {
+	line := from.Line
+	if line == 0 {
+		line = from.Position
+	}
 	return &scm.ReviewComment{
-		ID:   0,
+		ID:   from.ID,
 		Body: from.Body,
 		Path: from.Path,
 		Sha:  from.CommitID,
-		Line: from.Position,
+		Line: line,
 		Link: from.HTMLURL,
 		Author: scm.User{
 			Login:  from.User.Login,
 			Avatar: from.User.AvatarURL,
 		},
-		Created: from.CreatedAt,
-		Updated: from.UpdatedAt,
+		Created:   from.CreatedAt,
+		Updated:   from.UpdatedAt,
+		StartLine: from.StartLine,
+		Side:      from.Side,
+		InReplyTo: from.InReplyToID,
+	}
+}
+
+func convertReviewThreadList(from []*reviewThread) []*scm.ReviewThread {
+	to := []*scm.ReviewThread{}
+	for _, v := range from {
+		to = append(to, convertReviewThread(v))
+	}
+	return to
+}
+
+func convertReviewThread(from *reviewThread) *scm.ReviewThread {
+	thread := &scm.ReviewThread{
+		ID:        from.ID,
+		Path:      from.Path,
+		Line:      from.Line,
+		StartLine: from.StartLine,
+		Side:      from.DiffSide,
+		Resolved:  from.IsResolved,
+		Outdated:  from.IsOutdated,
+	}
+	for _, c := range from.Comments.Nodes {
+		thread.Comments = append(thread.Comments, convertReviewThreadComment(c, from.ID, from.DiffSide))
+	}
+	return thread
+}
+
+func convertReviewThreadComment(from *reviewThreadComment, threadID, side string) *scm.ReviewComment {
+	return &scm.ReviewComment{
+		ID:   from.DatabaseID,
+		Body: from.Body,
+		Path: from.Path,
+		Sha:  from.Commit.Oid,
+		Line: from.Line,
+		Link: from.URL,
+		Author: scm.User{
+			Login:  from.Author.Login,
+			Avatar: from.Author.AvatarURL,
+		},
+		Created:   from.CreatedAt,
+		Updated:   from.UpdatedAt,
+		StartLine: from.StartLine,
+		Side:      side,
+		ThreadID:  threadID,
+		InReplyTo: from.ReplyTo.DatabaseID,
 	}
 }
@@ -273,3 +273,147 @@ func TestReviewDismiss(t *testing.T) {
 	t.Run("Request", testRequest(res))
 	t.Run("Rate", testRate(res))
 }
+
+func TestReviewCreateComment(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/pulls/1347/comments").
+		JSON(map[string]interface{}{
+			"body":       "Great stuff!",
+			"path":       "file1.txt",
+			"commit_id":  "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			"line":       12,
+			"side":       "RIGHT",
+			"start_line": 10,
+			"start_side": "RIGHT",
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/review_comment_create.json")
+
+	input := &scm.ReviewCommentInput{
+		Body:      "Great stuff!",
+		Path:      "file1.txt",
+		StartLine: 10,
+		Line:      12,
+	}
+
+	client := NewDefault()
+	got, res, err := client.Reviews.CreateComment(context.Background(), "octocat/hello-world", 1347, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.ReviewComment)
+	raw, _ := ioutil.ReadFile("testdata/review_comment_create.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestReviewCreateComment_Reply(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/review_thread_reply.json")
+
+	input := &scm.ReviewCommentInput{
+		Body:     "Done",
+		ThreadID: "PRRT_kwDOABCD1M4Aa1b2",
+	}
+
+	client := NewDefault()
+	got, _, err := client.Reviews.CreateComment(context.Background(), "octocat/hello-world", 1, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.ID != 11 || got.ThreadID != "PRRT_kwDOABCD1M4Aa1b2" || got.InReplyTo != 8 {
+		t.Errorf("Unexpected reply %+v", got)
+	}
+}
+
+func TestReviewListThreads(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/review_threads.json")
+
+	client := NewDefault()
+	got, res, err := client.Reviews.ListThreads(context.Background(), "octocat/hello-world", 1, scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.ReviewThread{}
+	raw, _ := ioutil.ReadFile("testdata/review_threads.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestReviewResolveThread(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`resolveReviewThread`).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/review_thread_resolve.json")
+
+	client := NewDefault()
+	_, err := client.Reviews.ResolveThread(context.Background(), "octocat/hello-world", 1, "PRRT_kwDOABCD1M4Aa1b2")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReviewResolveThread_Error(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"data": null, "errors": [{"message": "Could not resolve to a node with the global id of 'missing'"}]}`)
+
+	client := NewDefault()
+	_, err := client.Reviews.ResolveThread(context.Background(), "octocat/hello-world", 1, "missing")
+	if err == nil || err.Error() != "Could not resolve to a node with the global id of 'missing'" {
+		t.Errorf("Expected the GraphQL error, got %v", err)
+	}
+}
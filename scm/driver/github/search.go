@@ -0,0 +1,237 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type searchService struct {
+	client *wrapper
+}
+
+type repositorySearchResults struct {
+	TotalCount        int           `json:"total_count"`
+	IncompleteResults bool          `json:"incomplete_results"`
+	Items             []*repository `json:"items"`
+}
+
+type codeSearchResults struct {
+	TotalCount        int           `json:"total_count"`
+	IncompleteResults bool          `json:"incomplete_results"`
+	Items             []*codeResult `json:"items"`
+}
+
+type codeResult struct {
+	Name        string     `json:"name"`
+	Path        string     `json:"path"`
+	Sha         string     `json:"sha"`
+	HTMLURL     string     `json:"html_url"`
+	Repository  repository `json:"repository"`
+	TextMatches []struct {
+		Fragment string `json:"fragment"`
+	} `json:"text_matches"`
+}
+
+type commitSearchResults struct {
+	TotalCount        int             `json:"total_count"`
+	IncompleteResults bool            `json:"incomplete_results"`
+	Items             []*searchCommit `json:"items"`
+}
+
+type searchCommit struct {
+	commit
+	Repository repository `json:"repository"`
+}
+
+func (s *searchService) Repositories(ctx context.Context, q scm.SearchQuery) (*scm.RepositorySearchResults, *scm.Response, error) {
+	if err := checkSearchQuery(q, true); err != nil {
+		return nil, nil, err
+	}
+	path := "search/repositories?" + encodeSearchQuery(q)
+	out := new(repositorySearchResults)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return &scm.RepositorySearchResults{
+		Total:      out.TotalCount,
+		Incomplete: out.IncompleteResults,
+		Items:      convertRepositoryList(out.Items),
+	}, res, err
+}
+
+func (s *searchService) Issues(ctx context.Context, q scm.SearchQuery) (*scm.IssueSearchResults, *scm.Response, error) {
+	path := "search/issues?" + encodeSearchQuery(q, "is:issue")
+	out := new(searchResults)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return &scm.IssueSearchResults{
+		Total:      out.TotalCount,
+		Incomplete: out.IncompleteResults,
+		Items:      convertSearchIssueList(out.Items),
+	}, res, err
+}
+
+func (s *searchService) PullRequests(ctx context.Context, q scm.SearchQuery) (*scm.PullRequestSearchResults, *scm.Response, error) {
+	path := "search/issues?" + encodeSearchQuery(q, "is:pr")
+	out := new(searchResults)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return &scm.PullRequestSearchResults{
+		Total:      out.TotalCount,
+		Incomplete: out.IncompleteResults,
+		Items:      convertSearchPullRequestList(out.Items),
+	}, res, err
+}
+
+func (s *searchService) Code(ctx context.Context, q scm.SearchQuery) (*scm.CodeSearchResults, *scm.Response, error) {
+	if err := checkSearchQuery(q, true); err != nil {
+		return nil, nil, err
+	}
+	req := &scm.Request{
+		Method: http.MethodGet,
+		Path:   "search/code?" + encodeSearchQuery(q),
+		Header: map[string][]string{
+			// This accept header adds the matching fragments of the files
+			"Accept": {"application/vnd.github.v3.text-match+json"},
+		},
+	}
+	out := new(codeSearchResults)
+	res, err := s.client.doRequest(ctx, req, nil, out)
+	return &scm.CodeSearchResults{
+		Total:      out.TotalCount,
+		Incomplete: out.IncompleteResults,
+		Items:      convertCodeResultList(out.Items),
+	}, res, err
+}
+
+func (s *searchService) Commits(ctx context.Context, q scm.SearchQuery) (*scm.CommitSearchResults, *scm.Response, error) {
+	if err := checkSearchQuery(q, false); err != nil {
+		return nil, nil, err
+	}
+	path := "search/commits?" + encodeSearchQuery(q)
+	out := new(commitSearchResults)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return &scm.CommitSearchResults{
+		Total:      out.TotalCount,
+		Incomplete: out.IncompleteResults,
+		Items:      convertSearchCommitList(out.Items),
+	}, res, err
+}
+
+// checkSearchQuery returns an error if the query of a search of repositories,
+// code or commits filters by a qualifier which only applies to issues and
+// pull requests. The author is only a qualifier of commits besides them.
+func checkSearchQuery(q scm.SearchQuery, noAuthor bool) error {
+	var qualifiers []string
+	if q.State != "" {
+		qualifiers = append(qualifiers, "state")
+	}
+	if len(q.Labels) > 0 {
+		qualifiers = append(qualifiers, "labels")
+	}
+	if !q.UpdatedAfter.IsZero() || !q.UpdatedBefore.IsZero() {
+		qualifiers = append(qualifiers, "updated time")
+	}
+	if noAuthor && q.Author != "" {
+		qualifiers = append(qualifiers, "author")
+	}
+	if len(qualifiers) > 0 {
+		return fmt.Errorf("the %s of the search query only apply to issues and pull requests", strings.Join(qualifiers, ", "))
+	}
+	return nil
+}
+
+// encodeSearchQuery encodes the query parameters of a search using the
+// GitHub search syntax with the given extra qualifiers.
+func encodeSearchQuery(q scm.SearchQuery, qualifiers ...string) string {
+	terms := append([]string{}, qualifiers...)
+	if query := q.String(); query != "" {
+		terms = append(terms, query)
+	}
+	params := url.Values{}
+	params.Set("q", strings.Join(terms, " "))
+	if q.Sort != "" {
+		params.Set("sort", q.Sort)
+		order := "desc"
+		if q.Ascending {
+			order = "asc"
+		}
+		params.Set("order", order)
+	}
+	if q.Page != 0 {
+		params.Set("page", strconv.Itoa(q.Page))
+	}
+	if q.Size != 0 {
+		params.Set("per_page", strconv.Itoa(q.Size))
+	}
+	return params.Encode()
+}
+
+func convertSearchPullRequestList(from []*searchIssue) []*scm.SearchPullRequest {
+	to := []*scm.SearchPullRequest{}
+	for _, v := range from {
+		issue := convertIssue(&v.issue)
+		pr := &scm.SearchPullRequest{
+			PullRequest: scm.PullRequest{
+				Number:    issue.Number,
+				Title:     issue.Title,
+				Body:      issue.Body,
+				State:     issue.State,
+				Closed:    issue.Closed,
+				Draft:     v.Draft,
+				Author:    issue.Author,
+				Assignees: issue.Assignees,
+				Link:      issue.Link,
+				Created:   issue.Created,
+				Updated:   issue.Updated,
+			},
+		}
+		for _, l := range v.Labels {
+			pr.Labels = append(pr.Labels, &scm.Label{Name: l.Name})
+		}
+		if v.PullRequest != nil && v.PullRequest.MergedAt != nil {
+			pr.Merged = true
+		}
+		populateRepositoryFromURL(&pr.Repository, v.RepositoryURL)
+		pr.Repository.FullName = scm.Join(pr.Repository.Namespace, pr.Repository.Name)
+		pr.Base.Repo = pr.Repository
+		to = append(to, pr)
+	}
+	return to
+}
+
+func convertCodeResultList(from []*codeResult) []*scm.CodeResult {
+	to := []*scm.CodeResult{}
+	for _, v := range from {
+		var fragments []string
+		for _, m := range v.TextMatches {
+			fragments = append(fragments, m.Fragment)
+		}
+		to = append(to, &scm.CodeResult{
+			Name:       v.Name,
+			Path:       v.Path,
+			Sha:        v.Sha,
+			Link:       v.HTMLURL,
+			Fragment:   strings.Join(fragments, "\n"),
+			Repository: *convertRepository(&v.Repository),
+		})
+	}
+	return to
+}
+
+func convertSearchCommitList(from []*searchCommit) []*scm.SearchCommit {
+	to := []*scm.SearchCommit{}
+	for _, v := range from {
+		to = append(to, &scm.SearchCommit{
+			Commit:     *convertCommit(&v.commit),
+			Repository: *convertRepository(&v.Repository),
+		})
+	}
+	return to
+}
@@ -0,0 +1,188 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSearchRepositories(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/search/repositories").
+		MatchParam("q", "^hello org:octocat$").
+		MatchParam("sort", "stars").
+		MatchParam("order", "asc").
+		MatchParam("per_page", "10").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/search_repositories.json")
+
+	client := NewDefault()
+	query := scm.SearchQuery{Terms: "hello", Org: "octocat", Sort: "stars", Ascending: true, Size: 10}
+	got, res, err := client.Search.Repositories(context.Background(), query)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.RepositorySearchResults)
+	raw, _ := ioutil.ReadFile("testdata/search_repositories.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestSearchIssues(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/search/issues").
+		MatchParam("q", `^is:issue windows state:open label:bug label:"good first issue"$`).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/issue_search.json")
+
+	client := NewDefault()
+	query := scm.SearchQuery{Terms: "windows", State: "open", Labels: []string{"bug", "good first issue"}}
+	got, _, err := client.Search.Issues(context.Background(), query)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	items := []*scm.SearchIssue{}
+	raw, _ := ioutil.ReadFile("testdata/issue_search.json.golden")
+	json.Unmarshal(raw, &items)
+	want := &scm.IssueSearchResults{Total: 280, Items: items}
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchPullRequests(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/search/issues").
+		MatchParam("q", "^is:pr repo:octocat/Hello-World is:merged author:octocat$").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/search_pulls.json")
+
+	client := NewDefault()
+	query := scm.SearchQuery{Repo: "octocat/Hello-World", State: "merged", Author: "octocat"}
+	got, _, err := client.Search.PullRequests(context.Background(), query)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.PullRequestSearchResults)
+	raw, _ := ioutil.ReadFile("testdata/search_pulls.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchCode(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/search/code").
+		MatchHeader("Accept", "text-match").
+		MatchParam("q", "^Hello repo:octocat/Hello-World$").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/search_code.json")
+
+	client := NewDefault()
+	query := scm.SearchQuery{Terms: "Hello", Repo: "octocat/Hello-World"}
+	got, _, err := client.Search.Code(context.Background(), query)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.CodeSearchResults)
+	raw, _ := ioutil.ReadFile("testdata/search_code.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchCommits(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/search/commits").
+		MatchParam("q", "^fix repo:octocat/Hello-World$").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/search_commits.json")
+
+	client := NewDefault()
+	query := scm.SearchQuery{Terms: "fix", Repo: "octocat/Hello-World"}
+	got, _, err := client.Search.Commits(context.Background(), query)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.CommitSearchResults)
+	raw, _ := ioutil.ReadFile("testdata/search_commits.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchIssueQualifiers(t *testing.T) {
+	client := NewDefault()
+	query := scm.SearchQuery{Terms: "fix", State: "open", Labels: []string{"bug"}}
+	if _, _, err := client.Search.Repositories(context.Background(), query); err == nil {
+		t.Errorf("Expected an error searching repositories by state and labels")
+	}
+	if _, _, err := client.Search.Code(context.Background(), query); err == nil {
+		t.Errorf("Expected an error searching code by state and labels")
+	}
+	if _, _, err := client.Search.Commits(context.Background(), query); err == nil {
+		t.Errorf("Expected an error searching commits by state and labels")
+	}
+	query = scm.SearchQuery{Terms: "fix", Author: "octocat"}
+	if _, _, err := client.Search.Repositories(context.Background(), query); err == nil {
+		t.Errorf("Expected an error searching repositories by author")
+	}
+}
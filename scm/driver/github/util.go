@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -43,6 +44,18 @@ func encodeCommitListOptions(opts scm.CommitListOptions) string {
 	if opts.Sha != "" {
 		params.Set("sha", opts.Sha)
 	}
+	if opts.Path != "" {
+		params.Set("path", opts.Path)
+	}
+	if opts.Author != "" {
+		params.Set("author", opts.Author)
+	}
+	if !opts.Since.IsZero() {
+		params.Set("since", opts.Since.UTC().Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		params.Set("until", opts.Until.UTC().Format(time.RFC3339))
+	}
 	return params.Encode()
 }
 
@@ -89,6 +102,18 @@ func encodePullRequestListOptions(opts scm.PullRequestListOptions) string {
 	} else if opts.Closed {
 		params.Set("state", "closed")
 	}
+	if opts.Head != "" {
+		params.Set("head", opts.Head)
+	}
+	if opts.Base != "" {
+		params.Set("base", opts.Base)
+	}
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
+	if opts.Direction != "" {
+		params.Set("direction", opts.Direction)
+	}
 	return params.Encode()
 }
 
@@ -116,6 +141,9 @@ func encodePullRequestMergeOptions(opts *scm.PullRequestMergeOptions) *pullReque
 	if opts.CommitTitle != "" {
 		mr.CommitTitle = opts.CommitTitle
 	}
+	if opts.CommitMessage != "" {
+		mr.CommitMessage = opts.CommitMessage
+	}
 	if opts.SHA != "" {
 		mr.SHA = opts.SHA
 	}
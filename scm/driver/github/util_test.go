@@ -6,6 +6,7 @@ package github
 
 import (
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -35,6 +36,21 @@ func Test_encodeCommitListOptions(t *testing.T) {
 	}
 }
 
+func Test_encodeCommitListOptions_Filters(t *testing.T) {
+	opts := scm.CommitListOptions{
+		Sha:    "master",
+		Path:   "apps/frontend",
+		Author: "octocat",
+		Since:  time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Until:  time.Date(2021, time.February, 1, 12, 30, 0, 0, time.UTC),
+	}
+	want := "author=octocat&path=apps%2Ffrontend&sha=master&since=2021-01-01T00%3A00%3A00Z&until=2021-02-01T12%3A30%3A00Z"
+	got := encodeCommitListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded commit list options %q, got %q", want, got)
+	}
+}
+
 func Test_encodeIssueListOptions(t *testing.T) {
 	opts := scm.IssueListOptions{
 		Page:   10,
@@ -78,6 +94,22 @@ func Test_encodePullRequestListOptions(t *testing.T) {
 	}
 }
 
+func Test_encodePullRequestListOptions_Filters(t *testing.T) {
+	t.Parallel()
+	opts := scm.PullRequestListOptions{
+		Open:      true,
+		Head:      "octocat:feature",
+		Base:      "master",
+		Sort:      "updated",
+		Direction: "asc",
+	}
+	want := "base=master&direction=asc&head=octocat%3Afeature&sort=updated"
+	got := encodePullRequestListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded pr list options %q, got %q", want, got)
+	}
+}
+
 func Test_encodePullRequestListOptions_Closed(t *testing.T) {
 	t.Parallel()
 	opts := scm.PullRequestListOptions{
@@ -0,0 +1,23 @@
+package gitlab
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// unsupported lists the features of the services of the
+// client which return scm.ErrNotSupported.
+var unsupported = []scm.Feature{
+	"Organizations.AcceptOrganizationInvitation",
+	"Organizations.Create",
+	"Organizations.Delete",
+	"Organizations.ListMemberships",
+	"Organizations.ListPendingInvitations",
+	"Releases.Delete",
+	"Releases.Find",
+	"Releases.Update",
+	"Reviews.Delete",
+	"Reviews.Submit",
+	"Reviews.Update",
+	"Users.AcceptInvitation",
+	"Users.CreateToken",
+	"Users.DeleteToken",
+	"Users.ListInvitations",
+}
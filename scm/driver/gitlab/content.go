@@ -34,8 +34,9 @@ func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm
 		}
 	}
 	return &scm.Content{
-		Path: out.FilePath,
-		Data: raw,
+		Path:          out.FilePath,
+		Data:          raw,
+		LastCommitSha: out.LastCommitID,
 	}, res, err
 }
 
@@ -73,8 +74,32 @@ func (s *contentService) Update(ctx context.Context, repo, path string, params *
 	return s.client.do(ctx, "PUT", endpoint, &body, nil)
 }
 
-func (s *contentService) Delete(ctx context.Context, repo, path, ref string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+func (s *contentService) Delete(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
+	path = url.QueryEscape(path)
+	path = strings.Replace(path, ".", "%2E", -1)
+	endpoint := fmt.Sprintf("api/v4/projects/%s/repository/files/%s", encode(repo), path)
+
+	body := &deleteContentBody{
+		Message: params.Message,
+		Branch:  params.Branch,
+	}
+	return s.client.do(ctx, "DELETE", endpoint, &body, nil)
+}
+
+func (s *contentService) Blame(ctx context.Context, repo, path, ref string) ([]*scm.BlameRange, *scm.Response, error) {
+	path = url.QueryEscape(path)
+	path = strings.Replace(path, ".", "%2E", -1)
+	params := url.Values{}
+	params.Set("ref", ref)
+	endpoint := fmt.Sprintf("api/v4/projects/%s/repository/files/%s/blame?%s", encode(repo), path, params.Encode())
+	out := []*blame{}
+	res, err := s.client.do(ctx, "GET", endpoint, nil, &out)
+	return convertBlameList(out), res, err
+}
+
+type blame struct {
+	Commit commit   `json:"commit"`
+	Lines  []string `json:"lines"`
 }
 
 type content struct {
@@ -109,6 +134,11 @@ type updateContentBody struct {
 	Message string `json:"commit_message"`
 }
 
+type deleteContentBody struct {
+	Branch  string `json:"branch"`
+	Message string `json:"commit_message"`
+}
+
 type entry struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
@@ -136,3 +166,22 @@ func convertEntry(from *entry) *scm.FileEntry {
 		Type: t,
 	}
 }
+
+// convertBlameList converts the commits and lines reported by gitlab
+// into the line ranges which each commit last changed.
+func convertBlameList(from []*blame) []*scm.BlameRange {
+	to := []*scm.BlameRange{}
+	line := 1
+	for _, v := range from {
+		if len(v.Lines) == 0 {
+			continue
+		}
+		to = append(to, &scm.BlameRange{
+			StartLine: line,
+			EndLine:   line + len(v.Lines) - 1,
+			Commit:    *convertCommit(&v.Commit),
+		})
+		line += len(v.Lines)
+	}
+	return to
+}
@@ -176,10 +176,74 @@ func TestContentUpdate(t *testing.T) {
 }
 
 func TestContentDelete(t *testing.T) {
-	content := new(contentService)
-	_, err := content.Delete(context.Background(), "octocat/hello-world", "README", "master")
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Delete("api/v4/projects/octocat/hello-world/repository/files/README").
+		MatchType("json").
+		JSON(map[string]string{
+			"branch":         "master",
+			"commit_message": "Delete README",
+		}).
+		Reply(204).
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	res, err := client.Contents.Delete(context.Background(), "octocat/hello-world", "README", &scm.ContentParams{Branch: "master", Message: "Delete README"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestContentBlame(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/files/app/models/key.rb/blame").
+		MatchParam("ref", "master").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/content_blame.json")
+
+	client := NewDefault()
+	got, res, err := client.Contents.Blame(context.Background(), "diaspora/diaspora", "app/models/key.rb", "master")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.BlameRange{}
+	raw, _ := ioutil.ReadFile("testdata/content_blame.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestContentBlame_EscapedRef(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/files/app/models/key.rb/blame").
+		MatchParam("ref", `^release/1\.0\+hotfix$`).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/content_blame.json")
+
+	client := NewDefault()
+	_, _, err := client.Contents.Blame(context.Background(), "diaspora/diaspora", "app/models/key.rb", "release/1.0+hotfix")
+	if err != nil {
+		t.Error(err)
 	}
 }
 
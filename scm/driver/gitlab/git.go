@@ -64,7 +64,12 @@ func (s *gitService) CreateRef(ctx context.Context, repo, ref, sha string) (*scm
 }
 
 func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	ref = strings.TrimPrefix(ref, "refs/")
+	path := fmt.Sprintf("api/v4/projects/%s/repository/branches/%s", encode(repo), encode(strings.TrimPrefix(ref, "heads/")))
+	if strings.HasPrefix(ref, "tags/") {
+		path = fmt.Sprintf("api/v4/projects/%s/repository/tags/%s", encode(repo), encode(strings.TrimPrefix(ref, "tags/")))
+	}
+	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
 func (s *gitService) FindBranch(ctx context.Context, repo, name string) (*scm.Reference, *scm.Response, error) {
@@ -117,16 +122,54 @@ func (s *gitService) ListChanges(ctx context.Context, repo, ref string, opts scm
 }
 
 func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
-	opts.From = encode(ref1)
-	opts.To = encode(ref2)
+	out, res, err := s.Compare(ctx, repo, ref1, ref2, opts)
+	if err != nil {
+		return nil, res, err
+	}
+	return out.Changes, res, nil
+}
+
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, opts scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
+	ahead, res, err := s.compare(ctx, repo, base, head, opts)
+	if err != nil {
+		return nil, res, err
+	}
+	// gitlab only reports the commits of the head so lets compare
+	// the other way around to find the commits of the base
+	behind, res, err := s.compare(ctx, repo, head, base, scm.ListOptions{})
+	if err != nil {
+		return nil, res, err
+	}
+	params := url.Values{}
+	params.Add("refs[]", base)
+	params.Add("refs[]", head)
+	path := fmt.Sprintf("api/v4/projects/%s/repository/merge_base?%s", encode(repo), params.Encode())
+	mergeBase := new(commit)
+	res, err = s.client.do(ctx, "GET", path, nil, mergeBase)
+	if err != nil {
+		return nil, res, err
+	}
+	return &scm.Comparison{
+		MergeBase: mergeBase.ID,
+		Ahead:     len(ahead.Commits),
+		Behind:    len(behind.Commits),
+		Commits:   convertCommitList(ahead.Commits),
+		Changes:   convertChangeList(ahead.Diffs),
+	}, res, nil
+}
+
+func (s *gitService) compare(ctx context.Context, repo, from, to string, opts scm.ListOptions) (*compare, *scm.Response, error) {
+	opts.From = from
+	opts.To = to
 	path := fmt.Sprintf("api/v4/projects/%s/repository/compare?%s", encode(repo), encodeListOptions(opts))
-	out := compare{}
-	res, err := s.client.do(ctx, "GET", path, nil, &out)
-	return convertChangeList(out.Diffs), res, err
+	out := new(compare)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out, res, err
 }
 
 type compare struct {
-	Diffs []*change `json:"diffs"`
+	Commits []*commit `json:"commits"`
+	Diffs   []*change `json:"diffs"`
 }
 
 type branch struct {
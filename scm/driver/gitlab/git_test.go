@@ -256,6 +256,8 @@ func TestGitCompareCommits(t *testing.T) {
 		SetHeaders(mockHeaders).
 		File("testdata/compare.json")
 
+	mockCompareBehind()
+
 	client := NewDefault()
 	got, res, err := client.Git.CompareCommits(context.Background(), "gitterHQ/webapp", "6da006adb7cafe15b8495e3b7811fc318e485553", "c5895070235cadd2d839136dad79e01838ee2de1", scm.ListOptions{})
 	if err != nil {
@@ -276,6 +278,60 @@ func TestGitCompareCommits(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestGitCompare(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/gitterHQ/webapp/repository/compare").
+		MatchParam("from", "6da006adb7cafe15b8495e3b7811fc318e485553").
+		MatchParam("to", "c5895070235cadd2d839136dad79e01838ee2de1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/compare.json")
+
+	mockCompareBehind()
+
+	client := NewDefault()
+	got, res, err := client.Git.Compare(context.Background(), "gitterHQ/webapp", "6da006adb7cafe15b8495e3b7811fc318e485553", "c5895070235cadd2d839136dad79e01838ee2de1", scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := ioutil.ReadFile("testdata/compare_full.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+// mockCompareBehind mocks the reverse comparison and the merge base
+// requested when comparing the commits of the gitterHQ/webapp project
+func mockCompareBehind() {
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/gitterHQ/webapp/repository/compare").
+		MatchParam("from", "c5895070235cadd2d839136dad79e01838ee2de1").
+		MatchParam("to", "6da006adb7cafe15b8495e3b7811fc318e485553").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/compare_behind.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/gitterHQ/webapp/repository/merge_base").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_base.json")
+}
+
 func TestGitCreateRef(t *testing.T) {
 	baseSHA := "aa218f56b14c9653891f9e74264a383fa43fefbd"
 	defer gock.Off()
@@ -306,3 +362,39 @@ func TestGitCreateRef(t *testing.T) {
 	t.Run("Request", testRequest(res))
 	t.Run("Rate", testRate(res))
 }
+
+func TestGitDeleteRef(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Delete("/api/v4/projects/diaspora/diaspora/repository/branches/feature/testing").
+		Reply(http.StatusNoContent).
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	res, err := client.Git.DeleteRef(context.Background(), "diaspora/diaspora", "refs/heads/feature/testing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestGitDeleteRef_Tag(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Delete("/api/v4/projects/diaspora/diaspora/repository/tags/v1.0.0").
+		Reply(http.StatusNoContent).
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	res, err := client.Git.DeleteRef(context.Background(), "diaspora/diaspora", "tags/v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
@@ -48,6 +48,7 @@ func New(uri string) (*scm.Client, error) {
 	client.PullRequests = &pullService{client}
 	client.Repositories = &repositoryService{client}
 	client.Reviews = &reviewService{client}
+	client.Search = &searchService{client}
 	client.Commits = &commitService{client}
 
 	//add the user service to the webhook service so it can be used for fetching users
@@ -57,6 +58,7 @@ func New(uri string) (*scm.Client, error) {
 		client:      client,
 		userService: us,
 	}
+	client.Unsupported = unsupported
 
 	graphqlEndpoint := scm.URLJoin(uri, "/api/graphql")
 	client.GraphQLURL, err = url.Parse(graphqlEndpoint)
@@ -261,15 +261,16 @@ type updateIssueOptions struct {
 }
 
 type issue struct {
-	ID     int      `json:"id"`
-	Number int      `json:"iid"`
-	State  string   `json:"state"`
-	Title  string   `json:"title"`
-	Desc   string   `json:"description"`
-	Link   string   `json:"web_url"`
-	Locked bool     `json:"discussion_locked"`
-	Labels []string `json:"labels"`
-	Author struct {
+	ID        int      `json:"id"`
+	Number    int      `json:"iid"`
+	ProjectID int      `json:"project_id"`
+	State     string   `json:"state"`
+	Title     string   `json:"title"`
+	Desc      string   `json:"description"`
+	Link      string   `json:"web_url"`
+	Locked    bool     `json:"discussion_locked"`
+	Labels    []string `json:"labels"`
+	Author    struct {
 		Name     string      `json:"name"`
 		Username string      `json:"username"`
 		Avatar   null.String `json:"avatar_url"`
@@ -99,11 +99,13 @@ func (s *milestoneService) List(ctx context.Context, repo string, opts scm.Miles
 
 func (s *milestoneService) Create(ctx context.Context, repo string, input *scm.MilestoneInput) (*scm.Milestone, *scm.Response, error) {
 	path := fmt.Sprintf("api/v4/projects/%s/milestones", encode(repo))
-	dueDateIso := isoTime(*input.DueDate)
 	in := &milestoneInput{
 		Title:       &input.Title,
 		Description: &input.Description,
-		DueDate:     &dueDateIso,
+	}
+	if input.DueDate != nil {
+		dueDateIso := isoTime(*input.DueDate)
+		in.DueDate = &dueDateIso
 	}
 	out := new(milestone)
 	res, err := s.client.do(ctx, "POST", path, in, out)
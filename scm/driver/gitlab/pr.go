@@ -7,13 +7,16 @@ package gitlab
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mitchellh/copystructure"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/draft"
 )
 
 type pullService struct {
@@ -34,6 +37,62 @@ func (s *pullService) Find(ctx context.Context, repo string, number int) (*scm.P
 	return convRepo, res, nil
 }
 
+func (s *pullService) FindMergeability(ctx context.Context, repo string, number int) (*scm.Mergeability, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d", encode(repo), number)
+	out := new(pr)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	from, convRes, err := s.convertPullRequest(ctx, out)
+	if err != nil {
+		return nil, convRes, err
+	}
+	// the approvals are fetched once to provide both the reviews
+	// and the approval rules of the merge request
+	path = fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/approvals", encode(repo), number)
+	approvals := new(mergeRequestApprovals)
+	approvalsRes, err := s.client.do(ctx, "GET", path, nil, approvals)
+	if err != nil && (approvalsRes == nil || approvalsRes.Status != http.StatusNotFound) {
+		return nil, approvalsRes, err
+	}
+	hasApprovals := err == nil
+
+	var statuses []*scm.Status
+	sha := from.Head.Sha
+	if sha == "" {
+		sha = from.Sha
+	}
+	if sha != "" {
+		combined, statusRes, err := s.client.Repositories.FindCombinedStatus(ctx, repo, sha)
+		if err != nil {
+			return nil, statusRes, err
+		}
+		statuses = combined.Statuses
+	}
+
+	m := scm.EvaluateMergeability(from, statuses, convertApprovals(approvals), nil)
+	if hasApprovals {
+		m.RequiredApprovals = approvals.ApprovalsRequired
+		m.Approvals = len(approvals.ApprovedBy)
+		if approvals.ApprovalsLeft > 0 {
+			m.Block(scm.MergeBlockerInsufficientApprovals)
+		}
+	}
+
+	if out.HasConflicts {
+		m.Block(scm.MergeBlockerConflicts)
+	}
+	m.State = out.DetailedMergeStatus
+	if m.State == "" {
+		m.State = out.MergeStatus
+	}
+	if blocker := convertMergeStatus(m.State); blocker != "" {
+		m.Block(blocker)
+	}
+	return m, res, nil
+}
+
 func (s *pullService) FindComment(ctx context.Context, repo string, index, id int) (*scm.Comment, *scm.Response, error) {
 	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/notes/%d", encode(repo), index, id)
 	out := new(issueComment)
@@ -62,6 +121,13 @@ func (s *pullService) ListChanges(ctx context.Context, repo string, number int,
 	return convertChangeList(out.Changes), res, err
 }
 
+func (s *pullService) ListCommits(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Commit, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/commits?%s", encode(repo), number, encodeListOptions(opts))
+	out := []*commit{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertCommitList(out), res, err
+}
+
 func (s *pullService) ListComments(ctx context.Context, repo string, index int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
 	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/notes?%s", encode(repo), index, encodeListOptions(opts))
 	out := []*issueComment{}
@@ -130,6 +196,56 @@ func (s *pullService) Merge(ctx context.Context, repo string, number int, option
 	return res, err
 }
 
+func (s *pullService) EnableAutoMerge(ctx context.Context, repo string, number int, options *scm.PullRequestMergeOptions) (*scm.Response, error) {
+	in := scm.PullRequestMergeOptions{}
+	if options != nil {
+		in = *options
+	}
+	in.MergeWhenPipelineSucceeds = true
+	return s.Merge(ctx, repo, number, &in)
+}
+
+func (s *pullService) DisableAutoMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/cancel_merge_when_pipeline_succeeds", encode(repo), number)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
+func (s *pullService) UpdateBranch(ctx context.Context, repo string, number int, method string) (*scm.Response, error) {
+	// gitlab can only rebase the source branch onto the target branch, its
+	// rebase api has no option to merge the target branch instead
+	if method != "" && method != "rebase" {
+		return nil, scm.ErrNotSupported
+	}
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/rebase", encode(repo), number)
+	return s.client.do(ctx, "PUT", path, nil, nil)
+}
+
+func (s *pullService) MarkReady(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(ctx, repo, number, false)
+}
+
+func (s *pullService) ConvertToDraft(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(ctx, repo, number, true)
+}
+
+// setDraft adds or removes the draft prefix of the merge request title
+func (s *pullService) setDraft(ctx context.Context, repo string, number int, draft bool) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d", encode(repo), number)
+	out := new(pr)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return res, err
+	}
+	title := draftPrefixes.Trim(out.Title)
+	if draft {
+		title = "Draft: " + title
+	}
+	if title == out.Title {
+		return res, nil
+	}
+	return s.client.do(ctx, "PUT", path, &updateMergeRequestOptions{Title: &title}, nil)
+}
+
 func (s *pullService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
 	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d?state_event=closed", encode(repo), number)
 	res, err := s.client.do(ctx, "PUT", path, nil, nil)
@@ -212,10 +328,35 @@ func (s *pullService) UnrequestReview(ctx context.Context, repo string, number i
 func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
 	path := fmt.Sprintf("api/v4/projects/%s/merge_requests", encode(repo))
 	in := &prInput{
-		Title:        input.Title,
-		SourceBranch: input.Head,
-		TargetBranch: input.Base,
-		Description:  input.Body,
+		Title:              input.Title,
+		SourceBranch:       input.Head,
+		TargetBranch:       input.Base,
+		Description:        input.Body,
+		Labels:             strings.Join(input.Labels, ","),
+		MilestoneID:        input.Milestone,
+		AllowCollaboration: input.MaintainerCanModify,
+	}
+	if input.Draft && !draftPrefixes.Match(in.Title) {
+		in.Title = "Draft: " + in.Title
+	}
+	// merge requests from a fork are created in the source project
+	if input.HeadRepo != "" && input.HeadRepo != repo {
+		target, res, err := s.client.Repositories.Find(ctx, repo)
+		if err != nil {
+			return nil, res, err
+		}
+		in.TargetProjectID, err = strconv.Atoi(target.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		path = fmt.Sprintf("api/v4/projects/%s/merge_requests", encode(input.HeadRepo))
+	}
+	var err error
+	if in.AssigneeIDs, err = s.findUserIDs(ctx, input.Assignees); err != nil {
+		return nil, nil, err
+	}
+	if in.ReviewerIDs, err = s.findUserIDs(ctx, input.Reviewers); err != nil {
+		return nil, nil, err
 	}
 
 	out := new(pr)
@@ -230,6 +371,22 @@ func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRe
 	return convRepo, res, nil
 }
 
+// findUserIDs returns the ids of the users with the given logins
+func (s *pullService) findUserIDs(ctx context.Context, logins []string) ([]int, error) {
+	var ids []int
+	for _, l := range logins {
+		u, _, err := s.client.Users.FindLogin(ctx, l)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, u.ID)
+	}
+	return ids, nil
+}
+
+// draftPrefixes are the title prefixes which mark a merge request as a draft
+var draftPrefixes = draft.Prefixes{"draft:", "[draft]", "(draft)", "wip:", "[wip]"}
+
 func (s *pullService) Update(ctx context.Context, repo string, number int, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
 	updateOpts := &updateMergeRequestOptions{}
 	if input.Title != "" {
@@ -302,21 +459,38 @@ type pr struct {
 	Labels          []*string `json:"labels"`
 	Link            string    `json:"web_url"`
 	WIP             bool      `json:"work_in_progress"`
+	Draft           bool      `json:"draft"`
 	Author          user      `json:"author"`
 	MergeStatus     string    `json:"merge_status"`
-	SourceBranch    string    `json:"source_branch"`
-	TargetBranch    string    `json:"target_branch"`
-	Created         time.Time `json:"created_at"`
-	Updated         time.Time `json:"updated_at"`
-	Closed          time.Time
-	DiffRefs        struct {
-		BaseSHA string `json:"base_sha"`
-		HeadSHA string `json:"head_sha"`
+	// DetailedMergeStatus is only available on GitLab 15.6 or later
+	DetailedMergeStatus string `json:"detailed_merge_status"`
+	HasConflicts        bool   `json:"has_conflicts"`
+	// MergeWhenPipelineSucceeds is set by the user, MergeUser, who enabled it
+	MergeWhenPipelineSucceeds bool      `json:"merge_when_pipeline_succeeds"`
+	MergeUser                 *user     `json:"merge_user"`
+	Squash                    bool      `json:"squash"`
+	SourceBranch              string    `json:"source_branch"`
+	TargetBranch              string    `json:"target_branch"`
+	Created                   time.Time `json:"created_at"`
+	Updated                   time.Time `json:"updated_at"`
+	Closed                    time.Time
+	DiffRefs                  struct {
+		BaseSHA  string `json:"base_sha"`
+		HeadSHA  string `json:"head_sha"`
+		StartSHA string `json:"start_sha"`
 	} `json:"diff_refs"`
 	Assignee  *user   `json:"assignee"`
 	Assignees []*user `json:"assignees"`
 }
 
+type mergeRequestApprovals struct {
+	ApprovalsRequired int `json:"approvals_required"`
+	ApprovalsLeft     int `json:"approvals_left"`
+	ApprovedBy        []struct {
+		User user `json:"user"`
+	} `json:"approved_by"`
+}
+
 type changes struct {
 	Changes []*change
 }
@@ -331,10 +505,16 @@ type change struct {
 }
 
 type prInput struct {
-	Title        string `json:"title"`
-	Description  string `json:"description"`
-	SourceBranch string `json:"source_branch"`
-	TargetBranch string `json:"target_branch"`
+	Title              string `json:"title"`
+	Description        string `json:"description"`
+	SourceBranch       string `json:"source_branch"`
+	TargetBranch       string `json:"target_branch"`
+	TargetProjectID    int    `json:"target_project_id,omitempty"`
+	Labels             string `json:"labels,omitempty"`
+	AssigneeIDs        []int  `json:"assignee_ids,omitempty"`
+	ReviewerIDs        []int  `json:"reviewer_ids,omitempty"`
+	MilestoneID        int    `json:"milestone_id,omitempty"`
+	AllowCollaboration bool   `json:"allow_collaboration,omitempty"`
 }
 
 type pullRequestMergeRequest struct {
@@ -406,7 +586,7 @@ func (s *pullService) convertPullRequest(ctx context.Context, from *pr) (*scm.Pu
 		Source:         from.SourceBranch,
 		Target:         from.TargetBranch,
 		Link:           from.Link,
-		Draft:          from.WIP,
+		Draft:          from.Draft || from.WIP,
 		Closed:         from.State != "opened",
 		Merged:         from.State == "merged",
 		Mergeable:      scm.ToMergeableState(from.MergeStatus) == scm.MergeableStateMergeable,
@@ -423,12 +603,29 @@ func (s *pullService) convertPullRequest(ctx context.Context, from *pr) (*scm.Pu
 			Sha:  from.DiffRefs.BaseSHA,
 			Repo: *baseRepo,
 		},
-		Created: from.Created,
-		Updated: from.Updated,
-		Fork:    sourceRepo.PathNamespace,
+		Created:   from.Created,
+		Updated:   from.Updated,
+		Fork:      sourceRepo.PathNamespace,
+		AutoMerge: convertAutoMerge(from),
 	}, nil, nil
 }
 
+// convertAutoMerge returns how the merge request will be merged once
+// the pipeline succeeds, if it will be
+func convertAutoMerge(from *pr) *scm.AutoMerge {
+	if !from.MergeWhenPipelineSucceeds {
+		return nil
+	}
+	to := &scm.AutoMerge{MergeMethod: "merge"}
+	if from.Squash {
+		to.MergeMethod = "squash"
+	}
+	if from.MergeUser != nil {
+		to.EnabledBy = *convertUser(from.MergeUser)
+	}
+	return to
+}
+
 func (s *pullService) getSourceFork(ctx context.Context, from *pr) (repository, error) {
 	path := fmt.Sprintf("api/v4/projects/%d", from.SourceProjectID)
 	sourceRepo := repository{}
@@ -439,6 +636,35 @@ func (s *pullService) getSourceFork(ctx context.Context, from *pr) (repository,
 	return sourceRepo, nil
 }
 
+// convertMergeStatus returns the blocker of the merge_status or
+// detailed_merge_status of a merge request, if any
+func convertMergeStatus(status string) scm.MergeBlocker {
+	switch status {
+	case "", "mergeable", "can_be_merged":
+		return ""
+	case "not_open":
+		return scm.MergeBlockerClosed
+	case "draft_status":
+		return scm.MergeBlockerDraft
+	case "broken_status", "conflict", "cannot_be_merged":
+		return scm.MergeBlockerConflicts
+	case "ci_must_pass":
+		return scm.MergeBlockerFailingChecks
+	case "ci_still_running":
+		return scm.MergeBlockerPendingChecks
+	case "not_approved":
+		return scm.MergeBlockerInsufficientApprovals
+	case "need_rebase":
+		return scm.MergeBlockerBehindBase
+	case "discussions_not_resolved":
+		return scm.MergeBlockerUnresolvedDiscussions
+	case "checking", "unchecked", "cannot_be_merged_recheck", "preparing", "approvals_syncing":
+		return scm.MergeBlockerUnknown
+	default:
+		return scm.MergeBlockerBlocked
+	}
+}
+
 func convertPullRequestLabels(from []*string) []*scm.Label {
 	var labels []*scm.Label
 	for _, label := range from {
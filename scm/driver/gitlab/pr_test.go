@@ -65,6 +65,94 @@ func TestPullFind(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestPullFindMergeability(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/32732").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/repo.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/32732").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/repo.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1347/approvals").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_approvals.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/commits/12d65c8dd2b2676fa3ac47d955accc085a37a9c1/statuses").
+		MatchParam("page", "1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeadersNoPagination).
+		File("testdata/statuses.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.FindMergeability(context.Background(), "diaspora/diaspora", 1347)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Mergeability)
+	raw, err := ioutil.ReadFile("testdata/merge_mergeability.json.golden")
+	if err != nil {
+		t.Fatalf("ioutil.ReadFile: %v", err)
+	}
+	if err := json.Unmarshal(raw, want); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestConvertMergeStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   scm.MergeBlocker
+	}{
+		{"mergeable", ""},
+		{"can_be_merged", ""},
+		{"cannot_be_merged", scm.MergeBlockerConflicts},
+		{"conflict", scm.MergeBlockerConflicts},
+		{"not_approved", scm.MergeBlockerInsufficientApprovals},
+		{"ci_must_pass", scm.MergeBlockerFailingChecks},
+		{"ci_still_running", scm.MergeBlockerPendingChecks},
+		{"draft_status", scm.MergeBlockerDraft},
+		{"need_rebase", scm.MergeBlockerBehindBase},
+		{"discussions_not_resolved", scm.MergeBlockerUnresolvedDiscussions},
+		{"not_open", scm.MergeBlockerClosed},
+		{"checking", scm.MergeBlockerUnknown},
+		{"blocked_status", scm.MergeBlockerBlocked},
+	}
+	for _, test := range tests {
+		if got := convertMergeStatus(test.status); got != test.want {
+			t.Errorf("Want blocker %q for status %q, got %q", test.want, test.status, got)
+		}
+	}
+}
+
 func TestPullList(t *testing.T) {
 	defer gock.Off()
 
@@ -158,6 +246,40 @@ func TestPullListChanges(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestPullListCommits(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1347/commits").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/pr_commits.json")
+
+	client := NewDefault()
+	got, res, err := client.PullRequests.ListCommits(context.Background(), "diaspora/diaspora", 1347, scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Commit{}
+	raw, _ := ioutil.ReadFile("testdata/pr_commits.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+	t.Run("Page", testPage(res))
+}
+
 func TestPullMerge(t *testing.T) {
 	defer gock.Off()
 
@@ -178,6 +300,177 @@ func TestPullMerge(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestPullEnableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1347/merge").
+		JSON(map[string]interface{}{
+			"merge_when_pipeline_succeeds": "true",
+			"squash":                       "true",
+			"squash_commit_message":        "Add the new feature\n\nIncludes the new feature",
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	options := &scm.PullRequestMergeOptions{
+		MergeMethod:   "squash",
+		CommitTitle:   "Add the new feature",
+		CommitMessage: "Includes the new feature",
+	}
+	res, err := client.PullRequests.EnableAutoMerge(context.Background(), "diaspora/diaspora", 1347, options)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if options.MergeWhenPipelineSucceeds {
+		t.Errorf("Expected the merge options to be left unchanged")
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestPullDisableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1347/cancel_merge_when_pipeline_succeeds").
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	res, err := client.PullRequests.DisableAutoMerge(context.Background(), "diaspora/diaspora", 1347)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestConvertAutoMerge(t *testing.T) {
+	if got := convertAutoMerge(&pr{}); got != nil {
+		t.Errorf("Want no auto merge, got %+v", got)
+	}
+
+	from := &pr{
+		MergeWhenPipelineSucceeds: true,
+		Squash:                    true,
+		MergeUser:                 &user{ID: 1, Username: "root"},
+	}
+	want := &scm.AutoMerge{
+		EnabledBy:   scm.User{ID: 1, Login: "root"},
+		MergeMethod: "squash",
+	}
+	if diff := cmp.Diff(convertAutoMerge(from), want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullUpdateBranch(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1347/rebase").
+		Reply(202).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"rebase_in_progress": true}`)
+
+	client := NewDefault()
+	res, err := client.PullRequests.UpdateBranch(context.Background(), "diaspora/diaspora", 1347, "rebase")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+
+	if _, err := client.PullRequests.UpdateBranch(context.Background(), "diaspora/diaspora", 1347, "merge"); err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error merging the target branch, got %v", err)
+	}
+}
+
+func TestPullMarkReady(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"iid": 1347, "title": "Draft: [WIP] JS fix", "draft": true}`)
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1347").
+		JSON(map[string]string{"title": "JS fix"}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"iid": 1347, "title": "JS fix", "draft": false}`)
+
+	client := NewDefault()
+	_, err := client.PullRequests.MarkReady(context.Background(), "diaspora/diaspora", 1347)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the merge request title to be updated")
+	}
+}
+
+func TestPullConvertToDraft(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"iid": 1347, "title": "JS fix", "draft": false}`)
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1347").
+		JSON(map[string]string{"title": "Draft: JS fix"}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"iid": 1347, "title": "Draft: JS fix", "draft": true}`)
+
+	client := NewDefault()
+	_, err := client.PullRequests.ConvertToDraft(context.Background(), "diaspora/diaspora", 1347)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the merge request title to be updated")
+	}
+}
+
+func TestTrimDraftTitle(t *testing.T) {
+	tests := map[string]string{
+		"JS fix":             "JS fix",
+		"Draft: JS fix":      "JS fix",
+		"[Draft] JS fix":     "JS fix",
+		"(draft) JS fix":     "JS fix",
+		"WIP: JS fix":        "JS fix",
+		"Draft: [WIP] fix":   "fix",
+		"Drafting the notes": "Drafting the notes",
+	}
+	for title, want := range tests {
+		if got := draftPrefixes.Trim(title); got != want {
+			t.Errorf("Want title %q for %q, got %q", want, title, got)
+		}
+	}
+}
+
 func TestPullClose(t *testing.T) {
 	defer gock.Off()
 
@@ -431,6 +724,75 @@ func TestPullCreate(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestPullCreate_Extended(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/users").
+		MatchParam("search", "john_smith").
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeadersNoPagination).
+		File("testdata/user_search.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/32732").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/repo.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/2").
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/other_repo.json")
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests").
+		JSON(map[string]interface{}{
+			"title":               "Draft: Amazing new feature",
+			"description":         "Please pull these awesome changes in!",
+			"source_branch":       "test1",
+			"target_branch":       "master",
+			"labels":              "bug,ui",
+			"assignee_ids":        []int{1},
+			"reviewer_ids":        []int{1},
+			"milestone_id":        3,
+			"allow_collaboration": true,
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_create.json")
+
+	input := &scm.PullRequestInput{
+		Title:               "Amazing new feature",
+		Body:                "Please pull these awesome changes in!",
+		Head:                "test1",
+		Base:                "master",
+		Draft:               true,
+		MaintainerCanModify: true,
+		Labels:              []string{"bug", "ui"},
+		Assignees:           []string{"john_smith"},
+		Reviewers:           []string{"john_smith"},
+		Milestone:           3,
+	}
+
+	client := NewDefault()
+	_, _, err := client.PullRequests.Create(context.Background(), "diaspora/diaspora", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks %v", gock.Pending())
+	}
+}
+
 func TestPullUpdate(t *testing.T) {
 	defer gock.Off()
 
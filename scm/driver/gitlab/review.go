@@ -6,6 +6,10 @@ package gitlab
 
 import (
 	"context"
+	"crypto/sha1" // #nosec
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -14,24 +18,136 @@ type reviewService struct {
 	client *wrapper
 }
 
+// Merge requests have no reviews so the review of a user is made up of
+// their approval and their discussions, identified by the id of the user.
+
 func (s *reviewService) Find(ctx context.Context, repo string, number, id int) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	reviews, res, err := s.List(ctx, repo, number, scm.ListOptions{})
+	if err != nil {
+		return nil, res, err
+	}
+	for _, review := range reviews {
+		if review.ID == id {
+			return review, res, nil
+		}
+	}
+	return nil, res, scm.ErrNotFound
 }
 
+// List returns the approvals of the merge request as reviews. The
+// discussions of a user are not reported as GitLab has no review
+// which groups them.
 func (s *reviewService) List(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/approvals", encode(repo), number)
+	out := new(mergeRequestApprovals)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertApprovals(out), res, err
 }
 
+// Create approves the merge request for the APPROVE event before the
+// comments of the review are opened as separate discussions on the diff.
+// Other events open a discussion with the body of the review, which is
+// required as GitLab has no review state to record without it.
 func (s *reviewService) Create(ctx context.Context, repo string, number int, input *scm.ReviewInput) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	state := scm.ReviewStateCommented
+	switch input.Event {
+	case "APPROVE":
+		state = scm.ReviewStateApproved
+	case "REQUEST_CHANGES":
+		state = scm.ReviewStateChangesRequested
+	case "", "COMMENT":
+	default:
+		return nil, nil, scm.ErrNotSupported
+	}
+	if state != scm.ReviewStateApproved && input.Body == "" {
+		return nil, nil, errors.New("a review which does not approve requires a body")
+	}
+
+	if state == scm.ReviewStateApproved {
+		path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/approve", encode(repo), number)
+		in := &approveInput{Sha: input.Sha}
+		if res, err := s.client.do(ctx, "POST", path, in, new(mergeRequestApprovals)); err != nil {
+			return nil, res, err
+		}
+	}
+	for _, comment := range input.Comments {
+		if _, res, err := s.CreateComment(ctx, repo, number, comment); err != nil {
+			return nil, res, err
+		}
+	}
+	if state == scm.ReviewStateApproved {
+		return s.approved(ctx, repo, number, input)
+	}
+
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/discussions", encode(repo), number)
+	in := &discussionInput{Body: input.Body}
+	out := new(discussion)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if len(out.Notes) == 0 {
+		return nil, res, scm.ErrNotFound
+	}
+	note := out.Notes[0]
+	return &scm.Review{
+		ID:      note.Author.ID,
+		Body:    note.Body,
+		Sha:     input.Sha,
+		State:   state,
+		Author:  *convertUser(&note.Author),
+		Created: note.CreatedAt,
+		Updated: note.UpdatedAt,
+	}, res, nil
+}
+
+// approved comments the body of the approval and returns it as the
+// review of the current user
+func (s *reviewService) approved(ctx context.Context, repo string, number int, input *scm.ReviewInput) (*scm.Review, *scm.Response, error) {
+	if input.Body != "" {
+		if _, res, err := s.client.PullRequests.CreateComment(ctx, repo, number, &scm.CommentInput{Body: input.Body}); err != nil {
+			return nil, res, err
+		}
+	}
+	author, res, err := s.client.Users.Find(ctx)
+	if err != nil {
+		return nil, res, err
+	}
+	return &scm.Review{
+		ID:     author.ID,
+		Body:   input.Body,
+		Sha:    input.Sha,
+		State:  scm.ReviewStateApproved,
+		Author: *author,
+	}, res, nil
 }
 
 func (s *reviewService) Delete(ctx context.Context, repo string, number, id int) (*scm.Response, error) {
 	return nil, scm.ErrNotSupported
 }
 
+// ListComments returns the comments on the diff made by the user of the
+// review, or by anyone if the review id is zero
 func (s *reviewService) ListComments(ctx context.Context, repo string, prID int, reviewID int, options scm.ListOptions) ([]*scm.ReviewComment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/discussions?%s", encode(repo), prID, encodeListOptions(options))
+	out := []*discussion{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+	comments := []*scm.ReviewComment{}
+	for _, d := range out {
+		for _, note := range d.Notes {
+			if note.Position == nil || note.System {
+				continue
+			}
+			if reviewID != 0 && note.Author.ID != reviewID {
+				continue
+			}
+			comments = append(comments, convertDiscussionNote(note, d.ID))
+		}
+	}
+	return comments, res, nil
 }
 
 func (s *reviewService) Update(ctx context.Context, repo string, prID int, reviewID int, body string) (*scm.Review, *scm.Response, error) {
@@ -42,6 +158,257 @@ func (s *reviewService) Submit(ctx context.Context, repo string, prID int, revie
 	return nil, nil, scm.ErrNotSupported
 }
 
+// Dismiss revokes the approval of the review which GitLab only allows
+// for the approval of the authenticated user
 func (s *reviewService) Dismiss(ctx context.Context, repo string, prID int, reviewID int, msg string) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	user, res, err := s.client.Users.Find(ctx)
+	if err != nil {
+		return nil, res, err
+	}
+	// the review ID is the ID of the approving user
+	if user.ID != reviewID {
+		return nil, nil, scm.ErrNotSupported
+	}
+	review, res, err := s.Find(ctx, repo, prID, reviewID)
+	if err != nil {
+		return nil, res, err
+	}
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/unapprove", encode(repo), prID)
+	res, err = s.client.do(ctx, "POST", path, nil, nil)
+	if err != nil {
+		return nil, res, err
+	}
+	review.State = scm.ReviewStateDismissed
+	return review, res, nil
+}
+
+func (s *reviewService) CreateComment(ctx context.Context, repo string, number int, input *scm.ReviewCommentInput) (*scm.ReviewComment, *scm.Response, error) {
+	if input.ThreadID != "" {
+		path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/discussions/%s/notes", encode(repo), number, input.ThreadID)
+		in := &issueCommentInput{Body: input.Body}
+		out := new(discussionNote)
+		res, err := s.client.do(ctx, "POST", path, in, out)
+		if err != nil {
+			return nil, res, err
+		}
+		return convertDiscussionNote(out, input.ThreadID), res, nil
+	}
+
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d", encode(repo), number)
+	mr := new(pr)
+	res, err := s.client.do(ctx, "GET", path, nil, mr)
+	if err != nil {
+		return nil, res, err
+	}
+	in := &discussionInput{
+		Body:     input.Body,
+		Position: toDiscussionPosition(input, mr),
+	}
+	path = fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/discussions", encode(repo), number)
+	out := new(discussion)
+	res, err = s.client.do(ctx, "POST", path, in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if len(out.Notes) == 0 {
+		return nil, res, scm.ErrNotFound
+	}
+	return convertDiscussionNote(out.Notes[0], out.ID), res, nil
+}
+
+func (s *reviewService) ListThreads(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.ReviewThread, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/discussions?%s", encode(repo), number, encodeListOptions(opts))
+	out := []*discussion{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertDiscussionList(out), res, err
+}
+
+func (s *reviewService) ResolveThread(ctx context.Context, repo string, number int, threadID string) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/discussions/%s?resolved=true", encode(repo), number, threadID)
+	return s.client.do(ctx, "PUT", path, nil, nil)
+}
+
+func (s *reviewService) UnresolveThread(ctx context.Context, repo string, number int, threadID string) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/discussions/%s?resolved=false", encode(repo), number, threadID)
+	return s.client.do(ctx, "PUT", path, nil, nil)
+}
+
+type approveInput struct {
+	Sha string `json:"sha,omitempty"`
+}
+
+type discussion struct {
+	ID             string            `json:"id"`
+	IndividualNote bool              `json:"individual_note"`
+	Notes          []*discussionNote `json:"notes"`
+}
+
+type discussionNote struct {
+	ID         int                 `json:"id"`
+	Type       string              `json:"type"`
+	Body       string              `json:"body"`
+	Author     user                `json:"author"`
+	System     bool                `json:"system"`
+	Resolvable bool                `json:"resolvable"`
+	Resolved   bool                `json:"resolved"`
+	Position   *discussionPosition `json:"position"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+type discussionInput struct {
+	Body     string              `json:"body"`
+	Position *discussionPosition `json:"position,omitempty"`
+}
+
+type discussionPosition struct {
+	BaseSHA      string               `json:"base_sha"`
+	StartSHA     string               `json:"start_sha"`
+	HeadSHA      string               `json:"head_sha"`
+	PositionType string               `json:"position_type"`
+	OldPath      string               `json:"old_path,omitempty"`
+	NewPath      string               `json:"new_path,omitempty"`
+	OldLine      int                  `json:"old_line,omitempty"`
+	NewLine      int                  `json:"new_line,omitempty"`
+	LineRange    *discussionLineRange `json:"line_range,omitempty"`
+}
+
+type discussionLineRange struct {
+	Start discussionLine `json:"start"`
+	End   discussionLine `json:"end"`
+}
+
+type discussionLine struct {
+	LineCode string `json:"line_code"`
+	Type     string `json:"type,omitempty"`
+	OldLine  int    `json:"old_line,omitempty"`
+	NewLine  int    `json:"new_line,omitempty"`
+}
+
+// toDiscussionPosition returns the position of an inline comment on the
+// latest version of the diff of the merge request. The shas all come from
+// its diff refs as GitLab rejects a head sha of another version
+func toDiscussionPosition(input *scm.ReviewCommentInput, mr *pr) *discussionPosition {
+	position := &discussionPosition{
+		BaseSHA:      mr.DiffRefs.BaseSHA,
+		StartSHA:     mr.DiffRefs.StartSHA,
+		HeadSHA:      mr.DiffRefs.HeadSHA,
+		PositionType: "text",
+		OldPath:      input.Path,
+		NewPath:      input.Path,
+	}
+	end := toDiscussionLine(input.Path, input.Side, input.Line, input.OldLine)
+	position.OldLine = end.OldLine
+	position.NewLine = end.NewLine
+	if input.StartLine != 0 {
+		side := input.StartSide
+		if side == "" {
+			side = input.Side
+		}
+		position.LineRange = &discussionLineRange{
+			Start: toDiscussionLine(input.Path, side, input.StartLine, input.StartOldLine),
+			End:   end,
+		}
+	}
+	return position
+}
+
+// toDiscussionLine returns the line on the given side of the diff, an
+// unchanged context line having both its old and new line. The line code
+// is derived from the path and line numbers like GitLab does
+func toDiscussionLine(path, side string, line, oldLine int) discussionLine {
+	to := discussionLine{Type: "new", NewLine: line}
+	switch {
+	case side == scm.ReviewSideLeft:
+		to = discussionLine{Type: "old", OldLine: line}
+	case oldLine != 0:
+		to = discussionLine{OldLine: oldLine, NewLine: line}
+	}
+	to.LineCode = fmt.Sprintf("%x_%d_%d", sha1.Sum([]byte(path)), to.OldLine, to.NewLine) // #nosec
+	return to
+}
+
+func convertDiscussionList(from []*discussion) []*scm.ReviewThread {
+	to := []*scm.ReviewThread{}
+	for _, v := range from {
+		if thread := convertDiscussion(v); thread != nil {
+			to = append(to, thread)
+		}
+	}
+	return to
+}
+
+// convertDiscussion converts the discussion to a review thread returning
+// nil for system notes and individual comments which are not on the diff
+func convertDiscussion(from *discussion) *scm.ReviewThread {
+	if len(from.Notes) == 0 {
+		return nil
+	}
+	first := from.Notes[0]
+	if first.System || (from.IndividualNote && first.Position == nil) {
+		return nil
+	}
+	thread := &scm.ReviewThread{ID: from.ID}
+	resolvable := false
+	resolved := true
+	for _, note := range from.Notes {
+		if note.Resolvable {
+			resolvable = true
+			resolved = resolved && note.Resolved
+		}
+		thread.Comments = append(thread.Comments, convertDiscussionNote(note, from.ID))
+	}
+	thread.Resolved = resolvable && resolved
+	if c := thread.Comments[0]; first.Position != nil {
+		thread.Path = c.Path
+		thread.Line = c.Line
+		thread.StartLine = c.StartLine
+		thread.Side = c.Side
+	}
+	return thread
+}
+
+func convertDiscussionNote(from *discussionNote, threadID string) *scm.ReviewComment {
+	to := &scm.ReviewComment{
+		ID:       from.ID,
+		Body:     from.Body,
+		Author:   *convertUser(&from.Author),
+		Created:  from.CreatedAt,
+		Updated:  from.UpdatedAt,
+		ThreadID: threadID,
+	}
+	if pos := from.Position; pos != nil {
+		to.Sha = pos.HeadSHA
+		to.Path = pos.NewPath
+		to.Line = pos.NewLine
+		to.Side = scm.ReviewSideRight
+		if pos.NewLine == 0 {
+			to.Path = pos.OldPath
+			to.Line = pos.OldLine
+			to.Side = scm.ReviewSideLeft
+		}
+		if r := pos.LineRange; r != nil {
+			start := r.Start.NewLine
+			if start == 0 {
+				start = r.Start.OldLine
+			}
+			if start != to.Line {
+				to.StartLine = start
+			}
+		}
+	}
+	return to
+}
+
+func convertApprovals(from *mergeRequestApprovals) []*scm.Review {
+	to := []*scm.Review{}
+	for _, approval := range from.ApprovedBy {
+		author := approval.User
+		to = append(to, &scm.Review{
+			ID:     author.ID,
+			State:  scm.ReviewStateApproved,
+			Author: *convertUser(&author),
+		})
+	}
+	return to
 }
@@ -6,32 +6,232 @@ package gitlab
 
 import (
 	"context"
+	"encoding/json"
+	"io/ioutil"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestReviewFind(t *testing.T) {
-	service := new(reviewService)
-	_, _, err := service.Find(context.Background(), "diaspora/diaspora", 1, 1)
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/approvals").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_approvals.json")
+
+	client := NewDefault()
+	got, _, err := client.Reviews.Find(context.Background(), "diaspora/diaspora", 1, 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Review{}
+	raw, _ := ioutil.ReadFile("testdata/merge_reviews.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want[0]); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReviewFind_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/approvals").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_approvals.json")
+
+	client := NewDefault()
+	_, _, err := client.Reviews.Find(context.Background(), "diaspora/diaspora", 1, 2)
+	if err != scm.ErrNotFound {
+		t.Errorf("Expect Not Found error, got %v", err)
 	}
 }
 
 func TestReviewList(t *testing.T) {
-	service := new(reviewService)
-	_, _, err := service.List(context.Background(), "diaspora/diaspora", 1, scm.ListOptions{})
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/approvals").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_approvals.json")
+
+	client := NewDefault()
+	got, res, err := client.Reviews.List(context.Background(), "diaspora/diaspora", 1, scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Review{}
+	raw, _ := ioutil.ReadFile("testdata/merge_reviews.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
 }
 
-func TestReviewCreate(t *testing.T) {
-	service := new(reviewService)
-	_, _, err := service.Create(context.Background(), "diaspora/diaspora", 1, nil)
+func TestReviewCreate_Approve(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/approve").
+		JSON(map[string]interface{}{"sha": "2be7ddb704c7b6b83732fdd5b9f09d5a397b5f8f"}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_approvals.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/user").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/user.json")
+
+	input := &scm.ReviewInput{
+		Sha:   "2be7ddb704c7b6b83732fdd5b9f09d5a397b5f8f",
+		Event: "APPROVE",
+	}
+
+	client := NewDefault()
+	got, _, err := client.Reviews.Create(context.Background(), "diaspora/diaspora", 1, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.State != scm.ReviewStateApproved || got.ID != 1 || got.Author.Login != "john_smith" || got.Sha != input.Sha {
+		t.Errorf("Unexpected review %+v", got)
+	}
+}
+
+func TestReviewCreate_RequestChanges(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions").
+		JSON(map[string]interface{}{"body": "Should this be a constant?"}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_discussion.json")
+
+	input := &scm.ReviewInput{
+		Body:  "Should this be a constant?",
+		Event: "REQUEST_CHANGES",
+	}
+
+	client := NewDefault()
+	got, _, err := client.Reviews.Create(context.Background(), "diaspora/diaspora", 1, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.State != scm.ReviewStateChangesRequested || got.ID != 1 || got.Body != input.Body || got.Author.Login != "root" {
+		t.Errorf("Unexpected review %+v", got)
+	}
+}
+
+func TestReviewListComments(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_discussions.json")
+
+	client := NewDefault()
+	got, _, err := client.Reviews.ListComments(context.Background(), "diaspora/diaspora", 1, 1, scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	threads := []*scm.ReviewThread{}
+	raw, _ := ioutil.ReadFile("testdata/merge_discussions.json.golden")
+	json.Unmarshal(raw, &threads)
+	want := threads[0].Comments
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReviewDismiss(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/user").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/user.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/approvals").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_approvals.json")
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/unapprove").
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	got, _, err := client.Reviews.Dismiss(context.Background(), "diaspora/diaspora", 1, 1, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.State != scm.ReviewStateDismissed || got.Author.Login != "root" {
+		t.Errorf("Unexpected review %+v", got)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected the approval to be revoked")
+	}
+}
+
+func TestReviewDismiss_OtherUser(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/user").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/user.json")
+
+	client := NewDefault()
+	_, _, err := client.Reviews.Dismiss(context.Background(), "diaspora/diaspora", 1, 2, "")
 	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+		t.Errorf("Expect Not Supported error dismissing the review of another user, got %v", err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected the current user to be found")
 	}
 }
 
@@ -42,3 +242,262 @@ func TestReviewDelete(t *testing.T) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
+
+func TestReviewCreateComment(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_diff_refs.json")
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions").
+		JSON(map[string]interface{}{
+			"body": "Should this be a constant?",
+			"position": map[string]interface{}{
+				"base_sha":      "c380d3acebd181f13629a25d2e2acca46ffe1e00",
+				"start_sha":     "c380d3acebd181f13629a25d2e2acca46ffe1e00",
+				"head_sha":      "2be7ddb704c7b6b83732fdd5b9f09d5a397b5f8f",
+				"position_type": "text",
+				"old_path":      "package.json",
+				"new_path":      "package.json",
+				"new_line":      27,
+				"line_range": map[string]interface{}{
+					"start": map[string]interface{}{
+						"line_code": "7030d0b2f71b999ff89a343de08c414af32fc93a_0_25",
+						"type":      "new",
+						"new_line":  25,
+					},
+					"end": map[string]interface{}{
+						"line_code": "7030d0b2f71b999ff89a343de08c414af32fc93a_0_27",
+						"type":      "new",
+						"new_line":  27,
+					},
+				},
+			},
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_discussion.json")
+
+	input := &scm.ReviewCommentInput{
+		Body:      "Should this be a constant?",
+		Path:      "package.json",
+		StartLine: 25,
+		Line:      27,
+		Side:      scm.ReviewSideRight,
+	}
+
+	client := NewDefault()
+	got, res, err := client.Reviews.CreateComment(context.Background(), "diaspora/diaspora", 1, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.ReviewComment)
+	raw, _ := ioutil.ReadFile("testdata/merge_discussion.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestReviewCreateComment_Reply(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions/6a9c1750b37d513a43987b574953fceb50b03ce7/notes").
+		JSON(map[string]interface{}{"body": "Done"}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_discussion_note.json")
+
+	input := &scm.ReviewCommentInput{
+		Body:     "Done",
+		ThreadID: "6a9c1750b37d513a43987b574953fceb50b03ce7",
+	}
+
+	client := NewDefault()
+	got, _, err := client.Reviews.CreateComment(context.Background(), "diaspora/diaspora", 1, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.ID != 1128 || got.ThreadID != input.ThreadID || got.Line != 27 {
+		t.Errorf("Unexpected reply %+v", got)
+	}
+}
+
+func TestReviewListThreads(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/merge_discussions.json")
+
+	client := NewDefault()
+	got, res, err := client.Reviews.ListThreads(context.Background(), "diaspora/diaspora", 1, scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.ReviewThread{}
+	raw, _ := ioutil.ReadFile("testdata/merge_discussions.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+	t.Run("Page", testPage(res))
+}
+
+func TestReviewResolveThread(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions/6a9c1750b37d513a43987b574953fceb50b03ce7").
+		MatchParam("resolved", "true").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	_, err := client.Reviews.ResolveThread(context.Background(), "diaspora/diaspora", 1, "6a9c1750b37d513a43987b574953fceb50b03ce7")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReviewUnresolveThread(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions/6a9c1750b37d513a43987b574953fceb50b03ce7").
+		MatchParam("resolved", "false").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	_, err := client.Reviews.UnresolveThread(context.Background(), "diaspora/diaspora", 1, "6a9c1750b37d513a43987b574953fceb50b03ce7")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReviewCreateComment_Context(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_diff_refs.json")
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions").
+		JSON(map[string]interface{}{
+			"body": "Should this be a constant?",
+			"position": map[string]interface{}{
+				"base_sha":      "c380d3acebd181f13629a25d2e2acca46ffe1e00",
+				"start_sha":     "c380d3acebd181f13629a25d2e2acca46ffe1e00",
+				"head_sha":      "2be7ddb704c7b6b83732fdd5b9f09d5a397b5f8f",
+				"position_type": "text",
+				"old_path":      "package.json",
+				"new_path":      "package.json",
+				"old_line":      26,
+				"new_line":      27,
+			},
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_discussion.json")
+
+	input := &scm.ReviewCommentInput{
+		Body:    "Should this be a constant?",
+		Path:    "package.json",
+		Line:    27,
+		OldLine: 26,
+		Sha:     "0000000000000000000000000000000000000000",
+	}
+
+	client := NewDefault()
+	_, _, err := client.Reviews.CreateComment(context.Background(), "diaspora/diaspora", 1, input)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected both lines and the shas of the latest diff version")
+	}
+}
+
+func TestReviewCreate_NoBody(t *testing.T) {
+	for _, event := range []string{"", "COMMENT", "REQUEST_CHANGES"} {
+		input := &scm.ReviewInput{Event: event}
+		client := NewDefault()
+		_, _, err := client.Reviews.Create(context.Background(), "diaspora/diaspora", 1, input)
+		if err == nil || err == scm.ErrNotSupported {
+			t.Errorf("Expected an error for %q without a body, got %v", event, err)
+		}
+	}
+}
+
+func TestReviewCreate_UnknownEvent(t *testing.T) {
+	input := &scm.ReviewInput{Body: "Looks good", Event: "PENDING"}
+	client := NewDefault()
+	_, _, err := client.Reviews.Create(context.Background(), "diaspora/diaspora", 1, input)
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expected ErrNotSupported, got %v", err)
+	}
+}
+
+func TestReviewCreate_ApproveFirst(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/approve").
+		Reply(401).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"message":"401 Unauthorized"}`)
+
+	input := &scm.ReviewInput{
+		Event: "APPROVE",
+		Comments: []*scm.ReviewCommentInput{
+			{Body: "Should this be a constant?", Path: "package.json", Line: 27},
+		},
+	}
+
+	client := NewDefault()
+	_, _, err := client.Reviews.Create(context.Background(), "diaspora/diaspora", 1, input)
+	if err == nil {
+		t.Errorf("Expected the failed approval to be returned")
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected no comments when the approval fails")
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type searchService struct {
+	client *wrapper
+}
+
+type searchBlob struct {
+	Basename  string `json:"basename"`
+	Data      string `json:"data"`
+	Path      string `json:"path"`
+	Filename  string `json:"filename"`
+	ID        string `json:"id"`
+	Ref       string `json:"ref"`
+	ProjectID int    `json:"project_id"`
+}
+
+type searchCommit struct {
+	commit
+	ProjectID int `json:"project_id"`
+}
+
+func (s *searchService) Repositories(ctx context.Context, q scm.SearchQuery) (*scm.RepositorySearchResults, *scm.Response, error) {
+	// a project cannot be searched for projects so lets search its group
+	if q.Repo != "" && q.Org == "" {
+		q.Org, _ = scm.Split(q.Repo)
+	}
+	q.Repo = ""
+	out := []*repository{}
+	res, err := s.search(ctx, "projects", q, &out)
+	return &scm.RepositorySearchResults{
+		Total: searchTotal(res, len(out)),
+		Items: convertRepositoryList(out),
+	}, res, err
+}
+
+func (s *searchService) Issues(ctx context.Context, q scm.SearchQuery) (*scm.IssueSearchResults, *scm.Response, error) {
+	out := []*issue{}
+	res, err := s.search(ctx, "issues", q, &out)
+	results := &scm.IssueSearchResults{
+		Total: searchTotal(res, len(out)),
+	}
+	for _, v := range out {
+		if !matchesSearch(q, v.Labels, v.Author.Username, v.Updated) {
+			continue
+		}
+		results.Items = append(results.Items, &scm.SearchIssue{
+			Issue:      *convertIssue(v),
+			Repository: searchRepository(q, v.ProjectID, v.Link, "issues"),
+		})
+	}
+	// the total of the server includes the results filtered out
+	results.Incomplete = len(results.Items) < len(out)
+	return results, res, err
+}
+
+func (s *searchService) PullRequests(ctx context.Context, q scm.SearchQuery) (*scm.PullRequestSearchResults, *scm.Response, error) {
+	out := []*pr{}
+	res, err := s.search(ctx, "merge_requests", q, &out)
+	results := &scm.PullRequestSearchResults{
+		Total: searchTotal(res, len(out)),
+	}
+	for _, v := range out {
+		var labels []string
+		for _, l := range v.Labels {
+			labels = append(labels, *l)
+		}
+		if !matchesSearch(q, labels, v.Author.Username, v.Updated) {
+			continue
+		}
+		results.Items = append(results.Items, convertSearchPullRequest(q, v))
+	}
+	// the total of the server includes the results filtered out
+	results.Incomplete = len(results.Items) < len(out)
+	return results, res, err
+}
+
+func (s *searchService) Code(ctx context.Context, q scm.SearchQuery) (*scm.CodeSearchResults, *scm.Response, error) {
+	out := []*searchBlob{}
+	res, err := s.search(ctx, "blobs", q, &out)
+	results := &scm.CodeSearchResults{
+		Total: searchTotal(res, len(out)),
+	}
+	for _, v := range out {
+		results.Items = append(results.Items, &scm.CodeResult{
+			Name:       v.Basename,
+			Path:       v.Path,
+			Sha:        v.ID,
+			Ref:        v.Ref,
+			Fragment:   v.Data,
+			Repository: searchRepository(q, v.ProjectID, "", ""),
+		})
+	}
+	return results, res, err
+}
+
+func (s *searchService) Commits(ctx context.Context, q scm.SearchQuery) (*scm.CommitSearchResults, *scm.Response, error) {
+	out := []*searchCommit{}
+	res, err := s.search(ctx, "commits", q, &out)
+	results := &scm.CommitSearchResults{
+		Total: searchTotal(res, len(out)),
+	}
+	for _, v := range out {
+		results.Items = append(results.Items, &scm.SearchCommit{
+			Commit:     *convertCommit(&v.commit),
+			Repository: searchRepository(q, v.ProjectID, v.URL, "commit"),
+		})
+	}
+	return results, res, err
+}
+
+// search performs the search of the scope in the project, group or
+// whole instance depending on how the query is scoped.
+func (s *searchService) search(ctx context.Context, scope string, q scm.SearchQuery, out interface{}) (*scm.Response, error) {
+	path := "api/v4/search"
+	if q.Repo != "" {
+		path = fmt.Sprintf("api/v4/projects/%s/search", encode(q.Repo))
+	} else if q.Org != "" {
+		path = fmt.Sprintf("api/v4/groups/%s/search", encode(q.Org))
+	}
+	return s.client.do(ctx, "GET", path+"?"+encodeSearchQuery(scope, q), nil, out)
+}
+
+// searchTotal returns the total number of results of the search
+// which is only reported by gitlab for smaller result sets.
+func searchTotal(res *scm.Response, count int) int {
+	if res != nil {
+		if total, err := strconv.Atoi(res.Header.Get("X-Total")); err == nil {
+			return total
+		}
+	}
+	return count
+}
+
+// matchesSearch returns true if the issue or merge request matches the
+// labels, author and updated filters of the query which are not supported
+// by the gitlab search API.
+func matchesSearch(q scm.SearchQuery, labels []string, author string, updated time.Time) bool {
+	if q.Author != "" && q.Author != author {
+		return false
+	}
+	if !q.UpdatedAfter.IsZero() && updated.Before(q.UpdatedAfter) {
+		return false
+	}
+	if !q.UpdatedBefore.IsZero() && updated.After(q.UpdatedBefore) {
+		return false
+	}
+	for _, want := range q.Labels {
+		found := false
+		for _, l := range labels {
+			if l == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// searchRepository returns the repository of a search result using the
+// repository of the query or else the path of the web link of the result.
+func searchRepository(q scm.SearchQuery, projectID int, link, kind string) scm.Repository {
+	repo := scm.Repository{ID: strconv.Itoa(projectID)}
+	fullName := q.Repo
+	if fullName == "" && kind != "" {
+		fullName = repositoryFromLink(link, kind)
+	}
+	if fullName != "" {
+		repo.Namespace, repo.Name = scm.Split(fullName)
+		repo.FullName = fullName
+	}
+	return repo
+}
+
+// repositoryFromLink returns the full name of the project from the web link
+// of one of its resources e.g. https://gitlab.com/group/project/-/issues/1
+func repositoryFromLink(link, kind string) string {
+	i := strings.Index(link, "://")
+	if i < 0 {
+		return ""
+	}
+	path := link[i+3:]
+	if i = strings.Index(path, "/"); i < 0 {
+		return ""
+	}
+	path = path[i+1:]
+	for _, sep := range []string{"/-/" + kind + "/", "/" + kind + "/"} {
+		if i = strings.Index(path, sep); i > 0 {
+			return path[:i]
+		}
+	}
+	return ""
+}
+
+func convertSearchPullRequest(q scm.SearchQuery, from *pr) *scm.SearchPullRequest {
+	repo := searchRepository(q, from.TargetProjectID, from.Link, "merge_requests")
+	return &scm.SearchPullRequest{
+		PullRequest: scm.PullRequest{
+			Number: from.Number,
+			Title:  from.Title,
+			Body:   from.Desc,
+			State:  gitlabStateToSCMState(from.State),
+			Labels: convertPullRequestLabels(from.Labels),
+			Sha:    from.Sha,
+			Ref:    fmt.Sprintf("refs/merge-requests/%d/head", from.Number),
+			Source: from.SourceBranch,
+			Target: from.TargetBranch,
+			Link:   from.Link,
+			Draft:  from.Draft || from.WIP,
+			Closed: from.State != "opened",
+			Merged: from.State == "merged",
+			Author: *convertUser(&from.Author),
+			Head: scm.PullRequestBranch{
+				Ref: from.SourceBranch,
+				Sha: from.Sha,
+			},
+			Base: scm.PullRequestBranch{
+				Ref:  from.TargetBranch,
+				Repo: repo,
+			},
+			Created: from.Created,
+			Updated: from.Updated,
+		},
+		Repository: repo,
+	}
+}
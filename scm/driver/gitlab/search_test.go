@@ -0,0 +1,214 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSearchRepositories(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/groups/diaspora/search").
+		MatchParam("scope", "projects").
+		MatchParam("search", "diaspora").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeader("X-Total", "12").
+		File("testdata/repos.json")
+
+	client := NewDefault()
+	got, res, err := client.Search.Repositories(context.Background(), scm.SearchQuery{Terms: "diaspora", Org: "diaspora"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	items := []*scm.Repository{}
+	raw, _ := ioutil.ReadFile("testdata/repos.json.golden")
+	json.Unmarshal(raw, &items)
+	want := &scm.RepositorySearchResults{Total: 12, Items: items}
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestSearchIssues(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/example/example/search").
+		MatchParam("scope", "issues").
+		MatchParam("search", "foo").
+		MatchParam("state", "opened").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/issues.json")
+
+	client := NewDefault()
+	query := scm.SearchQuery{Terms: "foo", Repo: "example/example", State: "open"}
+	got, _, err := client.Search.Issues(context.Background(), query)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	issues := []*scm.Issue{}
+	raw, _ := ioutil.ReadFile("testdata/issues.json.golden")
+	json.Unmarshal(raw, &issues)
+	want := &scm.IssueSearchResults{Total: 1}
+	for _, issue := range issues {
+		want.Items = append(want.Items, &scm.SearchIssue{
+			Issue: *issue,
+			Repository: scm.Repository{
+				ID:        "4",
+				Namespace: "example",
+				Name:      "example",
+				FullName:  "example/example",
+			},
+		})
+	}
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchPullRequests(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/search").
+		MatchParam("scope", "merge_requests").
+		MatchParam("search", "test").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeader("X-Total", "2").
+		File("testdata/search_merge_requests.json")
+
+	client := NewDefault()
+	query := scm.SearchQuery{Terms: "test", Labels: []string{"Manage"}, Author: "root"}
+	got, _, err := client.Search.PullRequests(context.Background(), query)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.PullRequestSearchResults)
+	raw, _ := ioutil.ReadFile("testdata/search_merge_requests.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchCode(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/search").
+		MatchParam("scope", "blobs").
+		MatchParam("search", "installation").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/search_blobs.json")
+
+	client := NewDefault()
+	query := scm.SearchQuery{Terms: "installation", Repo: "diaspora/diaspora"}
+	got, _, err := client.Search.Code(context.Background(), query)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := &scm.CodeSearchResults{
+		Total: 1,
+		Items: []*scm.CodeResult{
+			{
+				Name:     "README",
+				Path:     "README.md",
+				Ref:      "master",
+				Fragment: "```\n\n## Installation\n\nQuick start using the [pre-built",
+				Repository: scm.Repository{
+					ID:        "6",
+					Namespace: "diaspora",
+					Name:      "diaspora",
+					FullName:  "diaspora/diaspora",
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchCommits(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/groups/diaspora/search").
+		MatchParam("scope", "commits").
+		MatchParam("search", "readme").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/search_commits.json")
+
+	client := NewDefault()
+	query := scm.SearchQuery{Terms: "readme", Org: "diaspora"}
+	got, _, err := client.Search.Commits(context.Background(), query)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.CommitSearchResults)
+	raw, _ := ioutil.ReadFile("testdata/search_commits.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepositoryFromLink(t *testing.T) {
+	tests := []struct {
+		link, kind, want string
+	}{
+		{"https://gitlab.com/gitlab-org/testme/merge_requests/1", "merge_requests", "gitlab-org/testme"},
+		{"https://gitlab.com/group/sub/project/-/issues/1", "issues", "group/sub/project"},
+		{"https://gitlab.com/group/project/-/commit/6104942438c14ec7bd21c6cd5bd995272b3faff6", "commit", "group/project"},
+		{"https://gitlab.com/group/project", "issues", ""},
+		{"", "issues", ""},
+	}
+	for _, test := range tests {
+		if got := repositoryFromLink(test.link, test.kind); got != test.want {
+			t.Errorf("Want repository %q for %s, got %q", test.want, test.link, got)
+		}
+	}
+}
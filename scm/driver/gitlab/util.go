@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -45,6 +46,20 @@ func encodeMemberListOptions(opts scm.ListOptions) string {
 	return params.Encode()
 }
 
+// encodeGroupProjectListOptions encodes the list options of the projects
+// of a group including the projects of its subgroups.
+func encodeGroupProjectListOptions(opts scm.ListOptions) string {
+	params := url.Values{}
+	params.Set("include_subgroups", "true")
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("per_page", strconv.Itoa(opts.Size))
+	}
+	return params.Encode()
+}
+
 func encodeCommitListOptions(opts scm.CommitListOptions) string {
 	params := url.Values{}
 	if opts.Page != 0 {
@@ -56,6 +71,21 @@ func encodeCommitListOptions(opts scm.CommitListOptions) string {
 	if opts.Ref != "" {
 		params.Set("ref_name", opts.Ref)
 	}
+	if opts.Path != "" {
+		params.Set("path", opts.Path)
+	}
+	if opts.Author != "" {
+		params.Set("author", opts.Author)
+	}
+	if !opts.Since.IsZero() {
+		params.Set("since", opts.Since.UTC().Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		params.Set("until", opts.Until.UTC().Format(time.RFC3339))
+	}
+	if opts.FirstParent {
+		params.Set("first_parent", "true")
+	}
 	return params.Encode()
 }
 
@@ -123,6 +153,58 @@ func encodePullRequestListOptions(opts scm.PullRequestListOptions) string {
 	if opts.UpdatedBefore != nil {
 		params.Set("updated_before", opts.UpdatedBefore.Format(scm.SearchTimeFormat))
 	}
+	if opts.Head != "" {
+		// the owner of a qualified head is implied by the source project
+		head := opts.Head
+		if i := strings.Index(head, ":"); i >= 0 {
+			head = head[i+1:]
+		}
+		params.Set("source_branch", head)
+	}
+	if opts.Base != "" {
+		params.Set("target_branch", opts.Base)
+	}
+	if opts.Author != "" {
+		params.Set("author_username", opts.Author)
+	}
+	switch opts.Sort {
+	case "created", "updated":
+		params.Set("order_by", opts.Sort+"_at")
+	case "":
+	default:
+		params.Set("order_by", opts.Sort)
+	}
+	if opts.Direction != "" {
+		params.Set("sort", opts.Direction)
+	}
+	return params.Encode()
+}
+
+func encodeSearchQuery(scope string, q scm.SearchQuery) string {
+	params := url.Values{}
+	params.Set("scope", scope)
+	params.Set("search", q.Terms)
+	switch q.State {
+	case "":
+	case "open":
+		params.Set("state", "opened")
+	default:
+		params.Set("state", q.State)
+	}
+	if q.Sort != "" {
+		params.Set("order_by", q.Sort)
+		sort := "desc"
+		if q.Ascending {
+			sort = "asc"
+		}
+		params.Set("sort", sort)
+	}
+	if q.Page != 0 {
+		params.Set("page", strconv.Itoa(q.Page))
+	}
+	if q.Size != 0 {
+		params.Set("per_page", strconv.Itoa(q.Size))
+	}
 	return params.Encode()
 }
 
@@ -133,15 +215,19 @@ func encodePullRequestMergeOptions(opts *scm.PullRequestMergeOptions) *pullReque
 		if opts.SHA != "" {
 			prRequest.SHA = opts.SHA
 		}
+		message := opts.CommitTitle
+		if opts.CommitMessage != "" {
+			message = strings.TrimPrefix(message+"\n\n"+opts.CommitMessage, "\n\n")
+		}
 		switch opts.MergeMethod {
 		case "squash":
-			if opts.CommitTitle != "" {
-				prRequest.SquashCommitMessage = opts.CommitTitle
+			if message != "" {
+				prRequest.SquashCommitMessage = message
 			}
 			prRequest.Squash = "true"
 		default:
-			if opts.CommitTitle != "" {
-				prRequest.CommitMessage = opts.CommitTitle
+			if message != "" {
+				prRequest.CommitMessage = message
 			}
 		}
 		if opts.MergeWhenPipelineSucceeds {
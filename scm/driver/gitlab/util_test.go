@@ -6,6 +6,7 @@ package gitlab
 
 import (
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -47,6 +48,22 @@ func Test_encodeCommitListOptions(t *testing.T) {
 	}
 }
 
+func Test_encodeCommitListOptions_Filters(t *testing.T) {
+	opts := scm.CommitListOptions{
+		Ref:         "master",
+		Path:        "apps/frontend",
+		Author:      "root",
+		Since:       time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Until:       time.Date(2021, time.February, 1, 12, 30, 0, 0, time.UTC),
+		FirstParent: true,
+	}
+	want := "author=root&first_parent=true&path=apps%2Ffrontend&ref_name=master&since=2021-01-01T00%3A00%3A00Z&until=2021-02-01T12%3A30%3A00Z"
+	got := encodeCommitListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded commit list options %q, got %q", want, got)
+	}
+}
+
 func Test_encodeIssueListOptions(t *testing.T) {
 	opts := scm.IssueListOptions{
 		Page:   10,
@@ -119,6 +136,22 @@ func Test_encodePullRequestListOptions_Opened(t *testing.T) {
 	}
 }
 
+func Test_encodePullRequestListOptions_Filters(t *testing.T) {
+	t.Parallel()
+	opts := scm.PullRequestListOptions{
+		Head:      "diaspora:feature",
+		Base:      "master",
+		Author:    "john_smith",
+		Sort:      "updated",
+		Direction: "asc",
+	}
+	want := "author_username=john_smith&order_by=updated_at&sort=asc&source_branch=feature&target_branch=master"
+	got := encodePullRequestListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded pr list options %q, got %q", want, got)
+	}
+}
+
 func Test_encodePullRequestListOptions_Closed(t *testing.T) {
 	t.Parallel()
 	opts := scm.PullRequestListOptions{
@@ -0,0 +1,83 @@
+package gogs
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// unsupported lists the features of the services of the
+// client which return scm.ErrNotSupported.
+var unsupported = []scm.Feature{
+	"Contents.Blame",
+	"Contents.Create",
+	"Contents.Delete",
+	"Contents.List",
+	"Contents.Update",
+	"Git.Compare",
+	"Git.CompareCommits",
+	"Git.CreateRef",
+	"Git.DeleteRef",
+	"Git.FindRef",
+	"Git.FindTag",
+	"Git.ListChanges",
+	"Git.ListCommits",
+	"Git.ListTags",
+	"Issues.AssignIssue",
+	"Issues.ClearMilestone",
+	"Issues.Close",
+	"Issues.FindComment",
+	"Issues.ListEvents",
+	"Issues.Lock",
+	"Issues.Reopen",
+	"Issues.Search",
+	"Issues.SetMilestone",
+	"Issues.UnassignIssue",
+	"Issues.Unlock",
+	"Milestones.Create",
+	"Milestones.Delete",
+	"Milestones.Find",
+	"Milestones.List",
+	"Milestones.Update",
+	"Organizations.AcceptOrganizationInvitation",
+	"Organizations.Create",
+	"Organizations.Delete",
+	"Organizations.IsAdmin",
+	"Organizations.IsMember",
+	"Organizations.ListMemberships",
+	"Organizations.ListOrgMembers",
+	"Organizations.ListPendingInvitations",
+	"Organizations.ListTeamMembers",
+	"Organizations.ListTeams",
+	"PullRequests.AssignIssue",
+	"PullRequests.ClearMilestone",
+	"PullRequests.ConvertToDraft",
+	"PullRequests.DisableAutoMerge",
+	"PullRequests.EnableAutoMerge",
+	"PullRequests.FindComment",
+	"PullRequests.FindMergeability",
+	"PullRequests.ListChanges",
+	"PullRequests.ListCommits",
+	"PullRequests.ListEvents",
+	"PullRequests.MarkReady",
+	"PullRequests.RequestReview",
+	"PullRequests.SetMilestone",
+	"PullRequests.UnassignIssue",
+	"PullRequests.UnrequestReview",
+	"PullRequests.UpdateBranch",
+	"Repositories.Delete",
+	"Repositories.Fork",
+	"Repositories.UpdateHook",
+	"Reviews.Create",
+	"Reviews.CreateComment",
+	"Reviews.Delete",
+	"Reviews.Dismiss",
+	"Reviews.Find",
+	"Reviews.List",
+	"Reviews.ListComments",
+	"Reviews.ListThreads",
+	"Reviews.ResolveThread",
+	"Reviews.Submit",
+	"Reviews.UnresolveThread",
+	"Reviews.Update",
+	"Users.AcceptInvitation",
+	"Users.CreateToken",
+	"Users.DeleteToken",
+	"Users.ListInvitations",
+}
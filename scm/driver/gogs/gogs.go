@@ -14,10 +14,16 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 
+	"github.com/hashicorp/go-version"
 	"github.com/jenkins-x/go-scm/scm"
 )
 
+// minPullRequestVersion is the oldest version of gogs which serves
+// the pull request and commit status endpoints.
+var minPullRequestVersion = version.Must(version.NewVersion("0.13.0"))
+
 // NewWebHookService creates a new instance of the webhook service without the rest of the client
 func NewWebHookService() scm.WebhookService {
 	return &webhookService{nil}
@@ -32,7 +38,7 @@ func New(uri string) (*scm.Client, error) {
 	if !strings.HasSuffix(base.Path, "/") {
 		base.Path = base.Path + "/"
 	}
-	client := &wrapper{new(scm.Client)}
+	client := &wrapper{Client: new(scm.Client)}
 	client.BaseURL = base
 	// initialize services
 	client.Driver = scm.DriverGogs
@@ -46,6 +52,7 @@ func New(uri string) (*scm.Client, error) {
 	client.Reviews = &reviewService{client}
 	client.Users = &userService{client}
 	client.Webhooks = &webhookService{client}
+	client.Unsupported = unsupported
 	return client.Client, nil
 }
 
@@ -53,6 +60,38 @@ func New(uri string) (*scm.Client, error) {
 // for making http requests and unmarshaling the response.
 type wrapper struct {
 	*scm.Client
+
+	mu      sync.Mutex
+	version *version.Version
+}
+
+// checkVersion returns scm.ErrNotSupported unless the server reports
+// at least the given version. Servers which are too old to report
+// their version at all do not support the newer endpoints either.
+func (c *wrapper) checkVersion(ctx context.Context, min *version.Version) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.version == nil {
+		out := struct {
+			Version string `json:"version"`
+		}{}
+		res, err := c.do(ctx, "GET", "api/v1/version", nil, &out)
+		if res != nil && res.Status == http.StatusNotFound {
+			return scm.ErrNotSupported
+		}
+		if err != nil {
+			return err
+		}
+		v, err := version.NewVersion(out.Version)
+		if err != nil {
+			return scm.ErrNotSupported
+		}
+		c.version = v
+	}
+	if c.version.LessThan(min) {
+		return scm.ErrNotSupported
+	}
+	return nil
 }
 
 // do wraps the Client.Do function by creating the Request and
@@ -33,16 +33,47 @@ func (s *issueService) ListEvents(context.Context, string, int, scm.ListOptions)
 	return nil, nil, scm.ErrNotSupported
 }
 
-func (s *issueService) ListLabels(context.Context, string, int, scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *issueService) ListLabels(ctx context.Context, repo string, number int, _ scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/labels", repo, number)
+	out := []*label{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertLabels(out), res, err
 }
 
 func (s *issueService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	id, res, err := s.findLabel(ctx, repo, label)
+	if err != nil {
+		return res, err
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/labels", repo, number)
+	in := &issueLabelsInput{Labels: []int64{id}}
+	return s.client.do(ctx, "POST", path, in, nil)
 }
 
 func (s *issueService) DeleteLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	id, res, err := s.findLabel(ctx, repo, label)
+	if err != nil {
+		return res, err
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/labels/%d", repo, number, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+// findLabel returns the id of the repository label with the given
+// name as gogs addresses the labels of an issue by id.
+func (s *issueService) findLabel(ctx context.Context, repo, name string) (int64, *scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/labels", repo)
+	out := []*label{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	if err != nil {
+		return 0, res, err
+	}
+	for _, v := range out {
+		if v.Name == name {
+			return v.ID, res, nil
+		}
+	}
+	return 0, res, fmt.Errorf("label %q not found in repository %s", name, repo)
 }
 
 func (s *issueService) Find(ctx context.Context, repo string, number int) (*scm.Issue, *scm.Response, error) {
@@ -97,7 +128,13 @@ func (s *issueService) DeleteComment(ctx context.Context, repo string, index, id
 }
 
 func (s *issueService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v1/repos/%s/issues/%d/comments/%d", repo, number, id)
+	in := &issueCommentInput{
+		Body: input.Body,
+	}
+	out := new(issueComment)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertIssueComment(out), res, err
 }
 
 func (s *issueService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
@@ -137,7 +174,7 @@ type (
 		Title       string    `json:"title"`
 		Body        string    `json:"body"`
 		State       string    `json:"state"`
-		Labels      []string  `json:"labels"`
+		Labels      []*label  `json:"labels"`
 		Comments    int       `json:"comments"`
 		Created     time.Time `json:"created_at"`
 		Updated     time.Time `json:"updated_at"`
@@ -153,6 +190,11 @@ type (
 		Body  string `json:"body"`
 	}
 
+	// gogs issue labels request object.
+	issueLabelsInput struct {
+		Labels []int64 `json:"labels"`
+	}
+
 	// gogs issue comment response object.
 	issueComment struct {
 		ID        int       `json:"id"`
@@ -186,6 +228,7 @@ func convertIssue(from *issue) *scm.Issue {
 		Number:  from.Number,
 		Title:   from.Title,
 		Body:    from.Body,
+		Labels:  convertIssueLabels(from.Labels),
 		Link:    "", // TODO construct the link to the issue.
 		Closed:  from.State == "closed",
 		Author:  *convertUser(&from.User),
@@ -194,6 +237,14 @@ func convertIssue(from *issue) *scm.Issue {
 	}
 }
 
+func convertIssueLabels(from []*label) []string {
+	var to []string
+	for _, v := range from {
+		to = append(to, v.Name)
+	}
+	return to
+}
+
 func convertIssueCommentList(from []*issueComment) []*scm.Comment {
 	to := []*scm.Comment{}
 	for _, v := range from {
@@ -6,6 +6,8 @@ package gogs
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -14,7 +16,17 @@ type pullService struct {
 	client *wrapper
 }
 
-func (s *pullService) Find(context.Context, string, int) (*scm.PullRequest, *scm.Response, error) {
+func (s *pullService) Find(ctx context.Context, repo string, number int) (*scm.PullRequest, *scm.Response, error) {
+	if err := s.client.checkVersion(ctx, minPullRequestVersion); err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/pulls/%d", repo, number)
+	out := new(pullRequest)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertPullRequest(out), res, err
+}
+
+func (s *pullService) FindMergeability(context.Context, string, int) (*scm.Mergeability, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
 
@@ -22,63 +34,123 @@ func (s *pullService) FindComment(context.Context, string, int, int) (*scm.Comme
 	return nil, nil, scm.ErrNotSupported
 }
 
-func (s *pullService) List(context.Context, string, scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *pullService) List(ctx context.Context, repo string, opts scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
+	if err := s.client.checkVersion(ctx, minPullRequestVersion); err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/pulls?%s", repo, encodePullRequestListOptions(opts))
+	out := []*pullRequest{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertPullRequestList(out), res, err
 }
 
-func (s *pullService) ListComments(context.Context, string, int, scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+// ListComments lists the comments of the pull request which gogs
+// stores as the comments of its issue.
+func (s *pullService) ListComments(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
+	return s.issues().ListComments(ctx, repo, number, opts)
 }
 
 func (s *pullService) ListChanges(context.Context, string, int, scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
 
-func (s *pullService) ListLabels(context.Context, string, int, scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+func (s *pullService) ListCommits(context.Context, string, int, scm.ListOptions) ([]*scm.Commit, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
 
+func (s *pullService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	return s.issues().ListLabels(ctx, repo, number, opts)
+}
+
 func (s *pullService) ListEvents(context.Context, string, int, scm.ListOptions) ([]*scm.ListedIssueEvent, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
 
 func (s *pullService) AddLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
-	// TODO implement
-	return nil, scm.ErrNotSupported
+	return s.issues().AddLabel(ctx, repo, number, label)
 }
 
 func (s *pullService) DeleteLabel(ctx context.Context, repo string, number int, label string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	return s.issues().DeleteLabel(ctx, repo, number, label)
 }
 
-func (s *pullService) CreateComment(context.Context, string, int, *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *pullService) CreateComment(ctx context.Context, repo string, number int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
+	return s.issues().CreateComment(ctx, repo, number, input)
 }
 
-func (s *pullService) DeleteComment(context.Context, string, int, int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+func (s *pullService) DeleteComment(ctx context.Context, repo string, number, id int) (*scm.Response, error) {
+	return s.issues().DeleteComment(ctx, repo, number, id)
 }
 
 func (s *pullService) EditComment(ctx context.Context, repo string, number int, id int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	return s.issues().EditComment(ctx, repo, number, id, input)
 }
 
-func (s *pullService) Merge(context.Context, string, int, *scm.PullRequestMergeOptions) (*scm.Response, error) {
+func (s *pullService) Merge(ctx context.Context, repo string, number int, options *scm.PullRequestMergeOptions) (*scm.Response, error) {
+	if err := s.client.checkVersion(ctx, minPullRequestVersion); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/pulls/%d/merge", repo, number)
+	in := &pullRequestMergeInput{Do: "merge"}
+	if options != nil {
+		if options.MergeMethod != "" {
+			in.Do = options.MergeMethod
+		}
+		in.Title = options.CommitTitle
+		in.Message = options.CommitMessage
+	}
+	return s.client.do(ctx, "POST", path, in, nil)
+}
+
+func (s *pullService) EnableAutoMerge(context.Context, string, int, *scm.PullRequestMergeOptions) (*scm.Response, error) {
 	return nil, scm.ErrNotSupported
 }
 
-func (s *pullService) Update(ctx context.Context, repo string, number int, prInput *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *pullService) DisableAutoMerge(context.Context, string, int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
 }
 
-func (s *pullService) Close(context.Context, string, int) (*scm.Response, error) {
+func (s *pullService) UpdateBranch(context.Context, string, int, string) (*scm.Response, error) {
 	return nil, scm.ErrNotSupported
 }
 
-func (s *pullService) Reopen(context.Context, string, int) (*scm.Response, error) {
+func (s *pullService) MarkReady(context.Context, string, int) (*scm.Response, error) {
 	return nil, scm.ErrNotSupported
 }
 
+func (s *pullService) ConvertToDraft(context.Context, string, int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) Update(ctx context.Context, repo string, number int, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
+	in := &pullRequestUpdateInput{
+		Title: input.Title,
+		Body:  input.Body,
+		Base:  input.Base,
+	}
+	return s.update(ctx, repo, number, in)
+}
+
+func (s *pullService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	_, res, err := s.update(ctx, repo, number, &pullRequestUpdateInput{State: "closed"})
+	return res, err
+}
+
+func (s *pullService) Reopen(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	_, res, err := s.update(ctx, repo, number, &pullRequestUpdateInput{State: "open"})
+	return res, err
+}
+
+func (s *pullService) update(ctx context.Context, repo string, number int, in *pullRequestUpdateInput) (*scm.PullRequest, *scm.Response, error) {
+	if err := s.client.checkVersion(ctx, minPullRequestVersion); err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/pulls/%d", repo, number)
+	out := new(pullRequest)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertPullRequest(out), res, err
+}
+
 func (s *pullService) AssignIssue(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
 	return nil, scm.ErrNotSupported
 }
@@ -88,7 +160,32 @@ func (s *pullService) UnassignIssue(ctx context.Context, repo string, number int
 }
 
 func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	if err := s.client.checkVersion(ctx, minPullRequestVersion); err != nil {
+		return nil, nil, err
+	}
+	// the pull request api cannot set assignees, milestones or reviewers
+	if len(input.Assignees) > 0 || input.Milestone != 0 || len(input.Reviewers) > 0 {
+		return nil, nil, scm.ErrNotSupported
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/pulls", repo)
+	in := &pullRequestInput{
+		Title: input.Title,
+		Body:  input.Body,
+		Head:  input.Head,
+		Base:  input.Base,
+	}
+	out := new(pullRequest)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	if err != nil {
+		return convertPullRequest(out), res, err
+	}
+	// labels are added to the pull request as an issue
+	for _, label := range input.Labels {
+		if res, err := s.issues().AddLabel(ctx, repo, out.Number, label); err != nil {
+			return convertPullRequest(out), res, err
+		}
+	}
+	return convertPullRequest(out), res, nil
 }
 
 func (s *pullService) RequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
@@ -107,30 +204,97 @@ func (s *pullService) ClearMilestone(ctx context.Context, repo string, prID int)
 	return nil, scm.ErrNotSupported
 }
 
+// issues returns the issue service as gogs treats a pull request
+// as an issue for its comments and labels.
+func (s *pullService) issues() *issueService {
+	return &issueService{s.client}
+}
+
 //
 // native data structures
 //
 
 type pullRequest struct {
-	ID         int        `json:"id"`
-	Number     int        `json:"number"`
-	User       user       `json:"user"`
-	Title      string     `json:"title"`
-	Body       string     `json:"body"`
-	State      string     `json:"state"`
-	HeadBranch string     `json:"head_branch"`
-	HeadRepo   repository `json:"head_repo"`
-	BaseBranch string     `json:"base_branch"`
-	BaseRepo   repository `json:"base_repo"`
-	HTMLURL    string     `json:"html_url"`
-	Mergeable  bool       `json:"mergeable"`
-	Merged     bool       `json:"merged"`
+	ID             int        `json:"id"`
+	Number         int        `json:"number"`
+	User           user       `json:"user"`
+	Title          string     `json:"title"`
+	Body           string     `json:"body"`
+	Labels         []*label   `json:"labels"`
+	State          string     `json:"state"`
+	HeadBranch     string     `json:"head_branch"`
+	HeadRepo       repository `json:"head_repo"`
+	BaseBranch     string     `json:"base_branch"`
+	BaseRepo       repository `json:"base_repo"`
+	HTMLURL        string     `json:"html_url"`
+	Mergeable      bool       `json:"mergeable"`
+	Merged         bool       `json:"merged"`
+	MergeCommitSha string     `json:"merge_commit_sha"`
+	Created        time.Time  `json:"created_at"`
+	Updated        time.Time  `json:"updated_at"`
+}
+
+type pullRequestInput struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+}
+
+type pullRequestUpdateInput struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+	Base  string `json:"base,omitempty"`
+	State string `json:"state,omitempty"`
+}
+
+type pullRequestMergeInput struct {
+	Do      string `json:"do"`
+	Title   string `json:"merge_title_field,omitempty"`
+	Message string `json:"merge_message_field,omitempty"`
 }
 
 //
 // native data structure conversion
 //
 
+func convertPullRequestList(from []*pullRequest) []*scm.PullRequest {
+	to := []*scm.PullRequest{}
+	for _, v := range from {
+		to = append(to, convertPullRequest(v))
+	}
+	return to
+}
+
+func convertPullRequest(from *pullRequest) *scm.PullRequest {
+	fork := ""
+	if from.HeadRepo.FullName != from.BaseRepo.FullName {
+		fork = from.HeadRepo.FullName
+	}
+	return &scm.PullRequest{
+		Number:    from.Number,
+		Title:     from.Title,
+		Body:      from.Body,
+		Labels:    convertLabels(from.Labels),
+		Ref:       fmt.Sprintf("refs/pull/%d/head", from.Number),
+		Source:    from.HeadBranch,
+		Target:    from.BaseBranch,
+		Head:      scm.PullRequestBranch{Ref: from.HeadBranch, Repo: *convertRepository(&from.HeadRepo)},
+		Base:      scm.PullRequestBranch{Ref: from.BaseBranch, Repo: *convertRepository(&from.BaseRepo)},
+		Fork:      fork,
+		State:     from.State,
+		Closed:    from.State == "closed",
+		Merged:    from.Merged,
+		Mergeable: from.Mergeable,
+		MergeSha:  from.MergeCommitSha,
+		Author:    *convertUser(&from.User),
+		Link:      from.HTMLURL,
+		DiffLink:  from.HTMLURL + ".diff",
+		Created:   from.Created,
+		Updated:   from.Updated,
+	}
+}
+
 func convertPullRequestFromIssue(src *issue) *scm.PullRequest {
 	return &scm.PullRequest{
 		Number:  src.Number,
@@ -6,9 +6,13 @@ package gogs
 
 import (
 	"context"
+	"encoding/json"
+	"io/ioutil"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
 )
 
 //
@@ -16,6 +20,39 @@ import (
 //
 
 func TestPullRequestFind(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion("0.13.0")
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/pulls/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.PullRequests.Find(context.Background(), "gogits/gogs", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.PullRequest)
+	raw, _ := ioutil.ReadFile("testdata/pr.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullRequestFind_NoVersion(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/version").
+		Reply(404)
+
 	client, _ := New("https://try.gogs.io")
 	_, _, err := client.PullRequests.Find(context.Background(), "gogits/gogs", 1)
 	if err != scm.ErrNotSupported {
@@ -23,25 +60,193 @@ func TestPullRequestFind(t *testing.T) {
 	}
 }
 
-func TestPullRequestList(t *testing.T) {
+func TestPullRequestFind_OldVersion(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion("0.11.91")
+
 	client, _ := New("https://try.gogs.io")
-	_, _, err := client.PullRequests.List(context.Background(), "gogits/gogs", scm.PullRequestListOptions{})
+	_, _, err := client.PullRequests.Find(context.Background(), "gogits/gogs", 1)
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+	_, _, err = client.Repositories.ListStatus(context.Background(), "gogits/gogs", "master", scm.ListOptions{})
 	if err != scm.ErrNotSupported {
 		t.Errorf("Expect Not Supported error")
 	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the version to be requested once")
+	}
+}
+
+func TestPullRequestList(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion("0.13.0")
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/pulls").
+		MatchParam("state", "all").
+		MatchParam("page", "2").
+		Reply(200).
+		Type("application/json").
+		File("testdata/prs.json")
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.PullRequests.List(context.Background(), "gogits/gogs", scm.PullRequestListOptions{Page: 2, Open: true, Closed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.PullRequest{}
+	raw, _ := ioutil.ReadFile("testdata/prs.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullRequestUpdate(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion("0.13.0")
+
+	gock.New("https://try.gogs.io").
+		Patch("/api/v1/repos/gogits/gogs/pulls/1").
+		MatchType("json").
+		JSON(map[string]string{"title": "Add the contributing guide", "body": "Explains how to open a pull request."}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	input := &scm.PullRequestInput{
+		Title: "Add the contributing guide",
+		Body:  "Explains how to open a pull request.",
+	}
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.PullRequests.Update(context.Background(), "gogits/gogs", 1, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.PullRequest)
+	raw, _ := ioutil.ReadFile("testdata/pr.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
 }
 
 func TestPullRequestClose(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion("0.13.0")
+
+	gock.New("https://try.gogs.io").
+		Patch("/api/v1/repos/gogits/gogs/pulls/1").
+		MatchType("json").
+		JSON(map[string]string{"state": "closed"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
 	client, _ := New("https://try.gogs.io")
 	_, err := client.PullRequests.Close(context.Background(), "gogits/gogs", 1)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestPullRequestReopen(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion("0.13.0")
+
+	gock.New("https://try.gogs.io").
+		Patch("/api/v1/repos/gogits/gogs/pulls/1").
+		MatchType("json").
+		JSON(map[string]string{"state": "open"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	client, _ := New("https://try.gogs.io")
+	_, err := client.PullRequests.Reopen(context.Background(), "gogits/gogs", 1)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestPullRequestMerge(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion("0.13.0")
+
+	gock.New("https://try.gogs.io").
+		Post("/api/v1/repos/gogits/gogs/pulls/1/merge").
+		MatchType("json").
+		JSON(map[string]string{"do": "rebase", "merge_message_field": "Add the contributing guide"}).
+		Reply(200)
+
+	client, _ := New("https://try.gogs.io")
+	_, err := client.PullRequests.Merge(context.Background(), "gogits/gogs", 1, &scm.PullRequestMergeOptions{
+		MergeMethod:   "rebase",
+		CommitMessage: "Add the contributing guide",
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestPullRequestUpdateBranch(t *testing.T) {
+	client, _ := New("https://try.gogs.io")
+	_, err := client.PullRequests.UpdateBranch(context.Background(), "gogits/gogs", 1, "merge")
 	if err != scm.ErrNotSupported {
 		t.Errorf("Expect Not Supported error")
 	}
 }
 
-func TestPullRequestMerge(t *testing.T) {
+func TestPullRequestMarkReady(t *testing.T) {
+	client, _ := New("https://try.gogs.io")
+	_, err := client.PullRequests.MarkReady(context.Background(), "gogits/gogs", 1)
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
+
+func TestPullRequestConvertToDraft(t *testing.T) {
 	client, _ := New("https://try.gogs.io")
-	_, err := client.PullRequests.Merge(context.Background(), "gogits/gogs", 1, nil)
+	_, err := client.PullRequests.ConvertToDraft(context.Background(), "gogits/gogs", 1)
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
+
+func TestPullRequestEnableAutoMerge(t *testing.T) {
+	client, _ := New("https://try.gogs.io")
+	_, err := client.PullRequests.EnableAutoMerge(context.Background(), "gogits/gogs", 1, nil)
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
+
+func TestPullRequestDisableAutoMerge(t *testing.T) {
+	client, _ := New("https://try.gogs.io")
+	_, err := client.PullRequests.DisableAutoMerge(context.Background(), "gogits/gogs", 1)
 	if err != scm.ErrNotSupported {
 		t.Errorf("Expect Not Supported error")
 	}
@@ -72,30 +277,82 @@ func TestPullRequestCommentFind(t *testing.T) {
 }
 
 func TestPullRequestCommentList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/issues/1/comments").
+		Reply(200).
+		Type("application/json").
+		File("testdata/comments.json")
+
 	client, _ := New("https://try.gogs.io")
-	_, _, err := client.PullRequests.ListComments(context.Background(), "gogits/gogs", 1, scm.ListOptions{})
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	got, _, err := client.PullRequests.ListComments(context.Background(), "gogits/gogs", 1, scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Comment{}
+	raw, _ := ioutil.ReadFile("testdata/comments.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
 
 func TestPullRequestCommentCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Post("/api/v1/repos/gogits/gogs/issues/1/comments").
+		Reply(201).
+		Type("application/json").
+		File("testdata/comment.json")
+
 	client, _ := New("https://try.gogs.io")
-	_, _, err := client.PullRequests.CreateComment(context.Background(), "gogits/gogs", 1, &scm.CommentInput{})
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	got, _, err := client.PullRequests.CreateComment(context.Background(), "gogits/gogs", 1, &scm.CommentInput{Body: "what?"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Comment)
+	raw, _ := ioutil.ReadFile("testdata/comment.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
 
 func TestPullRequestCommentDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Delete("/api/v1/repos/gogits/gogs/issues/1/comments/1").
+		Reply(204)
+
 	client, _ := New("https://try.gogs.io")
 	_, err := client.PullRequests.DeleteComment(context.Background(), "gogits/gogs", 1, 1)
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	if err != nil {
+		t.Error(err)
 	}
 }
 
 func TestPullCreate(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion("0.13.0")
+
+	gock.New("https://try.gogs.io").
+		Post("/api/v1/repos/gogits/gogs/pulls").
+		MatchType("json").
+		JSON(map[string]string{"title": "Gogs feature", "body": "New Gogs feature", "head": "new-feature", "base": "master"}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr.json")
+
 	client, _ := New("https://try.gogs.io")
 	input := &scm.PullRequestInput{
 		Title: "Gogs feature",
@@ -104,8 +361,123 @@ func TestPullCreate(t *testing.T) {
 		Base:  "master",
 	}
 
-	_, _, err := client.PullRequests.Create(context.Background(), "gogits/gogs", input)
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	got, _, err := client.PullRequests.Create(context.Background(), "gogits/gogs", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.PullRequest)
+	raw, _ := ioutil.ReadFile("testdata/pr.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
+
+func TestPullCreate_Assignees(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion("0.13.0")
+
+	client, _ := New("https://try.gogs.io")
+	input := &scm.PullRequestInput{
+		Title:     "Gogs feature",
+		Head:      "new-feature",
+		Base:      "master",
+		Assignees: []string{"gogs"},
+	}
+	if _, _, err := client.PullRequests.Create(context.Background(), "gogits/gogs", input); err != scm.ErrNotSupported {
+		t.Errorf("Want ErrNotSupported creating a pull request with assignees, got %v", err)
+	}
+}
+
+//
+// pull request label sub-tests
+//
+
+func TestPullRequestAddLabel(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/labels").
+		Reply(200).
+		Type("application/json").
+		File("testdata/labels.json")
+
+	gock.New("https://try.gogs.io").
+		Post("/api/v1/repos/gogits/gogs/issues/1/labels").
+		MatchType("json").
+		JSON(map[string][]int{"labels": {2}}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/labels.json")
+
+	client, _ := New("https://try.gogs.io")
+	_, err := client.PullRequests.AddLabel(context.Background(), "gogits/gogs", 1, "enhancement")
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestPullRequestDeleteLabel(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/labels").
+		Reply(200).
+		Type("application/json").
+		File("testdata/labels.json")
+
+	gock.New("https://try.gogs.io").
+		Delete("/api/v1/repos/gogits/gogs/issues/1/labels/1").
+		Reply(204)
+
+	client, _ := New("https://try.gogs.io")
+	_, err := client.PullRequests.DeleteLabel(context.Background(), "gogits/gogs", 1, "bug")
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestPullRequestListLabels(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/issues/1/labels").
+		Reply(200).
+		Type("application/json").
+		File("testdata/labels.json")
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.PullRequests.ListLabels(context.Background(), "gogits/gogs", 1, scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Label{}
+	raw, _ := ioutil.ReadFile("testdata/labels.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+// mockServerVersion mocks the version endpoint which guards
+// the pull request and commit status endpoints.
+func mockServerVersion(v string) {
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/version").
+		Reply(200).
+		Type("application/json").
+		JSON(map[string]string{"version": v})
+}
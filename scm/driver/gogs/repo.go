@@ -7,6 +7,7 @@ package gogs
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 	"time"
 
@@ -17,8 +18,19 @@ type repositoryService struct {
 	client *wrapper
 }
 
-func (s *repositoryService) Create(context.Context, *scm.RepositoryInput) (*scm.Repository, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *repositoryService) Create(ctx context.Context, input *scm.RepositoryInput) (*scm.Repository, *scm.Response, error) {
+	path := "api/v1/user/repos"
+	if input.Namespace != "" {
+		path = fmt.Sprintf("api/v1/org/%s/repos", input.Namespace)
+	}
+	in := &repositoryInput{
+		Name:        input.Name,
+		Description: input.Description,
+		Private:     input.Private,
+	}
+	out := new(repository)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertRepository(out), res, err
 }
 
 func (s *repositoryService) Fork(context.Context, *scm.RepositoryInput, string) (*scm.Repository, *scm.Response, error) {
@@ -26,27 +38,64 @@ func (s *repositoryService) Fork(context.Context, *scm.RepositoryInput, string)
 }
 
 func (s *repositoryService) FindCombinedStatus(ctx context.Context, repo, ref string) (*scm.CombinedStatus, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	if err := s.client.checkVersion(ctx, minPullRequestVersion); err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/commits/%s/status", repo, ref)
+	out := new(combinedStatus)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertCombinedStatus(out), res, err
 }
 
 func (s *repositoryService) FindUserPermission(ctx context.Context, repo string, user string) (string, *scm.Response, error) {
-	return "", nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v1/repos/%s/collaborators", repo)
+	out := []*collaborator{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	if err != nil {
+		return "", res, err
+	}
+	for _, v := range out {
+		if v.Login == user || v.Username == user {
+			return convertCollaboratorPermission(v.Permissions), res, nil
+		}
+	}
+	return scm.NoPermission, res, nil
 }
 
 func (s *repositoryService) AddCollaborator(ctx context.Context, repo, user, permission string) (bool, bool, *scm.Response, error) {
-	return false, false, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v1/repos/%s/collaborators/%s", repo, user)
+	in := &collaboratorInput{Permission: permission}
+	res, err := s.client.do(ctx, "PUT", path, in, nil)
+	if err != nil {
+		return false, false, res, err
+	}
+	return true, false, res, nil
 }
 
 func (s *repositoryService) IsCollaborator(ctx context.Context, repo, user string) (bool, *scm.Response, error) {
-	return false, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v1/repos/%s/collaborators/%s", repo, user)
+	res, err := s.client.do(ctx, "GET", path, nil, nil)
+	if res != nil && res.Status == http.StatusNotFound {
+		return false, res, nil
+	}
+	if err != nil {
+		return false, res, err
+	}
+	return res.Status == http.StatusNoContent, res, nil
 }
 
 func (s *repositoryService) ListCollaborators(ctx context.Context, repo string, ops scm.ListOptions) ([]scm.User, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v1/repos/%s/collaborators", repo)
+	out := []*collaborator{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertCollaboratorList(out), res, err
 }
 
-func (s *repositoryService) ListLabels(context.Context, string, scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *repositoryService) ListLabels(ctx context.Context, repo string, _ scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/labels", repo)
+	out := []*label{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertLabels(out), res, err
 }
 
 func (s *repositoryService) Find(ctx context.Context, repo string) (*scm.Repository, *scm.Response, error) {
@@ -97,8 +146,14 @@ func (s *repositoryService) ListHooks(ctx context.Context, repo string, _ scm.Li
 	return convertHookList(out), res, err
 }
 
-func (s *repositoryService) ListStatus(context.Context, string, string, scm.ListOptions) ([]*scm.Status, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *repositoryService) ListStatus(ctx context.Context, repo string, ref string, _ scm.ListOptions) ([]*scm.Status, *scm.Response, error) {
+	if err := s.client.checkVersion(ctx, minPullRequestVersion); err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/statuses/%s", repo, ref)
+	out := []*status{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertStatusList(out), res, err
 }
 
 func (s *repositoryService) CreateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
@@ -122,8 +177,20 @@ func (s *repositoryService) UpdateHook(ctx context.Context, repo string, input *
 	return nil, nil, scm.ErrNotSupported
 }
 
-func (s *repositoryService) CreateStatus(context.Context, string, string, *scm.StatusInput) (*scm.Status, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *repositoryService) CreateStatus(ctx context.Context, repo string, ref string, input *scm.StatusInput) (*scm.Status, *scm.Response, error) {
+	if err := s.client.checkVersion(ctx, minPullRequestVersion); err != nil {
+		return nil, nil, err
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/statuses/%s", repo, ref)
+	in := &statusInput{
+		State:       convertFromState(input.State),
+		Context:     input.Label,
+		Description: input.Desc,
+		TargetURL:   input.Target,
+	}
+	out := new(status)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertStatus(out), res, err
 }
 
 func (s *repositoryService) DeleteHook(ctx context.Context, repo string, id string) (*scm.Response, error) {
@@ -157,6 +224,57 @@ type (
 		Permissions   perm      `json:"permissions"`
 	}
 
+	// gogs repository request object.
+	repositoryInput struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		Private     bool   `json:"private"`
+	}
+
+	// gogs collaborator resource.
+	collaborator struct {
+		user
+		Permissions perm `json:"permissions"`
+	}
+
+	// gogs collaborator request object.
+	collaboratorInput struct {
+		Permission string `json:"permission,omitempty"`
+	}
+
+	// gogs commit status resource.
+	status struct {
+		ID          int       `json:"id"`
+		State       string    `json:"status"`
+		TargetURL   string    `json:"target_url"`
+		Description string    `json:"description"`
+		Context     string    `json:"context"`
+		CreatedAt   time.Time `json:"created_at"`
+		UpdatedAt   time.Time `json:"updated_at"`
+	}
+
+	// gogs commit status request object.
+	statusInput struct {
+		State       string `json:"state"`
+		TargetURL   string `json:"target_url"`
+		Description string `json:"description"`
+		Context     string `json:"context"`
+	}
+
+	// gogs combined commit status resource.
+	combinedStatus struct {
+		State    string    `json:"state"`
+		Sha      string    `json:"sha"`
+		Statuses []*status `json:"statuses"`
+	}
+
+	// gogs label resource.
+	label struct {
+		ID    int64  `json:"id"`
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	}
+
 	// gogs permissions details.
 	perm struct {
 		Admin bool `json:"admin"`
@@ -255,3 +373,91 @@ func convertHookEvent(from scm.HookEvents) []string {
 	}
 	return events
 }
+
+func convertCollaboratorList(src []*collaborator) []scm.User {
+	dst := []scm.User{}
+	for _, v := range src {
+		dst = append(dst, *convertUser(&v.user))
+	}
+	return dst
+}
+
+func convertCollaboratorPermission(src perm) string {
+	switch {
+	case src.Admin:
+		return scm.AdminPermission
+	case src.Push:
+		return scm.WritePermission
+	case src.Pull:
+		return scm.ReadPermission
+	default:
+		return scm.NoPermission
+	}
+}
+
+func convertCombinedStatus(from *combinedStatus) *scm.CombinedStatus {
+	return &scm.CombinedStatus{
+		Sha:      from.Sha,
+		State:    convertState(from.State),
+		Statuses: convertStatusList(from.Statuses),
+	}
+}
+
+func convertStatusList(from []*status) []*scm.Status {
+	to := []*scm.Status{}
+	for _, v := range from {
+		to = append(to, convertStatus(v))
+	}
+	return to
+}
+
+func convertStatus(from *status) *scm.Status {
+	return &scm.Status{
+		State:   convertState(from.State),
+		Label:   from.Context,
+		Desc:    from.Description,
+		Target:  from.TargetURL,
+		Created: from.CreatedAt,
+		Updated: from.UpdatedAt,
+	}
+}
+
+func convertState(from string) scm.State {
+	switch from {
+	case "error":
+		return scm.StateError
+	case "failure":
+		return scm.StateFailure
+	case "pending":
+		return scm.StatePending
+	case "success":
+		return scm.StateSuccess
+	default:
+		return scm.StateUnknown
+	}
+}
+
+func convertFromState(from scm.State) string {
+	switch from {
+	case scm.StatePending, scm.StateRunning:
+		return "pending"
+	case scm.StateSuccess:
+		return "success"
+	case scm.StateFailure:
+		return "failure"
+	default:
+		return "error"
+	}
+}
+
+func convertLabels(from []*label) []*scm.Label {
+	to := []*scm.Label{}
+	for _, v := range from {
+		to = append(to, &scm.Label{
+			ID:    v.ID,
+			Name:  v.Name,
+			Color: v.Color,
+		})
+	}
+	return to
+}
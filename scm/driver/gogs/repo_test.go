@@ -44,6 +44,58 @@ func TestRepoFind(t *testing.T) {
 	}
 }
 
+func TestRepoCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Post("/api/v1/org/gogits/repos").
+		MatchType("json").
+		JSON(map[string]interface{}{"name": "gogs", "private": true}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	input := &scm.RepositoryInput{
+		Namespace: "gogits",
+		Name:      "gogs",
+		Private:   true,
+	}
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Repositories.Create(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Repository)
+	raw, _ := ioutil.ReadFile("testdata/repo.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepoCreate_User(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Post("/api/v1/user/repos").
+		Reply(201).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	client, _ := New("https://try.gogs.io")
+	_, _, err := client.Repositories.Create(context.Background(), &scm.RepositoryInput{Name: "gogs"})
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
 func TestRepoFindPerm(t *testing.T) {
 	defer gock.Off()
 
@@ -267,17 +319,244 @@ func TestHookEvents(t *testing.T) {
 //
 
 func TestStatusList(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion("0.13.0")
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/statuses/master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/statuses.json")
+
 	client, _ := New("https://try.gogs.io")
-	_, _, err := client.Repositories.ListStatus(context.Background(), "gogits/gogs", "master", scm.ListOptions{})
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	got, _, err := client.Repositories.ListStatus(context.Background(), "gogits/gogs", "master", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Status{}
+	raw, _ := ioutil.ReadFile("testdata/statuses.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
 
 func TestStatusCreate(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion("0.13.0")
+
+	gock.New("https://try.gogs.io").
+		Post("/api/v1/repos/gogits/gogs/statuses/master").
+		MatchType("json").
+		JSON(map[string]string{
+			"state":       "success",
+			"context":     "ci/build",
+			"description": "the build succeeded",
+			"target_url":  "https://ci.example.com/gogits/gogs/1",
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/status.json")
+
+	input := &scm.StatusInput{
+		State:  scm.StateSuccess,
+		Label:  "ci/build",
+		Desc:   "the build succeeded",
+		Target: "https://ci.example.com/gogits/gogs/1",
+	}
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Repositories.CreateStatus(context.Background(), "gogits/gogs", "master", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Status)
+	raw, _ := ioutil.ReadFile("testdata/status.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestCombinedStatusFind(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion("0.13.0")
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/commits/master/status").
+		Reply(200).
+		Type("application/json").
+		File("testdata/combined_status.json")
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Repositories.FindCombinedStatus(context.Background(), "gogits/gogs", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.CombinedStatus)
+	raw, _ := ioutil.ReadFile("testdata/combined_status.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestStatusList_NoVersion(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/version").
+		Reply(404)
+
 	client, _ := New("https://try.gogs.io")
-	_, _, err := client.Repositories.CreateStatus(context.Background(), "gogits/gogs", "master", &scm.StatusInput{})
+	_, _, err := client.Repositories.ListStatus(context.Background(), "gogits/gogs", "master", scm.ListOptions{})
 	if err != scm.ErrNotSupported {
 		t.Errorf("Expect Not Supported error")
 	}
 }
+
+//
+// label sub-tests
+//
+
+func TestRepositoryListLabels(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/labels").
+		Reply(200).
+		Type("application/json").
+		File("testdata/labels.json")
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Repositories.ListLabels(context.Background(), "gogits/gogs", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Label{}
+	raw, _ := ioutil.ReadFile("testdata/labels.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+//
+// collaborator sub-tests
+//
+
+func TestRepositoryListCollaborators(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/collaborators").
+		Reply(200).
+		Type("application/json").
+		File("testdata/collaborators.json")
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Repositories.ListCollaborators(context.Background(), "gogits/gogs", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []scm.User{}
+	raw, _ := ioutil.ReadFile("testdata/collaborators.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepositoryIsCollaborator(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/collaborators/janedoe").
+		Reply(204)
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/collaborators/johndoe").
+		Reply(404)
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Repositories.IsCollaborator(context.Background(), "gogits/gogs", "janedoe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got {
+		t.Errorf("Expect janedoe to be a collaborator")
+	}
+
+	got, _, err = client.Repositories.IsCollaborator(context.Background(), "gogits/gogs", "johndoe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got {
+		t.Errorf("Expect johndoe not to be a collaborator")
+	}
+}
+
+func TestRepositoryAddCollaborator(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Put("/api/v1/repos/gogits/gogs/collaborators/janedoe").
+		MatchType("json").
+		JSON(map[string]string{"permission": "write"}).
+		Reply(204)
+
+	client, _ := New("https://try.gogs.io")
+	added, alreadyExisted, _, err := client.Repositories.AddCollaborator(context.Background(), "gogits/gogs", "janedoe", "write")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !added || alreadyExisted {
+		t.Errorf("Expect the collaborator to be added")
+	}
+}
+
+func TestRepositoryFindUserPermission(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Get("/api/v1/repos/gogits/gogs/collaborators").
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		File("testdata/collaborators.json")
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Repositories.FindUserPermission(context.Background(), "gogits/gogs", "janedoe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := scm.WritePermission; got != want {
+		t.Errorf("Want permission %q, got %q", want, got)
+	}
+
+	got, _, err = client.Repositories.FindUserPermission(context.Background(), "gogits/gogs", "johndoe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := scm.NoPermission; got != want {
+		t.Errorf("Want permission %q, got %q", want, got)
+	}
+}
@@ -42,3 +42,27 @@ func TestReviewDelete(t *testing.T) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
+
+func TestReviewCreateComment(t *testing.T) {
+	client, _ := New("https://try.gogs.io")
+	_, _, err := client.Reviews.CreateComment(context.Background(), "gogits/gogs", 1, &scm.ReviewCommentInput{})
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
+
+func TestReviewListThreads(t *testing.T) {
+	client, _ := New("https://try.gogs.io")
+	_, _, err := client.Reviews.ListThreads(context.Background(), "gogits/gogs", 1, scm.ListOptions{})
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
+
+func TestReviewResolveThread(t *testing.T) {
+	client, _ := New("https://try.gogs.io")
+	_, err := client.Reviews.ResolveThread(context.Background(), "gogits/gogs", 1, "1")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
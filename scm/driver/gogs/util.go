@@ -0,0 +1,27 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gogs
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+func encodePullRequestListOptions(opts scm.PullRequestListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Open && opts.Closed {
+		params.Set("state", "all")
+	} else if opts.Closed {
+		params.Set("state", "closed")
+	} else {
+		params.Set("state", "open")
+	}
+	return params.Encode()
+}
@@ -0,0 +1,27 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gogs
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+func Test_encodePullRequestListOptions(t *testing.T) {
+	tests := []struct {
+		opts scm.PullRequestListOptions
+		want string
+	}{
+		{opts: scm.PullRequestListOptions{Open: true}, want: "state=open"},
+		{opts: scm.PullRequestListOptions{Closed: true}, want: "state=closed"},
+		{opts: scm.PullRequestListOptions{Open: true, Closed: true, Page: 3}, want: "page=3&state=all"},
+	}
+	for _, test := range tests {
+		if got := encodePullRequestListOptions(test.opts); got != test.want {
+			t.Errorf("Want encoded %q, got %q", test.want, got)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Package draft marks pull requests as drafts with a prefix of their
+// title for providers which have no draft flag.
+package draft
+
+import "strings"
+
+// Prefixes are the case insensitive title prefixes which mark a pull
+// request as a draft.
+type Prefixes []string
+
+// Match returns true if the title starts with one of the prefixes.
+func (p Prefixes) Match(title string) bool {
+	lower := strings.ToLower(title)
+	for _, prefix := range p {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Trim removes the prefixes from the start of the title.
+func (p Prefixes) Trim(title string) string {
+	for p.Match(title) {
+		lower := strings.ToLower(title)
+		for _, prefix := range p {
+			if strings.HasPrefix(lower, prefix) {
+				title = strings.TrimSpace(title[len(prefix):])
+				break
+			}
+		}
+	}
+	return title
+}
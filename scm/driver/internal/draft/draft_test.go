@@ -0,0 +1,24 @@
+package draft
+
+import "testing"
+
+func TestPrefixes(t *testing.T) {
+	prefixes := Prefixes{"wip:", "[wip]"}
+	tests := map[string]string{
+		"JS fix":           "JS fix",
+		"WIP: JS fix":      "JS fix",
+		"[wip] WIP: fix":   "fix",
+		"Wipe the cache":   "Wipe the cache",
+		"Draft: JS fix":    "Draft: JS fix",
+		"wip:":             "",
+		"  WIP: not first": "  WIP: not first",
+	}
+	for title, want := range tests {
+		if got := prefixes.Trim(title); got != want {
+			t.Errorf("Want title %q for %q, got %q", want, title, got)
+		}
+		if got, want := prefixes.Match(title), title != want; got != want {
+			t.Errorf("Want match %t for %q, got %t", want, title, got)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Package mergebase finds the merge base of two refs for providers which
+// only report the commits reachable from one ref but not the other.
+package mergebase
+
+// Commit is a commit reachable from only one side of a comparison.
+type Commit struct {
+	Sha     string
+	Parents []string
+}
+
+// Find returns the merge base of the head and base refs given the
+// commits reachable from the head but not the base, and the commits
+// reachable from the base but not the head.
+//
+// The parents of either side outside of its commits are common
+// ancestors, so the merge base is the one parent of both sides. If the
+// sides share no such parent, such as when a side merged older commits
+// of the other, the first common ancestor of the head side is used.
+// It returns an empty string if either side has no commits, in which
+// case the merge base is the ref of that side itself.
+func Find(ahead, behind []Commit) string {
+	if len(ahead) == 0 || len(behind) == 0 {
+		return ""
+	}
+	boundary := parents(ahead)
+	for _, sha := range parents(behind) {
+		for _, v := range boundary {
+			if sha == v {
+				return sha
+			}
+		}
+	}
+	if len(boundary) == 0 {
+		return ""
+	}
+	return boundary[0]
+}
+
+// parents returns the parents of the commits which are not one of
+// the commits in the order they are found
+func parents(commits []Commit) []string {
+	seen := map[string]bool{}
+	for _, c := range commits {
+		seen[c.Sha] = true
+	}
+	var shas []string
+	for _, c := range commits {
+		for _, p := range c.Parents {
+			if !seen[p] {
+				seen[p] = true
+				shas = append(shas, p)
+			}
+		}
+	}
+	return shas
+}
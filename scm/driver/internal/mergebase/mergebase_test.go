@@ -0,0 +1,41 @@
+package mergebase
+
+import "testing"
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name          string
+		ahead, behind []Commit
+		want          string
+	}{
+		{
+			name: "diverged",
+			ahead: []Commit{
+				{Sha: "b1", Parents: []string{"a"}},
+				{Sha: "b2", Parents: []string{"b1"}},
+			},
+			behind: []Commit{{Sha: "c1", Parents: []string{"a"}}},
+			want:   "a",
+		},
+		{
+			name: "merged an older commit of the base",
+			ahead: []Commit{
+				{Sha: "b1", Parents: []string{"z"}},
+				{Sha: "b2", Parents: []string{"b1", "a"}},
+			},
+			behind: []Commit{{Sha: "c1", Parents: []string{"a"}}},
+			want:   "a",
+		},
+		{
+			name:   "up to date",
+			ahead:  []Commit{{Sha: "b1", Parents: []string{"a"}}},
+			behind: nil,
+			want:   "",
+		},
+	}
+	for _, test := range tests {
+		if got := Find(test.ahead, test.behind); got != test.want {
+			t.Errorf("%s: want merge base %q, got %q", test.name, test.want, got)
+		}
+	}
+}
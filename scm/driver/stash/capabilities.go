@@ -0,0 +1,61 @@
+package stash
+
+import "github.com/jenkins-x/go-scm/scm"
+
+// unsupported lists the features of the services of the
+// client which return scm.ErrNotSupported.
+//
+// Pull requests never report their AutoMerge as Bitbucket Server does not
+// return it, although EnableAutoMerge and DisableAutoMerge are supported.
+var unsupported = []scm.Feature{
+	"Contents.Delete",
+	"Issues.AssignIssue",
+	"Issues.ClearMilestone",
+	"Issues.Close",
+	"Issues.Create",
+	"Issues.DeleteComment",
+	"Issues.EditComment",
+	"Issues.Find",
+	"Issues.FindComment",
+	"Issues.List",
+	"Issues.ListComments",
+	"Issues.ListEvents",
+	"Issues.ListLabels",
+	"Issues.Lock",
+	"Issues.Reopen",
+	"Issues.Search",
+	"Issues.SetMilestone",
+	"Issues.UnassignIssue",
+	"Issues.Unlock",
+	"Milestones.Create",
+	"Milestones.Delete",
+	"Milestones.Find",
+	"Milestones.List",
+	"Milestones.Update",
+	"Organizations.AcceptOrganizationInvitation",
+	"Organizations.Create",
+	"Organizations.Delete",
+	"Organizations.Find",
+	"Organizations.ListMemberships",
+	"Organizations.ListPendingInvitations",
+	"Organizations.ListTeamMembers",
+	"Organizations.ListTeams",
+	"PullRequests.ClearMilestone",
+	"PullRequests.ListEvents",
+	"PullRequests.SetMilestone",
+	"PullRequests.UpdateBranch",
+	"Repositories.Delete",
+	"Repositories.ListOrganisation",
+	"Repositories.ListUser",
+	"Repositories.UpdateHook",
+	"Reviews.Create",
+	"Reviews.Delete",
+	"Reviews.Dismiss",
+	"Reviews.Find",
+	"Reviews.List",
+	"Reviews.ListComments",
+	"Reviews.Submit",
+	"Reviews.Update",
+	"Users.CreateToken",
+	"Users.DeleteToken",
+}
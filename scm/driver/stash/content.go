@@ -9,6 +9,9 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -29,17 +32,167 @@ func (s *contentService) Find(ctx context.Context, repo, path, ref string) (*scm
 }
 
 func (s *contentService) List(ctx context.Context, repo, path, ref string) ([]*scm.FileEntry, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	namespace, name := scm.Split(repo)
+	params := url.Values{}
+	if ref != "" {
+		params.Set("at", ref)
+	}
+	var answer []*scm.FileEntry
+	var res *scm.Response
+	for {
+		endpoint := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/browse/%s?%s", namespace, name, path, params.Encode())
+		out := new(contents)
+		var err error
+		res, err = s.client.do(ctx, "GET", endpoint, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		answer = append(answer, convertContentList(path, out)...)
+		if out.Children.LastPage.Bool || !out.Children.NextPage.Valid {
+			break
+		}
+		params.Set("start", strconv.FormatInt(out.Children.NextPage.Int64, 10))
+	}
+	return answer, res, nil
 }
 
 func (s *contentService) Create(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	namespace, name := scm.Split(repo)
+	endpoint := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/browse/%s", namespace, name, path)
+	in := form{
+		"branch":  params.Branch,
+		"content": string(params.Data),
+		"message": params.Message,
+	}
+	return s.client.do(ctx, "PUT", endpoint, in, nil)
 }
 
+// Update edits the file on the branch. Stash rejects edits which are not
+// based on the latest commit changing the file, which is sent as the sha
+// of the params. When no sha is given the latest commit is looked up.
 func (s *contentService) Update(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	sha := params.Sha
+	if sha == "" {
+		commits, res, err := s.client.Git.ListCommits(ctx, repo, scm.CommitListOptions{Ref: params.Branch, Path: path, Size: 1})
+		if err != nil {
+			return res, err
+		}
+		if len(commits) == 0 {
+			return res, scm.ErrNotFound
+		}
+		sha = commits[0].Sha
+	}
+	namespace, name := scm.Split(repo)
+	endpoint := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/browse/%s", namespace, name, path)
+	in := form{
+		"branch":         params.Branch,
+		"content":        string(params.Data),
+		"message":        params.Message,
+		"sourceCommitId": sha,
+	}
+	return s.client.do(ctx, "PUT", endpoint, in, nil)
 }
 
-func (s *contentService) Delete(ctx context.Context, repo, path, ref string) (*scm.Response, error) {
+// Delete is not supported as the stash REST API has no endpoint
+// to remove a file from a branch.
+func (s *contentService) Delete(ctx context.Context, repo, path string, params *scm.ContentParams) (*scm.Response, error) {
 	return nil, scm.ErrNotSupported
 }
+
+func (s *contentService) Blame(ctx context.Context, repo, path, ref string) ([]*scm.BlameRange, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	endpoint := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/browse/%s?at=%s&blame=true&noContent=true", namespace, name, path, url.QueryEscape(ref))
+	out := []*blame{}
+	res, err := s.client.do(ctx, "GET", endpoint, nil, &out)
+	return convertBlameList(out), res, err
+}
+
+type contents struct {
+	Path     diffpath `json:"path"`
+	Revision string   `json:"revision"`
+	Children struct {
+		pagination
+		Values []*content `json:"values"`
+	} `json:"children"`
+}
+
+type content struct {
+	Path      diffpath `json:"path"`
+	ContentID string   `json:"contentId"`
+	Type      string   `json:"type"`
+	Size      int      `json:"size"`
+}
+
+type blame struct {
+	Author             user   `json:"author"`
+	AuthorTimestamp    int64  `json:"authorTimestamp"`
+	Committer          user   `json:"committer"`
+	CommitterTimestamp int64  `json:"committerTimestamp"`
+	CommitHash         string `json:"commitHash"`
+	CommitID           string `json:"commitId"`
+	LineNumber         int    `json:"lineNumber"`
+	SpannedLines       int    `json:"spannedLines"`
+}
+
+func convertContentList(dir string, from *contents) []*scm.FileEntry {
+	to := []*scm.FileEntry{}
+	for _, v := range from.Children.Values {
+		to = append(to, convertContent(dir, v))
+	}
+	return to
+}
+
+func convertContent(dir string, from *content) *scm.FileEntry {
+	to := &scm.FileEntry{
+		Name: from.Path.Name,
+		Path: from.Path.ToString,
+		Size: from.Size,
+		Sha:  from.ContentID,
+	}
+	// the paths of the children are relative to the directory
+	if dir != "" {
+		to.Path = strings.TrimSuffix(dir, "/") + "/" + to.Path
+	}
+	switch from.Type {
+	case "DIRECTORY":
+		to.Type = "dir"
+	case "SUBMODULE":
+		to.Type = "submodule"
+	default:
+		to.Type = "file"
+	}
+	return to
+}
+
+func convertBlameList(from []*blame) []*scm.BlameRange {
+	to := []*scm.BlameRange{}
+	for _, v := range from {
+		// older versions report the commit id instead of the hash
+		sha := v.CommitHash
+		if sha == "" {
+			sha = v.CommitID
+		}
+		to = append(to, &scm.BlameRange{
+			StartLine: v.LineNumber,
+			EndLine:   v.LineNumber + v.SpannedLines - 1,
+			Commit: scm.Commit{
+				Sha: sha,
+				Author: scm.Signature{
+					Name:   v.Author.DisplayName,
+					Email:  v.Author.EmailAddress,
+					Date:   time.Unix(0, v.AuthorTimestamp*int64(time.Millisecond)),
+					Login:  v.Author.Slug,
+					Avatar: avatarLink(v.Author.EmailAddress),
+				},
+				Committer: scm.Signature{
+					Name:   v.Committer.DisplayName,
+					Email:  v.Committer.EmailAddress,
+					Date:   time.Unix(0, v.CommitterTimestamp*int64(time.Millisecond)),
+					Login:  v.Committer.Slug,
+					Avatar: avatarLink(v.Committer.EmailAddress),
+				},
+			},
+		})
+	}
+	return to
+}
@@ -42,26 +42,162 @@ func TestContentFind(t *testing.T) {
 	}
 }
 
+func TestContentList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/browse/docs").
+		MatchParam("at", "master").
+		MatchParam("start", "2").
+		Reply(200).
+		Type("application/json").
+		File("testdata/content_list_2.json")
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/browse/docs").
+		MatchParam("at", "master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/content_list.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Contents.List(context.Background(), "PRJ/my-repo", "docs", "master")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.FileEntry{}
+	raw, _ := ioutil.ReadFile("testdata/content_list.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestContentCreate(t *testing.T) {
-	content := new(contentService)
-	_, err := content.Create(context.Background(), "atlassian/atlaskit", "README", nil)
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Put("/rest/api/1.0/projects/PRJ/repos/my-repo/browse/docs/install.md").
+		MatchHeader("Content-Type", "^multipart/form-data").
+		BodyString("document the installation").
+		Reply(200).
+		Type("application/json").
+		File("testdata/content_update.json")
+
+	client, _ := New("http://example.com:7990")
+	params := &scm.ContentParams{
+		Branch:  "master",
+		Message: "document the installation",
+		Data:    []byte("# Installation"),
+	}
+	_, err := client.Contents.Create(context.Background(), "PRJ/my-repo", "docs/install.md", params)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the file to be created")
 	}
 }
 
 func TestContentUpdate(t *testing.T) {
-	content := new(contentService)
-	_, err := content.Update(context.Background(), "atlassian/atlaskit", "README", nil)
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Put("/rest/api/1.0/projects/PRJ/repos/my-repo/browse/README.md").
+		MatchHeader("Content-Type", "^multipart/form-data").
+		BodyString("e69de29bb2d1d6434b8b29ae775ad8c2e48c5391").
+		Reply(200).
+		Type("application/json").
+		File("testdata/content_update.json")
+
+	client, _ := New("http://example.com:7990")
+	params := &scm.ContentParams{
+		Branch:  "master",
+		Message: "update the readme",
+		Data:    []byte("# Hello World"),
+		Sha:     "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391",
+	}
+	_, err := client.Contents.Update(context.Background(), "PRJ/my-repo", "README.md", params)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the file to be updated from the given commit")
+	}
+}
+
+func TestContentUpdate_LatestCommit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/commits").
+		MatchParam("until", "master").
+		MatchParam("path", "README.md").
+		MatchParam("limit", "1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/commits.json")
+
+	gock.New("http://example.com:7990").
+		Put("/rest/api/1.0/projects/PRJ/repos/my-repo/browse/README.md").
+		MatchHeader("Content-Type", "^multipart/form-data").
+		BodyString("131cb13f4aed12e725177bc4b7c28db67839bf9f").
+		Reply(200).
+		Type("application/json").
+		File("testdata/content_update.json")
+
+	client, _ := New("http://example.com:7990")
+	params := &scm.ContentParams{
+		Branch:  "master",
+		Message: "update the readme",
+		Data:    []byte("# Hello World"),
+	}
+	_, err := client.Contents.Update(context.Background(), "PRJ/my-repo", "README.md", params)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the file to be updated from its latest commit")
 	}
 }
 
 func TestContentDelete(t *testing.T) {
 	content := new(contentService)
-	_, err := content.Delete(context.Background(), "atlassian/atlaskit", "README", "master")
+	_, err := content.Delete(context.Background(), "atlassian/atlaskit", "README", &scm.ContentParams{Branch: "master"})
 	if err != scm.ErrNotSupported {
 		t.Errorf("Expect Not Supported error")
 	}
 }
+
+func TestContentBlame(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/browse/README.md").
+		MatchParam("at", "master").
+		MatchParam("blame", "true").
+		MatchParam("noContent", "true").
+		Reply(200).
+		Type("application/json").
+		File("testdata/content_blame.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Contents.Blame(context.Background(), "PRJ/my-repo", "README.md", "master")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.BlameRange{}
+	raw, _ := ioutil.ReadFile("testdata/content_blame.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
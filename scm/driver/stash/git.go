@@ -8,14 +8,15 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/mergebase"
 )
 
 // TODO(bradrydzewski) commit link is an empty string.
-// TODO(bradrydzewski) commit list is not supported; behavior incompatible with other drivers.
 
 type gitService struct {
 	client *wrapper
@@ -41,11 +42,24 @@ func (s *gitService) FindRef(ctx context.Context, repo, ref string) (string, *sc
 }
 
 func (s *gitService) CreateRef(ctx context.Context, repo, ref, sha string) (*scm.Reference, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/branch-utils/1.0/projects/%s/repos/%s/branches", namespace, name)
+	in := &branchInput{
+		Name:       scm.TrimRef(strings.TrimPrefix(ref, "heads/")),
+		StartPoint: sha,
+	}
+	out := new(branch)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertBranch(out), res, err
 }
 
 func (s *gitService) DeleteRef(ctx context.Context, repo, ref string) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/branch-utils/1.0/projects/%s/repos/%s/branches", namespace, name)
+	in := &branchDelete{
+		Name: scm.ExpandRef(strings.TrimPrefix(ref, "heads/"), "refs/heads"),
+	}
+	return s.client.do(ctx, "DELETE", path, in, nil)
 }
 
 func (s *gitService) FindBranch(ctx context.Context, repo, branch string) (*scm.Reference, *scm.Response, error) {
@@ -101,7 +115,15 @@ func (s *gitService) ListBranches(ctx context.Context, repo string, opts scm.Lis
 }
 
 func (s *gitService) ListCommits(ctx context.Context, repo string, opts scm.CommitListOptions) ([]*scm.Commit, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/commits?%s", namespace, name, encodeCommitListOptions(opts))
+	out := new(commits)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	copyPagination(out.pagination, res)
+	return filterCommits(convertCommitList(out), opts), res, err
 }
 
 func (s *gitService) ListTags(ctx context.Context, repo string, opts scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
@@ -129,17 +151,92 @@ func (s *gitService) ListChanges(ctx context.Context, repo, ref string, opts scm
 }
 
 func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	out, res, err := s.Compare(ctx, repo, ref1, ref2, opts)
+	if err != nil {
+		return nil, res, err
+	}
+	return out.Changes, res, nil
+}
+
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, opts scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	opts.From = url.PathEscape(ref1)
-	opts.To = url.PathEscape(ref2)
+	// the changes are those of the from ref which are not on the to ref
+	opts.From = head
+	opts.To = base
 	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/compare/changes?%s", namespace, name, encodeListOptions(opts))
 	out := new(diffstats)
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
 	if !out.pagination.LastPage.Bool {
 		res.Page.First = 1
 		res.Page.Next = opts.Page + 1
 	}
-	return convertDiffstats(out), res, err
+	ahead, aheadRes, err := s.listCommitsBetween(ctx, repo, head, base)
+	if err != nil {
+		return nil, aheadRes, err
+	}
+	behind, behindRes, err := s.listCommitsBetween(ctx, repo, base, head)
+	if err != nil {
+		return nil, behindRes, err
+	}
+	comparison := &scm.Comparison{
+		Ahead:     len(ahead.Values),
+		Behind:    len(behind.Values),
+		Commits:   convertCommitList(ahead),
+		Changes:   convertDiffstats(out),
+		MergeBase: mergebase.Find(toMergeBaseCommits(ahead), toMergeBaseCommits(behind)),
+	}
+	// stash does not report the merge base so it is found from the
+	// commits of both sides, or else is the ref of the side without any
+	ref := ""
+	switch {
+	case len(ahead.Values) == 0:
+		ref = head
+	case len(behind.Values) == 0:
+		ref = base
+	}
+	if ref != "" {
+		commit, commitRes, err := s.FindCommit(ctx, repo, ref)
+		if err != nil {
+			return nil, commitRes, err
+		}
+		comparison.MergeBase = commit.Sha
+	}
+	return comparison, res, nil
+}
+
+// listCommitsBetween returns all the commits reachable from the from ref
+// which are not reachable from the to ref, oldest first.
+func (s *gitService) listCommitsBetween(ctx context.Context, repo, from, to string) (*commits, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	answer := new(commits)
+	params := url.Values{}
+	params.Set("from", from)
+	params.Set("to", to)
+	params.Set("limit", "100")
+	var res *scm.Response
+	for {
+		path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/compare/commits?%s", namespace, name, params.Encode())
+		out := new(commits)
+		var err error
+		res, err = s.client.do(ctx, "GET", path, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		answer.Values = append(answer.Values, out.Values...)
+		if out.pagination.LastPage.Bool || !out.pagination.NextPage.Valid {
+			break
+		}
+		params.Set("start", strconv.FormatInt(out.pagination.NextPage.Int64, 10))
+	}
+	// stash lists the commits newest first
+	values := answer.Values
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+	return answer, res, nil
 }
 
 type branch struct {
@@ -151,6 +248,16 @@ type branch struct {
 	IsDefault       bool   `json:"isDefault"`
 }
 
+type branchInput struct {
+	Name       string `json:"name"`
+	StartPoint string `json:"startPoint"`
+}
+
+type branchDelete struct {
+	Name   string `json:"name"`
+	DryRun bool   `json:"dryRun"`
+}
+
 type commits struct {
 	pagination
 	Values []*commit `json:"values"`
@@ -269,6 +376,18 @@ func convertDiffstat(from *diffstat) *scm.Change {
 	return to
 }
 
+func toMergeBaseCommits(from *commits) []mergebase.Commit {
+	to := []mergebase.Commit{}
+	for _, v := range from.Values {
+		c := mergebase.Commit{Sha: v.ID}
+		for _, p := range v.Parents {
+			c.Parents = append(c.Parents, p.ID)
+		}
+		to = append(to, c)
+	}
+	return to
+}
+
 func convertCommitList(from *commits) []*scm.Commit {
 	to := []*scm.Commit{}
 	for _, v := range from.Values {
@@ -299,6 +418,33 @@ func convertCommit(from *commit) *scm.Commit {
 	}
 }
 
+// filterCommits filters the commits by the author and time range of the
+// options which the stash API does not support
+func filterCommits(commits []*scm.Commit, opts scm.CommitListOptions) []*scm.Commit {
+	if opts.Author == "" && opts.Since.IsZero() && opts.Until.IsZero() {
+		return commits
+	}
+	dst := []*scm.Commit{}
+	for _, c := range commits {
+		switch {
+		case opts.Author != "" && !matchSignature(c.Author, opts.Author):
+		case !opts.Since.IsZero() && c.Author.Date.Before(opts.Since):
+		case !opts.Until.IsZero() && c.Author.Date.After(opts.Until):
+		default:
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
+
+// matchSignature returns true if the author is the login, name or email
+// of the signature
+func matchSignature(sig scm.Signature, author string) bool {
+	return strings.EqualFold(sig.Login, author) ||
+		strings.EqualFold(sig.Name, author) ||
+		strings.EqualFold(sig.Email, author)
+}
+
 func convertBranchList(from *branches) []*scm.Reference {
 	to := []*scm.Reference{}
 	for _, v := range from.Values {
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 
@@ -94,10 +95,109 @@ func TestGitFindTag(t *testing.T) {
 }
 
 func TestGitListCommits(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/commits").
+		MatchParam("until", "master").
+		MatchParam("path", "README.md").
+		MatchParam("limit", "2").
+		Reply(200).
+		Type("application/json").
+		File("testdata/commits.json")
+
+	client, _ := New("http://example.com:7990")
+	got, res, err := client.Git.ListCommits(context.Background(), "PRJ/my-repo", scm.CommitListOptions{Ref: "master", Path: "README.md", Page: 1, Size: 2})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Commit{}
+	raw, _ := ioutil.ReadFile("testdata/commits.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if got, want := res.Page.First, 1; got != want {
+		t.Errorf("Want first page %d, got %d", want, got)
+	}
+	if got, want := res.Page.Next, 2; got != want {
+		t.Errorf("Want next page %d, got %d", want, got)
+	}
+}
+
+func TestGitListCommits_Filtered(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/commits").
+		Reply(200).
+		Type("application/json").
+		File("testdata/commits.json")
+
+	client, _ := New("http://example.com:7990")
+	opts := scm.CommitListOptions{
+		Author: "Jane@example.com",
+		Since:  time.Unix(1530720000, 0),
+	}
+	got, _, err := client.Git.ListCommits(context.Background(), "PRJ/my-repo", opts)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(got) != 1 || got[0].Author.Date.Before(opts.Since) {
+		t.Errorf("Want the one commit since the time, got %d", len(got))
+	}
+}
+
+func TestGitCreateRef(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Post("/rest/branch-utils/1.0/projects/PRJ/repos/my-repo/branches").
+		MatchType("json").
+		JSON(map[string]string{"name": "feature", "startPoint": "131cb13f4aed12e725177bc4b7c28db67839bf9f"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/branch_create.json")
+
 	client, _ := New("http://example.com:7990")
-	_, _, err := client.Git.ListCommits(context.Background(), "PRJ/my-repo", scm.CommitListOptions{Ref: "master", Page: 1, Size: 30})
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	got, _, err := client.Git.CreateRef(context.Background(), "PRJ/my-repo", "refs/heads/feature", "131cb13f4aed12e725177bc4b7c28db67839bf9f")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Reference)
+	raw, _ := ioutil.ReadFile("testdata/branch_create.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitDeleteRef(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Delete("/rest/branch-utils/1.0/projects/PRJ/repos/my-repo/branches").
+		MatchType("json").
+		JSON(map[string]interface{}{"name": "refs/heads/feature", "dryRun": false}).
+		Reply(204)
+
+	client, _ := New("http://example.com:7990")
+	_, err := client.Git.DeleteRef(context.Background(), "PRJ/my-repo", "heads/feature")
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect the branch to be deleted")
 	}
 }
 
@@ -188,13 +288,15 @@ func TestGitCompareCommits(t *testing.T) {
 
 	gock.New("http://example.com:7990").
 		Get("rest/api/1.0/projects/PRJ/repos/my-repo/compare/changes").
-		MatchParam("from", "anarbitraryshabutnotatallarbitrarylength").
-		MatchParam("to", "anothershathatwillgetpaddedwithdigits121").
+		MatchParam("from", "anothershathatwillgetpaddedwithdigits121").
+		MatchParam("to", "anarbitraryshabutnotatallarbitrarylength").
 		MatchParam("limit", "30").
 		Reply(200).
 		Type("application/json").
 		File("testdata/changes.json")
 
+	mockCompareCommits()
+
 	client, _ := New("http://example.com:7990")
 	got, _, err := client.Git.CompareCommits(context.Background(), "PRJ/my-repo", "anarbitraryshabutnotatallarbitrarylength", "anothershathatwillgetpaddedwithdigits121", scm.ListOptions{Page: 1, Size: 30})
 	if err != nil {
@@ -210,3 +312,97 @@ func TestGitCompareCommits(t *testing.T) {
 		t.Log(diff)
 	}
 }
+
+func TestGitCompare(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/compare/changes").
+		MatchParam("from", "anothershathatwillgetpaddedwithdigits121").
+		MatchParam("to", "anarbitraryshabutnotatallarbitrarylength").
+		Reply(200).
+		Type("application/json").
+		File("testdata/changes.json")
+
+	mockCompareCommits()
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Git.Compare(context.Background(), "PRJ/my-repo", "anarbitraryshabutnotatallarbitrarylength", "anothershathatwillgetpaddedwithdigits121", scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := ioutil.ReadFile("testdata/compare.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitCompare_Paged(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/compare/changes").
+		Reply(200).
+		Type("application/json").
+		File("testdata/changes.json")
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/compare/commits").
+		MatchParam("from", "feature/x").
+		MatchParam("start", "2").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"values":[{"id":"b1","parents":[{"id":"a"}]}],"isLastPage":true,"limit":100}`)
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/compare/commits").
+		MatchParam("from", "feature/x").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"values":[{"id":"b3","parents":[{"id":"b2"}]},{"id":"b2","parents":[{"id":"b1"}]}],"isLastPage":false,"limit":100,"nextPageStart":2}`)
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/compare/commits").
+		MatchParam("from", "master").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"values":[{"id":"c1","parents":[{"id":"a"}]}],"isLastPage":true,"limit":100}`)
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Git.Compare(context.Background(), "PRJ/my-repo", "master", "feature/x", scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Ahead != 3 || got.Behind != 1 || got.MergeBase != "a" {
+		t.Errorf("Want 3 commits ahead and 1 behind of merge base a, got %d ahead and %d behind of %s", got.Ahead, got.Behind, got.MergeBase)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected the second page of commits from the next page start")
+	}
+}
+
+// mockCompareCommits mocks the commits requested when comparing
+// the commits of the PRJ/my-repo repository
+func mockCompareCommits() {
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/compare/commits").
+		MatchParam("from", "anothershathatwillgetpaddedwithdigits121").
+		MatchParam("to", "anarbitraryshabutnotatallarbitrarylength").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare_ahead.json")
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/compare/commits").
+		MatchParam("from", "anarbitraryshabutnotatallarbitrarylength").
+		MatchParam("to", "anothershathatwillgetpaddedwithdigits121").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare_behind.json")
+}
@@ -29,6 +29,38 @@ func (s *pullService) Find(ctx context.Context, repo string, number int) (*scm.P
 	return convertPullRequest(out), res, err
 }
 
+func (s *pullService) FindMergeability(ctx context.Context, repo string, number int) (*scm.Mergeability, *scm.Response, error) {
+	pr, res, err := s.Find(ctx, repo, number)
+	if err != nil {
+		return nil, res, err
+	}
+	m, res, err := scm.FindMergeability(ctx, s.client.Client, repo, pr, nil)
+	if err != nil {
+		return nil, res, err
+	}
+	if pr.Closed {
+		return m, res, nil
+	}
+
+	// the merge endpoint reports conflicts and the vetoes of the merge checks
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/merge", namespace, name, number)
+	out := new(mergeStatus)
+	res, err = s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	m.State = out.Outcome
+	if out.Conflicted {
+		m.Block(scm.MergeBlockerConflicts)
+	}
+	for _, veto := range out.Vetoes {
+		m.Messages = append(m.Messages, veto.SummaryMessage)
+		m.Block(scm.MergeBlockerBlocked)
+	}
+	return m, res, nil
+}
+
 func (s *pullService) FindComment(ctx context.Context, repo string, number int, id int) (*scm.Comment, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments/%d", namespace, name, number, id)
@@ -39,14 +71,37 @@ func (s *pullService) FindComment(ctx context.Context, repo string, number int,
 
 func (s *pullService) List(ctx context.Context, repo string, opts scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests", namespace, name)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests?%s", namespace, name, encodePullRequestListOptions(opts))
 	out := new(pullRequests)
 	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
 	if !out.pagination.LastPage.Bool {
 		res.Page.First = 1
 		res.Page.Next = opts.Page + 1
 	}
-	return convertPullRequests(out), res, err
+	prs := convertPullRequests(out)
+	if opts.Closed && !opts.Open {
+		filtered := []*scm.PullRequest{}
+		for _, pr := range prs {
+			if pr.Closed {
+				filtered = append(filtered, pr)
+			}
+		}
+		prs = filtered
+	}
+	if opts.Head != "" && opts.Base != "" {
+		// only the head branch can be filtered by the API if both are given
+		filtered := []*scm.PullRequest{}
+		for _, pr := range prs {
+			if pr.Base.Ref == opts.Base {
+				filtered = append(filtered, pr)
+			}
+		}
+		prs = filtered
+	}
+	return prs, res, err
 }
 
 func (s *pullService) ListChanges(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
@@ -61,6 +116,21 @@ func (s *pullService) ListChanges(ctx context.Context, repo string, number int,
 	return convertDiffstats(out), res, err
 }
 
+func (s *pullService) ListCommits(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Commit, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/commits?%s", namespace, name, number, encodeListOptions(opts))
+	out := new(commits)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if !out.pagination.LastPage.Bool {
+		res.Page.First = 1
+		res.Page.Next = opts.Page + 1
+	}
+	return convertCommitList(out), res, err
+}
+
 func (s *pullService) ListLabels(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
 	// Get all comments, parse out labels (removing and added based off time)
 	cs, res, err := s.ListComments(ctx, repo, number, opts)
@@ -118,11 +188,46 @@ func (s *pullService) Merge(ctx context.Context, repo string, number int, option
 	return res, err
 }
 
+func (s *pullService) EnableAutoMerge(ctx context.Context, repo string, number int, options *scm.PullRequestMergeOptions) (*scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	in := new(autoMergeInput)
+	if options != nil {
+		in.Message = strings.Trim(options.CommitTitle+"\n\n"+options.CommitMessage, "\n")
+		in.StrategyID = convertMergeMethod(options.MergeMethod)
+		in.FromHash = options.SHA
+	}
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/auto-merge", namespace, name, number)
+	return s.client.do(ctx, "POST", path, in, nil)
+}
+
+func (s *pullService) DisableAutoMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/auto-merge", namespace, name, number)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+type autoMergeInput struct {
+	Message    string `json:"message,omitempty"`
+	StrategyID string `json:"strategyId,omitempty"`
+	FromHash   string `json:"fromHash,omitempty"`
+}
+
+type mergeStatus struct {
+	CanMerge   bool   `json:"canMerge"`
+	Conflicted bool   `json:"conflicted"`
+	Outcome    string `json:"outcome"`
+	Vetoes     []struct {
+		SummaryMessage  string `json:"summaryMessage"`
+		DetailedMessage string `json:"detailedMessage"`
+	} `json:"vetoes"`
+}
+
 type prUpdateInput struct {
 	ID          int    `json:"id"`
 	Version     int    `json:"version"`
 	Title       string `json:"title,omitempty"`
 	Description string `json:"description,omitempty"`
+	Draft       *bool  `json:"draft,omitempty"`
 }
 
 func (s *pullService) Update(ctx context.Context, repo string, number int, prInput *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
@@ -146,6 +251,38 @@ func (s *pullService) Update(ctx context.Context, repo string, number int, prInp
 	return convertPullRequest(out), res, err
 }
 
+func (s *pullService) UpdateBranch(ctx context.Context, repo string, number int, method string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) MarkReady(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(ctx, repo, number, false)
+}
+
+func (s *pullService) ConvertToDraft(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(ctx, repo, number, true)
+}
+
+// setDraft updates the draft state of the pull request which
+// requires Bitbucket Data Center 8.18 or later.
+func (s *pullService) setDraft(ctx context.Context, repo string, number int, draft bool) (*scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d", namespace, name, number)
+	getOut := new(pullRequest)
+	res, err := s.client.do(ctx, "GET", path, nil, getOut)
+	if err != nil {
+		return res, err
+	}
+	input := &prUpdateInput{
+		ID:          getOut.ID,
+		Version:     getOut.Version,
+		Title:       getOut.Title,
+		Description: getOut.Description,
+		Draft:       &draft,
+	}
+	return s.client.do(ctx, "PUT", path, input, nil)
+}
+
 func (s *pullService) Close(ctx context.Context, repo string, number int) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/decline", namespace, name, number)
@@ -214,8 +351,16 @@ func (s *pullService) UnassignIssue(ctx context.Context, repo string, number int
 }
 
 func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
+	// pull requests in bitbucket server have no assignees or milestones
+	if len(input.Assignees) > 0 || input.Milestone != 0 {
+		return nil, nil, scm.ErrNotSupported
+	}
 	namespace, name := scm.Split(repo)
 	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests", namespace, name)
+	headNamespace, headName := namespace, name
+	if input.HeadRepo != "" {
+		headNamespace, headName = scm.Split(input.HeadRepo)
+	}
 
 	in := &createPRInput{
 		Title:       input.Title,
@@ -226,8 +371,8 @@ func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRe
 		FromRef: createPRInputRef{
 			ID: fmt.Sprintf("refs/heads/%s", input.Head),
 			Repository: createPRInputRepo{
-				Slug:    name,
-				Project: createPRInputRepoProject{Key: namespace},
+				Slug:    headName,
+				Project: createPRInputRepoProject{Key: headNamespace},
 			},
 		},
 		ToRef: createPRInputRef{
@@ -238,11 +383,24 @@ func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRe
 			},
 		},
 		Locked: false,
+		Draft:  input.Draft,
+	}
+	for _, login := range input.Reviewers {
+		in.Reviewers = append(in.Reviewers, createPRInputReviewer{User: createPRInputUser{Name: login}})
 	}
 
 	out := new(pullRequest)
 	res, err := s.client.do(ctx, "POST", path, in, out)
-	return convertPullRequest(out), res, err
+	if err != nil {
+		return convertPullRequest(out), res, err
+	}
+	// labels are stored as comments
+	for _, label := range input.Labels {
+		if res, err := s.AddLabel(ctx, repo, out.ID, label); err != nil {
+			return convertPullRequest(out), res, err
+		}
+	}
+	return convertPullRequest(out), res, nil
 }
 
 func (s *pullService) RequestReview(ctx context.Context, repo string, number int, logins []string) (*scm.Response, error) {
@@ -302,14 +460,24 @@ func (s *pullService) ClearMilestone(ctx context.Context, repo string, prID int)
 }
 
 type createPRInput struct {
-	Title       string           `json:"title,omitempty"`
-	Description string           `json:"description,omitempty"`
-	State       string           `json:"state,omitempty"`
-	Open        bool             `json:"open,omitempty"`
-	Closed      bool             `json:"closed,omitempty"`
-	FromRef     createPRInputRef `json:"fromRef,omitempty"`
-	ToRef       createPRInputRef `json:"toRef,omitempty"`
-	Locked      bool             `json:"locked,omitempty"`
+	Title       string                  `json:"title,omitempty"`
+	Description string                  `json:"description,omitempty"`
+	State       string                  `json:"state,omitempty"`
+	Open        bool                    `json:"open,omitempty"`
+	Closed      bool                    `json:"closed,omitempty"`
+	FromRef     createPRInputRef        `json:"fromRef,omitempty"`
+	ToRef       createPRInputRef        `json:"toRef,omitempty"`
+	Locked      bool                    `json:"locked,omitempty"`
+	Reviewers   []createPRInputReviewer `json:"reviewers,omitempty"`
+	Draft       bool                    `json:"draft,omitempty"`
+}
+
+type createPRInputReviewer struct {
+	User createPRInputUser `json:"user"`
+}
+
+type createPRInputUser struct {
+	Name string `json:"name"`
 }
 
 type createPRInputRef struct {
@@ -339,6 +507,7 @@ type pullRequest struct {
 	FromRef      prRepoRef     `json:"fromRef"`
 	ToRef        prRepoRef     `json:"toRef"`
 	Locked       bool          `json:"locked"`
+	Draft        bool          `json:"draft"`
 	Author       prUser        `json:"author"`
 	Reviewers    []prUser      `json:"reviewers"`
 	Participants []interface{} `json:"participants"`
@@ -404,6 +573,7 @@ func convertPullRequest(from *pullRequest) *scm.PullRequest {
 		Link:      extractSelfLink(from.Links.Self),
 		State:     strings.ToLower(from.State),
 		Closed:    from.Closed,
+		Draft:     from.Draft,
 		Merged:    from.State == "MERGED",
 		Reviewers: convertReviewers(from.Reviewers),
 		Created:   time.Unix(from.CreatedDate/1000, 0),
@@ -429,6 +599,9 @@ type pullRequestComment struct {
 	UpdatedDate         int64                `json:"updatedDate"`
 	Comments            []pullRequestComment `json:"comments"`
 	Tasks               []interface{}        `json:"tasks"`
+	State               string               `json:"state"`
+	ThreadResolved      bool                 `json:"threadResolved"`
+	Anchor              *commentAnchor       `json:"anchor"`
 	PermittedOperations struct {
 		Editable  bool `json:"editable"`
 		Deletable bool `json:"deletable"`
@@ -465,7 +638,7 @@ type pullRequestActivity struct {
 	Action        string              `json:"action"`
 	CommentAction string              `json:"commentAction"`
 	Comment       *pullRequestComment `json:"comment"`
-	CommentAnchor interface{}         `json:"commentAnchor"`
+	CommentAnchor *commentAnchor      `json:"commentAnchor"`
 }
 
 func convertPullRequestActivities(from *pullRequestActivities) []*scm.Comment {
@@ -503,6 +676,21 @@ func convertPullRequestComment(from *pullRequestComment) *scm.Comment {
 	}
 }
 
+// convertMergeMethod maps a merge method to the id of the
+// equivalent Bitbucket Server merge strategy.
+func convertMergeMethod(from string) string {
+	switch from {
+	case "merge":
+		return "no-ff"
+	case "squash":
+		return "squash"
+	case "rebase":
+		return "rebase-ff-only"
+	default:
+		return ""
+	}
+}
+
 func convertReviewers(from []prUser) []scm.User {
 	var answer []scm.User
 
@@ -175,6 +175,74 @@ func TestPullList(t *testing.T) {
 	}
 }
 
+func TestPullListClosed(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests").
+		MatchParam("state", "ALL").
+		Reply(200).
+		Type("application/json").
+		File("testdata/prs.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.PullRequests.List(context.Background(), "PRJ/my-repo", scm.PullRequestListOptions{Closed: true})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Want open pull requests filtered out, got %d", len(got))
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestPullListCommits(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/commits").
+		MatchParam("limit", "30").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_commits.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.PullRequests.ListCommits(context.Background(), "PRJ/my-repo", 1, scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*scm.Commit{}
+	raw, _ := ioutil.ReadFile("testdata/pr_commits.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullListCommits_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/commits").
+		Reply(404).
+		Type("application/json").
+		File("testdata/error.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.PullRequests.ListCommits(context.Background(), "PRJ/my-repo", 1, scm.ListOptions{Page: 1, Size: 30})
+	if err == nil {
+		t.Errorf("Expect not found message")
+	}
+	if got != nil {
+		t.Errorf("Want no commits, got %v", got)
+	}
+}
+
 func TestPullListChanges(t *testing.T) {
 	defer gock.Off()
 
@@ -225,6 +293,109 @@ func TestPullMerge(t *testing.T) {
 	}
 }
 
+func TestPullEnableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Post("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/auto-merge").
+		JSON(map[string]interface{}{
+			"message":    "Add the new feature\n\nIncludes the new feature",
+			"strategyId": "squash",
+		}).
+		Reply(200)
+
+	client, _ := New("http://example.com:7990")
+	options := &scm.PullRequestMergeOptions{
+		MergeMethod:   "squash",
+		CommitTitle:   "Add the new feature",
+		CommitMessage: "Includes the new feature",
+	}
+	_, err := client.PullRequests.EnableAutoMerge(context.Background(), "PRJ/my-repo", 1, options)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPullDisableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Delete("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/auto-merge").
+		Reply(204)
+
+	client, _ := New("http://example.com:7990")
+	_, err := client.PullRequests.DisableAutoMerge(context.Background(), "PRJ/my-repo", 1)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPullUpdateBranch(t *testing.T) {
+	client, _ := New("http://example.com:7990")
+	_, err := client.PullRequests.UpdateBranch(context.Background(), "PRJ/my-repo", 1, "merge")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
+
+func TestPullConvertToDraft(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	gock.New("http://example.com:7990").
+		Put("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1").
+		JSON(map[string]interface{}{
+			"id":          1,
+			"version":     0,
+			"title":       "Updated Files",
+			"description": "* added LICENSE\r\n* update files\r\n* update files",
+			"draft":       true,
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	client, _ := New("http://example.com:7990")
+	_, err := client.PullRequests.ConvertToDraft(context.Background(), "PRJ/my-repo", 1)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPullMarkReady(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	gock.New("http://example.com:7990").
+		Put("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1").
+		JSON(map[string]interface{}{
+			"id":          1,
+			"version":     0,
+			"title":       "Updated Files",
+			"description": "* added LICENSE\r\n* update files\r\n* update files",
+			"draft":       false,
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	client, _ := New("http://example.com:7990")
+	_, err := client.PullRequests.MarkReady(context.Background(), "PRJ/my-repo", 1)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestPullClose(t *testing.T) {
 	defer gock.Off()
 
@@ -350,6 +521,70 @@ func TestPullCreate(t *testing.T) {
 	}
 }
 
+func TestPullCreate_Extended(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Post("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests").
+		JSON(map[string]interface{}{
+			"title":       "Updated Files",
+			"description": "update files",
+			"state":       "OPEN",
+			"open":        true,
+			"fromRef": map[string]interface{}{
+				"id": "refs/heads/feature/x",
+				"repository": map[string]interface{}{
+					"slug":    "my-fork",
+					"project": map[string]string{"key": "~JCITIZEN"},
+				},
+			},
+			"toRef": map[string]interface{}{
+				"id": "refs/heads/master",
+				"repository": map[string]interface{}{
+					"slug":    "my-repo",
+					"project": map[string]string{"key": "PRJ"},
+				},
+			},
+			"reviewers": []map[string]interface{}{
+				{"user": map[string]string{"name": "jane"}},
+			},
+			"draft": true,
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	gock.New("http://example.com:7990").
+		Post("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/comments").
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	client, _ := New("http://example.com:7990")
+
+	input := &scm.PullRequestInput{
+		Title:     "Updated Files",
+		Body:      "update files",
+		Base:      "master",
+		Head:      "feature/x",
+		HeadRepo:  "~JCITIZEN/my-fork",
+		Draft:     true,
+		Reviewers: []string{"jane"},
+		Labels:    []string{"bug"},
+	}
+	if _, _, err := client.PullRequests.Create(context.Background(), "PRJ/my-repo", input); err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks %v", gock.Pending())
+	}
+
+	input.Assignees = []string{"jane"}
+	if _, _, err := client.PullRequests.Create(context.Background(), "PRJ/my-repo", input); err != scm.ErrNotSupported {
+		t.Errorf("Want ErrNotSupported creating a pull request with assignees, got %v", err)
+	}
+}
+
 func TestPullAddLabel(t *testing.T) {
 	defer gock.Off()
 
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -86,11 +87,12 @@ type hookInput struct {
 }
 
 type status struct {
-	State string `json:"state"`
-	Key   string `json:"key"`
-	Name  string `json:"name"`
-	URL   string `json:"url"`
-	Desc  string `json:"description"`
+	State     string `json:"state"`
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	Desc      string `json:"description"`
+	DateAdded int64  `json:"dateAdded,omitempty"`
 }
 
 type statuses struct {
@@ -543,12 +545,16 @@ func convertStatusList(from *statuses) []*scm.Status {
 }
 
 func convertStatus(from *status) *scm.Status {
-	return &scm.Status{
+	to := &scm.Status{
 		State:  convertState(from.State),
 		Label:  from.Key,
 		Desc:   from.Desc,
 		Target: from.URL,
 	}
+	if from.DateAdded != 0 {
+		to.Created = time.Unix(0, from.DateAdded*int64(time.Millisecond))
+	}
+	return to
 }
 
 func convertFromState(from scm.State) string {
@@ -6,6 +6,9 @@ package stash
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -45,3 +48,212 @@ func (s *reviewService) Submit(ctx context.Context, repo string, prID int, revie
 func (s *reviewService) Dismiss(ctx context.Context, repo string, prID int, reviewID int, msg string) (*scm.Review, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
+
+func (s *reviewService) CreateComment(ctx context.Context, repo string, number int, input *scm.ReviewCommentInput) (*scm.ReviewComment, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	in := &reviewCommentInput{Text: input.Body}
+	if input.ThreadID != "" {
+		id, err := strconv.Atoi(input.ThreadID)
+		if err != nil {
+			return nil, nil, err
+		}
+		in.Parent = &commentParent{ID: id}
+	} else {
+		in.Anchor = toCommentAnchor(input)
+	}
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments", namespace, name, number)
+	out := new(pullRequestComment)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	threadID := input.ThreadID
+	if threadID == "" {
+		threadID = strconv.Itoa(out.ID)
+	}
+	comment := convertReviewComment(out, out.Anchor, threadID)
+	if in.Parent != nil {
+		comment.InReplyTo = in.Parent.ID
+	}
+	return comment, res, nil
+}
+
+// ListThreads returns the threads started by comments on the diff. The
+// thread id is the id of the root comment of the thread
+func (s *reviewService) ListThreads(ctx context.Context, repo string, number int, opts scm.ListOptions) ([]*scm.ReviewThread, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/activities?%s", namespace, name, number, encodeListOptions(opts))
+	out := new(pullRequestActivities)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if !out.pagination.LastPage.Bool {
+		res.Page.First = 1
+		res.Page.Next = opts.Page + 1
+	}
+	return convertReviewThreads(out), res, nil
+}
+
+func (s *reviewService) ResolveThread(ctx context.Context, repo string, number int, threadID string) (*scm.Response, error) {
+	return s.resolveThread(ctx, repo, number, threadID, true)
+}
+
+func (s *reviewService) UnresolveThread(ctx context.Context, repo string, number int, threadID string) (*scm.Response, error) {
+	return s.resolveThread(ctx, repo, number, threadID, false)
+}
+
+// resolveThread updates the root comment of the thread which requires
+// its current version
+func (s *reviewService) resolveThread(ctx context.Context, repo string, number int, threadID string, resolved bool) (*scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments/%s", namespace, name, number, threadID)
+	existing := new(pullRequestComment)
+	res, err := s.client.do(ctx, "GET", path, nil, existing)
+	if err != nil {
+		return res, err
+	}
+	in := &commentResolveInput{
+		Text:           existing.Text,
+		Version:        existing.Version,
+		ThreadResolved: resolved,
+	}
+	return s.client.do(ctx, "PUT", path, in, nil)
+}
+
+type commentAnchor struct {
+	FromHash        string           `json:"fromHash,omitempty"`
+	ToHash          string           `json:"toHash,omitempty"`
+	Line            int              `json:"line,omitempty"`
+	LineType        string           `json:"lineType,omitempty"`
+	FileType        string           `json:"fileType,omitempty"`
+	Path            string           `json:"path"`
+	SrcPath         string           `json:"srcPath,omitempty"`
+	DiffType        string           `json:"diffType,omitempty"`
+	Orphaned        bool             `json:"orphaned,omitempty"`
+	MultilineMarker *multilineMarker `json:"multilineMarker,omitempty"`
+}
+
+type multilineMarker struct {
+	StartLine     int    `json:"startLine"`
+	StartLineType string `json:"startLineType"`
+}
+
+type commentParent struct {
+	ID int `json:"id"`
+}
+
+type reviewCommentInput struct {
+	Text   string         `json:"text"`
+	Parent *commentParent `json:"parent,omitempty"`
+	Anchor *commentAnchor `json:"anchor,omitempty"`
+}
+
+type commentResolveInput struct {
+	Text           string `json:"text"`
+	Version        int    `json:"version"`
+	ThreadResolved bool   `json:"threadResolved"`
+}
+
+// toCommentAnchor anchors the comment to a line of the effective diff of
+// the pull request, the old version of the file being the FROM file and
+// unchanged context lines being anchored to the TO file
+func toCommentAnchor(input *scm.ReviewCommentInput) *commentAnchor {
+	anchor := &commentAnchor{
+		Path:     input.Path,
+		Line:     input.Line,
+		LineType: toLineType(input.Side, input.OldLine),
+		FileType: "TO",
+		DiffType: "EFFECTIVE",
+	}
+	if input.Side == scm.ReviewSideLeft {
+		anchor.FileType = "FROM"
+	}
+	if input.StartLine != 0 {
+		side := input.StartSide
+		if side == "" {
+			side = input.Side
+		}
+		anchor.MultilineMarker = &multilineMarker{
+			StartLine:     input.StartLine,
+			StartLineType: toLineType(side, input.StartOldLine),
+		}
+	}
+	return anchor
+}
+
+func toLineType(side string, oldLine int) string {
+	switch {
+	case side == scm.ReviewSideLeft:
+		return "REMOVED"
+	case oldLine != 0:
+		return "CONTEXT"
+	}
+	return "ADDED"
+}
+
+func convertReviewThreads(from *pullRequestActivities) []*scm.ReviewThread {
+	to := []*scm.ReviewThread{}
+	for _, v := range from.Values {
+		if v.Action != "COMMENTED" || v.CommentAction != "ADDED" || v.Comment == nil || v.CommentAnchor == nil {
+			continue
+		}
+		threadID := strconv.Itoa(v.Comment.ID)
+		root := convertReviewComment(v.Comment, v.CommentAnchor, threadID)
+		thread := &scm.ReviewThread{
+			ID:        threadID,
+			Path:      root.Path,
+			Line:      root.Line,
+			StartLine: root.StartLine,
+			Side:      root.Side,
+			Resolved:  v.Comment.ThreadResolved || v.Comment.State == "RESOLVED",
+			Outdated:  v.CommentAnchor.Orphaned,
+			Comments:  []*scm.ReviewComment{root},
+		}
+		thread.Comments = appendReplies(thread.Comments, v.Comment, v.CommentAnchor, threadID)
+		to = append(to, thread)
+	}
+	return to
+}
+
+// appendReplies appends the nested replies to the comment in the order
+// they appear in the thread
+func appendReplies(to []*scm.ReviewComment, from *pullRequestComment, anchor *commentAnchor, threadID string) []*scm.ReviewComment {
+	for i := range from.Comments {
+		reply := &from.Comments[i]
+		comment := convertReviewComment(reply, anchor, threadID)
+		comment.InReplyTo = from.ID
+		to = append(to, comment)
+		to = appendReplies(to, reply, anchor, threadID)
+	}
+	return to
+}
+
+func convertReviewComment(from *pullRequestComment, anchor *commentAnchor, threadID string) *scm.ReviewComment {
+	to := &scm.ReviewComment{
+		ID:      from.ID,
+		Body:    from.Text,
+		Created: time.Unix(from.CreatedDate/1000, 0),
+		Updated: time.Unix(from.UpdatedDate/1000, 0),
+		Author: scm.User{
+			Login:  from.Author.Slug,
+			Name:   from.Author.DisplayName,
+			Email:  from.Author.EmailAddress,
+			Avatar: avatarLink(from.Author.EmailAddress),
+		},
+		ThreadID: threadID,
+	}
+	if anchor != nil {
+		to.Path = anchor.Path
+		to.Sha = anchor.ToHash
+		to.Line = anchor.Line
+		to.Side = scm.ReviewSideRight
+		if anchor.FileType == "FROM" {
+			to.Side = scm.ReviewSideLeft
+		}
+		if anchor.MultilineMarker != nil {
+			to.StartLine = anchor.MultilineMarker.StartLine
+		}
+	}
+	return to
+}
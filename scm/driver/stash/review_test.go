@@ -6,9 +6,13 @@ package stash
 
 import (
 	"context"
+	"encoding/json"
+	"io/ioutil"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestReviewFind(t *testing.T) {
@@ -38,3 +42,168 @@ func TestReviewDelete(t *testing.T) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
+
+func TestReviewCreateComment(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Post("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/comments").
+		JSON(map[string]interface{}{
+			"text": "Should this be a constant?",
+			"anchor": map[string]interface{}{
+				"path":     "README.md",
+				"line":     12,
+				"lineType": "ADDED",
+				"fileType": "TO",
+				"diffType": "EFFECTIVE",
+				"multilineMarker": map[string]interface{}{
+					"startLine":     10,
+					"startLineType": "ADDED",
+				},
+			},
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_review_comment.json")
+
+	input := &scm.ReviewCommentInput{
+		Body:      "Should this be a constant?",
+		Path:      "README.md",
+		StartLine: 10,
+		Line:      12,
+	}
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Reviews.CreateComment(context.Background(), "PRJ/my-repo", 1, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.ReviewComment)
+	raw, _ := ioutil.ReadFile("testdata/pr_review_comment.json.golden")
+	json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReviewCreateComment_Reply(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Post("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/comments").
+		JSON(map[string]interface{}{
+			"text":   "this is a comment",
+			"parent": map[string]interface{}{"id": 1},
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	input := &scm.ReviewCommentInput{
+		Body:     "this is a comment",
+		ThreadID: "1",
+	}
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Reviews.CreateComment(context.Background(), "PRJ/my-repo", 1, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.ThreadID != "1" || got.InReplyTo != 1 {
+		t.Errorf("Unexpected reply %+v", got)
+	}
+}
+
+func TestReviewListThreads(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/activities").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_review_threads.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Reviews.ListThreads(context.Background(), "PRJ/my-repo", 1, scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.ReviewThread{}
+	raw, _ := ioutil.ReadFile("testdata/pr_review_threads.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReviewResolveThread(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/comments/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	gock.New("http://example.com:7990").
+		Put("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/comments/1").
+		JSON(map[string]interface{}{
+			"text":           "this is a comment",
+			"version":        5,
+			"threadResolved": true,
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	client, _ := New("http://example.com:7990")
+	_, err := client.Reviews.ResolveThread(context.Background(), "PRJ/my-repo", 1, "1")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReviewCreateComment_Context(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Post("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/comments").
+		JSON(map[string]interface{}{
+			"text": "Should this be a constant?",
+			"anchor": map[string]interface{}{
+				"path":     "README.md",
+				"line":     12,
+				"lineType": "CONTEXT",
+				"fileType": "TO",
+				"diffType": "EFFECTIVE",
+			},
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_review_comment.json")
+
+	input := &scm.ReviewCommentInput{
+		Body:    "Should this be a constant?",
+		Path:    "README.md",
+		Line:    12,
+		OldLine: 11,
+	}
+
+	client, _ := New("http://example.com:7990")
+	_, _, err := client.Reviews.CreateComment(context.Background(), "PRJ/my-repo", 1, input)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected the comment anchored to a context line")
+	}
+}
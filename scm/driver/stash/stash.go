@@ -10,7 +10,9 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"mime/multipart"
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/jenkins-x/go-scm/scm"
@@ -48,6 +50,7 @@ func New(uri string) (*scm.Client, error) {
 	client.Reviews = &reviewService{client}
 	client.Users = &userService{client}
 	client.Webhooks = &webhookService{client}
+	client.Unsupported = unsupported
 	return client.Client, nil
 }
 
@@ -73,7 +76,16 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	}
 	// if we are posting or putting data, we need to
 	// write it to the body of the request.
-	if in != nil {
+	if f, ok := in.(form); ok {
+		buf := new(bytes.Buffer)
+		w := multipart.NewWriter(buf)
+		for _, k := range f.keys() {
+			w.WriteField(k, f[k]) // #nosec
+		}
+		w.Close() // #nosec
+		req.Header.Add("Content-Type", w.FormDataContentType())
+		req.Body = buf
+	} else if in != nil {
 		buf := new(bytes.Buffer)
 		json.NewEncoder(buf).Encode(in) // #nosec
 		req.Header.Add("Content-Type", "application/json")
@@ -114,6 +126,20 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	return res, json.NewDecoder(res.Body).Decode(out)
 }
 
+// form represents the fields of a multipart form which
+// some endpoints expect instead of a json body.
+type form map[string]string
+
+// keys returns the names of the fields in a stable order.
+func (f form) keys() []string {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // pagination represents Bitbucket pagination properties
 // embedded in list responses.
 type pagination struct {
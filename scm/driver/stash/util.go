@@ -7,6 +7,7 @@ package stash
 import (
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -54,22 +55,72 @@ func encodePullRequestListOptions(opts scm.PullRequestListOptions) string {
 	if opts.Size != 0 {
 		params.Set("limit", strconv.Itoa(opts.Size))
 	}
-	if opts.Open && opts.Closed {
-		params.Set("state", "all")
-	} else if opts.Closed {
-		params.Set("state", "closed")
+	// the state is one of OPEN, DECLINED, MERGED or ALL so closed pull
+	// requests are listed with all of them and the open ones filtered out
+	if opts.Closed {
+		params.Set("state", "ALL")
+	}
+	// a pull request is outgoing from its head and incoming to its base
+	if opts.Head != "" {
+		head := opts.Head
+		if i := strings.Index(head, ":"); i >= 0 {
+			head = head[i+1:]
+		}
+		params.Set("at", "refs/heads/"+head)
+		params.Set("direction", "OUTGOING")
+	} else if opts.Base != "" {
+		params.Set("at", "refs/heads/"+opts.Base)
+		params.Set("direction", "INCOMING")
+	}
+	if opts.Author != "" {
+		params.Set("role.1", "AUTHOR")
+		params.Set("username.1", opts.Author)
+	}
+	switch {
+	case opts.Sort == "created" && opts.Direction == "asc":
+		params.Set("order", "OLDEST")
+	case opts.Sort != "":
+		params.Set("order", "NEWEST")
 	}
 	return params.Encode()
 }
 
-// func copyPagination(from pagination, to *scm.Response) error {
-// 	if to == nil {
-// 		return nil
-// 	}
-// 	to.Page.First = 1
-// 	if from.LastPage.Bool {
-// 		return nil
-// 	}
-// 	to.Page.Next =
-// 	return nil
-// }
+// encodeCommitListOptions encodes the commit list options as query
+// parameters. The author and time range are filtered as the commits are
+// converted and stash cannot follow only the first parent.
+func encodeCommitListOptions(opts scm.CommitListOptions) string {
+	params := url.Values{}
+	if opts.Page > 1 {
+		params.Set("start", strconv.Itoa(
+			(opts.Page-1)*opts.Size),
+		)
+	}
+	if opts.Size != 0 {
+		params.Set("limit", strconv.Itoa(opts.Size))
+	}
+	if opts.Sha != "" {
+		params.Set("until", opts.Sha)
+	} else if opts.Ref != "" {
+		params.Set("until", opts.Ref)
+	}
+	if opts.Path != "" {
+		params.Set("path", opts.Path)
+	}
+	if opts.NoMerges {
+		params.Set("merges", "exclude")
+	}
+	return params.Encode()
+}
+
+// copyPagination populates the page of the response from the
+// isLastPage and nextPageStart properties of a list response.
+func copyPagination(from pagination, to *scm.Response) {
+	if to == nil {
+		return
+	}
+	to.Page.First = 1
+	if from.LastPage.Bool || !from.NextPage.Valid || from.Limit.Int64 == 0 {
+		return
+	}
+	to.Page.Next = int(from.NextPage.Int64/from.Limit.Int64) + 1
+}
@@ -40,9 +40,77 @@ func Test_encodePullRequestListOptions(t *testing.T) {
 		Open:   true,
 		Closed: true,
 	}
-	want := "limit=30&start=270&state=all"
+	want := "limit=30&start=270&state=ALL"
 	got := encodePullRequestListOptions(opts)
 	if got != want {
 		t.Errorf("Want encoded pr list options %q, got %q", want, got)
 	}
+
+	opts = scm.PullRequestListOptions{Closed: true}
+	want = "state=ALL"
+	got = encodePullRequestListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded pr list options %q, got %q", want, got)
+	}
+
+	opts = scm.PullRequestListOptions{Open: true}
+	want = ""
+	got = encodePullRequestListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded pr list options %q, got %q", want, got)
+	}
+}
+
+func Test_encodePullRequestListOptions_Filters(t *testing.T) {
+	t.Parallel()
+	opts := scm.PullRequestListOptions{
+		Head:      "PRJ:feature",
+		Author:    "jcitizen",
+		Sort:      "created",
+		Direction: "asc",
+	}
+	want := "at=refs%2Fheads%2Ffeature&direction=OUTGOING&order=OLDEST&role.1=AUTHOR&username.1=jcitizen"
+	got := encodePullRequestListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded pr list options %q, got %q", want, got)
+	}
+
+	opts = scm.PullRequestListOptions{Base: "master"}
+	want = "at=refs%2Fheads%2Fmaster&direction=INCOMING"
+	got = encodePullRequestListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded pr list options %q, got %q", want, got)
+	}
+}
+
+func Test_encodeCommitListOptions(t *testing.T) {
+	opts := scm.CommitListOptions{
+		Ref:      "master",
+		Page:     3,
+		Size:     25,
+		Path:     "docs/README.md",
+		NoMerges: true,
+	}
+	want := "limit=25&merges=exclude&path=docs%2FREADME.md&start=50&until=master"
+	if got := encodeCommitListOptions(opts); got != want {
+		t.Errorf("Want encoded commit list options %q, got %q", want, got)
+	}
+}
+
+func Test_copyPagination(t *testing.T) {
+	res := new(scm.Response)
+	from := pagination{}
+	from.Limit.Int64, from.Limit.Valid = 25, true
+	from.NextPage.Int64, from.NextPage.Valid = 50, true
+	copyPagination(from, res)
+	if got, want := res.Page.Next, 3; got != want {
+		t.Errorf("Want next page %d, got %d", want, got)
+	}
+
+	res = new(scm.Response)
+	from.LastPage.Bool, from.LastPage.Valid = true, true
+	copyPagination(from, res)
+	if got, want := res.Page.Next, 0; got != want {
+		t.Errorf("Want no next page on the last page, got %d", got)
+	}
 }
@@ -0,0 +1,132 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+)
+
+// TestCapabilities is the compatibility matrix of the drivers. It invokes
+// every method of every service against a server which knows nothing and
+// asserts that a method returns scm.ErrNotSupported if and only if the
+// driver declares the feature as unsupported.
+func TestCapabilities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/api/v1/version") {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"version":"1.22.0"}`)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	features := map[scm.Feature]bool{}
+	for _, f := range scm.Features() {
+		features[f] = true
+	}
+
+	for _, driver := range []string{"bitbucket", "coding", "fake", "gitea", "github", "gitlab", "gogs", "stash"} {
+		t.Run(driver, func(t *testing.T) {
+			client, err := NewClient(driver, server.URL, "token")
+			if err != nil {
+				t.Fatalf("failed to create client: %s", err)
+			}
+			if driver == "fake" {
+				// keep the contents in memory rather than on disk
+				var data *fake.Data
+				client, data = fake.NewDefault()
+				data.ContentDir = ""
+			}
+			for _, f := range client.Unsupported {
+				if !features[f] {
+					t.Errorf("declared unsupported feature %s does not exist", f)
+				}
+			}
+			var undeclared []string
+			for _, f := range scm.Features() {
+				implemented, panicked := implements(client, f)
+				if panicked != "" {
+					t.Errorf("feature %s panics: %s", f, panicked)
+					continue
+				}
+				if implemented != client.Supports(f) {
+					t.Errorf("feature %s is implemented %t but declared supported %t", f, implemented, client.Supports(f))
+				}
+				if !implemented {
+					undeclared = append(undeclared, string(f))
+				}
+			}
+			sort.Strings(undeclared)
+			if t.Failed() {
+				t.Logf("unsupported features:\n%q", undeclared)
+			}
+		})
+	}
+}
+
+// implements invokes the method of the feature with zero arguments and
+// returns false if the service is missing or the method returns
+// scm.ErrNotSupported, along with the value of any panic of the method.
+func implements(client *scm.Client, f scm.Feature) (implemented bool, panicked string) {
+	service := reflect.ValueOf(client).Elem().FieldByName(f.Service())
+	if service.IsNil() {
+		return false, ""
+	}
+	method := service.MethodByName(f.Method())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	args := make([]reflect.Value, method.Type().NumIn())
+	for i := range args {
+		in := method.Type().In(i)
+		switch {
+		case in == reflect.TypeOf((*context.Context)(nil)).Elem():
+			args[i] = reflect.ValueOf(ctx)
+		case in == reflect.TypeOf((*http.Request)(nil)):
+			args[i] = reflect.ValueOf(httptest.NewRequest("POST", "/", strings.NewReader("{}")))
+		case in.Kind() == reflect.Ptr:
+			args[i] = reflect.New(in.Elem())
+		case in.Kind() == reflect.Interface && in.NumMethod() == 0:
+			args[i] = reflect.ValueOf(&struct{}{})
+		case in.Kind() == reflect.Func:
+			args[i] = reflect.MakeFunc(in, func([]reflect.Value) []reflect.Value {
+				out := make([]reflect.Value, in.NumOut())
+				for j := range out {
+					out[j] = reflect.Zero(in.Out(j))
+				}
+				return out
+			})
+		default:
+			args[i] = reflect.Zero(in)
+		}
+	}
+
+	// a method must fail on the zero arguments with an error rather than
+	// a panic, which is reported as a failure of the feature
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = fmt.Sprint(r)
+		}
+	}()
+
+	var out []reflect.Value
+	if method.Type().IsVariadic() {
+		out = method.CallSlice(args)
+	} else {
+		out = method.Call(args)
+	}
+	err, _ := out[len(out)-1].Interface().(error)
+	return !errors.Is(err, scm.ErrNotSupported), ""
+}
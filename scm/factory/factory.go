@@ -281,8 +281,10 @@ func NewWebHookService(driver string) (scm.WebhookService, error) {
 	switch driver {
 	case "bitbucket", "bitbucketcloud":
 		service = bitbucket.NewWebHookService()
+	case "coding":
+		service = coding.NewWebHookService()
 	case "fake", "fakegit":
-		// TODO: support fake
+		service = fake.NewWebHookService()
 	case "gitea":
 		service = gitea.NewWebHookService()
 	case "github":
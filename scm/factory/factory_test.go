@@ -50,3 +50,15 @@ func TestFromRepoURL(t *testing.T) {
 		t.Fatalf("got %q, want %q", p, "abc123")
 	}
 }
+
+func TestNewWebHookService(t *testing.T) {
+	for _, driver := range []string{"bitbucket", "coding", "fake", "gitea", "github", "gitlab", "gogs", "stash"} {
+		service, err := NewWebHookService(driver)
+		if err != nil {
+			t.Errorf("failed to create webhook service for %s: %s", driver, err)
+		}
+		if service == nil {
+			t.Errorf("no webhook service created for %s", driver)
+		}
+	}
+}
@@ -43,6 +43,54 @@ type (
 		Sha  string
 		Page int
 		Size int
+
+		// Path only lists the commits which changed the
+		// file or directory at the given path.
+		Path string
+
+		// Author only lists the commits by the given author
+		// which is matched against the login, name or email
+		// depending on the provider. The Bitbucket, Bitbucket
+		// Server and Gitea APIs cannot filter by author so
+		// each page is filtered as it is listed and may come
+		// back short.
+		Author string
+
+		// Since and Until only list the commits in the time
+		// range, ignored if they are the zero time. Bitbucket
+		// and Bitbucket Server filter each page as it is
+		// listed like Author.
+		Since time.Time
+		Until time.Time
+
+		// FirstParent only follows the first parent of merge
+		// commits which GitLab supports, and NoMerges skips
+		// merge commits which Bitbucket Server supports. The
+		// GitHub, Gitea and Bitbucket APIs support neither so
+		// they are ignored.
+		FirstParent bool
+		NoMerges    bool
+	}
+
+	// Comparison represents the comparison of two commits.
+	Comparison struct {
+		// MergeBase is the sha of the best common ancestor
+		// of the two commits.
+		MergeBase string
+
+		// Ahead is the number of commits on the head which
+		// are not on the base and Behind is the number of
+		// commits on the base which are not on the head.
+		Ahead  int
+		Behind int
+
+		// Commits are the commits on the head which are not
+		// on the base, oldest first.
+		Commits []*Commit
+
+		// Changes are the files changed between the merge
+		// base and the head.
+		Changes []*Change
 	}
 
 	// Signature identifies a git commit creator.
@@ -80,6 +128,12 @@ type (
 		// ListChanges returns the changeset between two commits.
 		CompareCommits(ctx context.Context, repo, ref1, ref2 string, opts ListOptions) ([]*Change, *Response, error)
 
+		// Compare compares the head commit with the base commit
+		// returning their merge base, how far the head is ahead
+		// and behind the base and the commits and changes
+		// between them.
+		Compare(ctx context.Context, repo, base, head string, opts ListOptions) (*Comparison, *Response, error)
+
 		// ListTags returns a list of git tags.
 		ListTags(ctx context.Context, repo string, opts ListOptions) ([]*Reference, *Response, error)
 
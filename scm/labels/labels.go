@@ -40,6 +40,19 @@ func ConvertLabelComments(cs []*scm.Comment) ([]*scm.Label, error) {
 	return ls, nil
 }
 
+// ParseLabelComment returns the label which the comment adds, or removes if
+// removed is true, and whether the comment is a label comment at all.
+func ParseLabelComment(body string) (label string, removed bool, ok bool) {
+	if !strings.HasPrefix(body, addLabel) {
+		return "", false, false
+	}
+	label = strings.TrimPrefix(body, addLabel)
+	if strings.HasSuffix(label, removeLabel) {
+		return strings.TrimSuffix(label, removeLabel), true, true
+	}
+	return label, false, true
+}
+
 // CreateLabelAddComment creates a label comment for git providers which don't support labels with comment with /jx-label <name>
 func CreateLabelAddComment(label string) *scm.CommentInput {
 	return &scm.CommentInput{
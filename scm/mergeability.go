@@ -0,0 +1,166 @@
+package scm
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// FindMergeability evaluates whether the pull request can be merged using the
+// combined status of its head commit and its reviews along with the given
+// branch protection rules, which may be nil if they are not known.
+//
+// Drivers use this to implement PullRequestService.FindMergeability before
+// adding any provider specific blockers.
+func FindMergeability(ctx context.Context, client *Client, repo string, pr *PullRequest, rules *MergeRules) (*Mergeability, *Response, error) {
+	sha := pr.Head.Sha
+	if sha == "" {
+		sha = pr.Sha
+	}
+	var statuses []*Status
+	if sha != "" {
+		combined, res, err := client.Repositories.FindCombinedStatus(ctx, repo, sha)
+		if err != nil && err != ErrNotSupported {
+			return nil, res, err
+		}
+		if combined != nil {
+			statuses = combined.Statuses
+		}
+	}
+	var reviews []*Review
+	if client.Reviews != nil {
+		list, res, err := client.Reviews.List(ctx, repo, pr.Number, ListOptions{Size: 100})
+		if err != nil && err != ErrNotSupported {
+			return nil, res, err
+		}
+		reviews = list
+	}
+	return EvaluateMergeability(pr, statuses, reviews, rules), nil, nil
+}
+
+// EvaluateMergeability returns the mergeability of the pull request given the
+// statuses of its head commit, its reviews and the branch protection rules.
+//
+// If the rules are nil every reported status context is treated as required,
+// otherwise only the required contexts of the rules block the merge. When a
+// context has several statuses the most recently updated one is used; statuses
+// without timestamps are assumed to be listed newest first.
+func EvaluateMergeability(pr *PullRequest, statuses []*Status, reviews []*Review, rules *MergeRules) *Mergeability {
+	m := &Mergeability{}
+	if rules != nil {
+		m.RequiredApprovals = rules.RequiredApprovals
+	}
+	if pr.Closed || pr.Merged {
+		m.Block(MergeBlockerClosed)
+	}
+	if pr.Draft {
+		m.Block(MergeBlockerDraft)
+	}
+	if pr.MergeableState == MergeableStateConflicting {
+		m.Block(MergeBlockerConflicts)
+	}
+
+	// the latest status of each context by the time it was reported,
+	// or else the first one as the providers list the newest first
+	latest := map[string]*Status{}
+	var contexts []string
+	for _, s := range statuses {
+		prev, ok := latest[s.Label]
+		if !ok {
+			latest[s.Label] = s
+			contexts = append(contexts, s.Label)
+			continue
+		}
+		if statusTime(s).After(statusTime(prev)) {
+			latest[s.Label] = s
+		}
+	}
+	if rules != nil {
+		contexts = rules.RequiredContexts
+	}
+	for _, context := range contexts {
+		s, ok := latest[context]
+		if !ok {
+			m.MissingContexts = append(m.MissingContexts, context)
+			continue
+		}
+		switch s.State {
+		case StateSuccess:
+		case StatePending, StateRunning:
+			m.PendingContexts = append(m.PendingContexts, context)
+		case StateExpected, StateUnknown:
+			m.MissingContexts = append(m.MissingContexts, context)
+		default:
+			m.FailingContexts = append(m.FailingContexts, context)
+		}
+	}
+	if len(m.FailingContexts) > 0 {
+		m.Block(MergeBlockerFailingChecks)
+	}
+	if len(m.MissingContexts) > 0 {
+		m.Block(MergeBlockerMissingChecks)
+	}
+	if len(m.PendingContexts) > 0 {
+		m.Block(MergeBlockerPendingChecks)
+	}
+
+	// the latest approving or blocking review of each reviewer
+	sorted := make([]*Review, len(reviews))
+	copy(sorted, reviews)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Created.Before(sorted[j].Created)
+	})
+	verdicts := map[string]string{}
+	for _, r := range sorted {
+		switch r.State {
+		case ReviewStateApproved, ReviewStateChangesRequested, ReviewStateDismissed:
+			verdicts[r.Author.Login] = r.State
+		}
+	}
+	changesRequested := false
+	for _, state := range verdicts {
+		switch state {
+		case ReviewStateApproved:
+			m.Approvals++
+		case ReviewStateChangesRequested:
+			changesRequested = true
+		}
+	}
+	if changesRequested {
+		m.Block(MergeBlockerChangesRequested)
+	}
+	if m.Approvals < m.RequiredApprovals {
+		m.Block(MergeBlockerInsufficientApprovals)
+	}
+	m.Mergeable = len(m.Blockers) == 0
+	return m
+}
+
+// statusTime returns the time the status was last reported
+func statusTime(s *Status) time.Time {
+	if !s.Updated.IsZero() {
+		return s.Updated
+	}
+	return s.Created
+}
+
+// Block adds the blocker to the mergeability if it is not already present
+func (m *Mergeability) Block(blocker MergeBlocker) {
+	for _, b := range m.Blockers {
+		if b == blocker {
+			return
+		}
+	}
+	m.Blockers = append(m.Blockers, blocker)
+	m.Mergeable = false
+}
+
+// IsBlockedBy returns true if the pull request is blocked by the given blocker
+func (m *Mergeability) IsBlockedBy(blocker MergeBlocker) bool {
+	for _, b := range m.Blockers {
+		if b == blocker {
+			return true
+		}
+	}
+	return false
+}
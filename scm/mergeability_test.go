@@ -0,0 +1,117 @@
+package scm
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEvaluateMergeability(t *testing.T) {
+	now := time.Now()
+	success := &Status{Label: "ci", State: StateSuccess}
+	failure := &Status{Label: "lint", State: StateFailure}
+	pending := &Status{Label: "e2e", State: StatePending}
+	approved := func(login string, created time.Time) *Review {
+		return &Review{Author: User{Login: login}, State: ReviewStateApproved, Created: created}
+	}
+	changesRequested := func(login string, created time.Time) *Review {
+		return &Review{Author: User{Login: login}, State: ReviewStateChangesRequested, Created: created}
+	}
+
+	tests := []struct {
+		name     string
+		pr       *PullRequest
+		statuses []*Status
+		reviews  []*Review
+		rules    *MergeRules
+		want     []MergeBlocker
+		missing  []string
+	}{
+		{
+			name:     "mergeable",
+			pr:       &PullRequest{},
+			statuses: []*Status{success},
+			reviews:  []*Review{approved("bob", now)},
+			rules:    &MergeRules{RequiredContexts: []string{"ci"}, RequiredApprovals: 1},
+		},
+		{
+			name: "closed draft and conflicting",
+			pr:   &PullRequest{Closed: true, Draft: true, MergeableState: MergeableStateConflicting},
+			want: []MergeBlocker{MergeBlockerClosed, MergeBlockerDraft, MergeBlockerConflicts},
+		},
+		{
+			name:     "all reported contexts are required without rules",
+			pr:       &PullRequest{},
+			statuses: []*Status{success, failure, pending},
+			want:     []MergeBlocker{MergeBlockerFailingChecks, MergeBlockerPendingChecks},
+		},
+		{
+			name:     "only required contexts block with rules",
+			pr:       &PullRequest{},
+			statuses: []*Status{success, failure},
+			rules:    &MergeRules{RequiredContexts: []string{"ci", "build"}},
+			want:     []MergeBlocker{MergeBlockerMissingChecks},
+			missing:  []string{"build"},
+		},
+		{
+			name:     "latest status of a context wins",
+			pr:       &PullRequest{},
+			statuses: []*Status{success, {Label: "ci", State: StateFailure}},
+		},
+		{
+			name: "latest status of a context wins by time whatever the order",
+			pr:   &PullRequest{},
+			statuses: []*Status{
+				{Label: "ci", State: StateSuccess, Created: now.Add(-time.Hour)},
+				{Label: "ci", State: StateFailure, Created: now.Add(-2 * time.Hour), Updated: now},
+				{Label: "ci", State: StatePending, Created: now.Add(-time.Minute)},
+			},
+			want: []MergeBlocker{MergeBlockerFailingChecks},
+		},
+		{
+			name:    "latest review of each reviewer wins",
+			pr:      &PullRequest{},
+			reviews: []*Review{approved("bob", now), changesRequested("bob", now.Add(-time.Hour)), approved("alice", now)},
+			rules:   &MergeRules{RequiredApprovals: 2},
+		},
+		{
+			name:    "changes requested and insufficient approvals",
+			pr:      &PullRequest{},
+			reviews: []*Review{approved("bob", now.Add(-time.Hour)), changesRequested("bob", now)},
+			rules:   &MergeRules{RequiredApprovals: 1},
+			want:    []MergeBlocker{MergeBlockerChangesRequested, MergeBlockerInsufficientApprovals},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := EvaluateMergeability(test.pr, test.statuses, test.reviews, test.rules)
+			if !reflect.DeepEqual(got.Blockers, test.want) {
+				t.Errorf("Want blockers %v, got %v", test.want, got.Blockers)
+			}
+			if got.Mergeable != (len(test.want) == 0) {
+				t.Errorf("Want mergeable %v, got %v", len(test.want) == 0, got.Mergeable)
+			}
+			if !reflect.DeepEqual(got.MissingContexts, test.missing) {
+				t.Errorf("Want missing contexts %v, got %v", test.missing, got.MissingContexts)
+			}
+		})
+	}
+}
+
+func TestMergeabilityBlock(t *testing.T) {
+	m := &Mergeability{Mergeable: true}
+	m.Block(MergeBlockerBehindBase)
+	m.Block(MergeBlockerBehindBase)
+	if m.Mergeable {
+		t.Errorf("Want blocked pull request to not be mergeable")
+	}
+	if got, want := len(m.Blockers), 1; got != want {
+		t.Errorf("Want %d blockers, got %d", want, got)
+	}
+	if !m.IsBlockedBy(MergeBlockerBehindBase) {
+		t.Errorf("Want pull request to be blocked by %s", MergeBlockerBehindBase)
+	}
+	if m.IsBlockedBy(MergeBlockerDraft) {
+		t.Errorf("Want pull request to not be blocked by %s", MergeBlockerDraft)
+	}
+}
@@ -42,6 +42,12 @@ type (
 		Created        time.Time
 		Updated        time.Time
 
+		// AutoMerge the automatic merge enabled on the pull request
+		// which is nil if it will not be merged automatically. It is
+		// always nil on Bitbucket Server and Gitea whose pull requests
+		// do not report their automatic merge
+		AutoMerge *AutoMerge
+
 		// Link links to the main pull request page
 		Link string
 
@@ -49,12 +55,54 @@ type (
 		DiffLink string
 	}
 
+	// AutoMerge describes how a pull request will be merged
+	// automatically once its checks pass.
+	AutoMerge struct {
+		// EnabledBy the user who enabled the automatic merge
+		EnabledBy User
+
+		// MergeMethod the merge method which will be used such as
+		// "merge", "squash" or "rebase"
+		MergeMethod string
+
+		// CommitTitle the title of the merge commit
+		CommitTitle string
+
+		// CommitMessage the body of the merge commit
+		CommitMessage string
+	}
+
 	// PullRequestInput provides the input needed to create or update a PR.
 	PullRequestInput struct {
 		Title string
 		Head  string
 		Base  string
 		Body  string
+
+		// The following fields are only used when creating a pull request
+
+		// HeadRepo the full name of the fork containing the head branch
+		// if it is not the base repository
+		HeadRepo string
+
+		// Draft creates the pull request as a draft
+		Draft bool
+
+		// MaintainerCanModify allows the maintainers of the base
+		// repository to push to the head branch
+		MaintainerCanModify bool
+
+		// Labels the names of the labels to add
+		Labels []string
+
+		// Assignees the logins of the users to assign
+		Assignees []string
+
+		// Reviewers the logins of the users to request a review from
+		Reviewers []string
+
+		// Milestone the number of the milestone
+		Milestone int
 	}
 
 	// Milestone the milestone
@@ -80,6 +128,26 @@ type (
 		UpdatedBefore *time.Time
 		CreatedAfter  *time.Time
 		CreatedBefore *time.Time
+
+		// Head the source branch of the pull requests, which can
+		// be qualified with the owner of a fork as "owner:branch"
+		Head string
+
+		// Base the target branch of the pull requests
+		Base string
+
+		// Author the login of the author of the pull requests. The
+		// GitHub and Gitea APIs cannot filter by author so each page
+		// is filtered as it is listed, which means a page may hold
+		// fewer pull requests than its size, or none, while the
+		// response still points to a next page.
+		Author string
+
+		// Sort the field to sort by, either "created" or "updated"
+		Sort string
+
+		// Direction the sort direction, either "asc" or "desc"
+		Direction string
 	}
 
 	// PullRequestBranch contains information about a particular branch in a PR.
@@ -106,8 +174,9 @@ type (
 
 	// PullRequestMergeOptions lets you define how a pull request will be merged.
 	PullRequestMergeOptions struct {
-		CommitTitle string // Extra detail to append to automatic commit message. (Optional.)
-		SHA         string // SHA that pull request head must match to allow merge. (Optional.)
+		CommitTitle   string // Extra detail to append to automatic commit message. (Optional.)
+		CommitMessage string // The body of the merge commit message. (Optional.)
+		SHA           string // SHA that pull request head must match to allow merge. (Optional.)
 
 		// The merge method to use. Possible values include: "merge", "squash", and "rebase" with the default being merge. (Optional.)
 		MergeMethod string
@@ -119,6 +188,49 @@ type (
 		DeleteSourceBranch bool
 	}
 
+	// MergeBlocker is a reason a pull request cannot be merged yet
+	MergeBlocker string
+
+	// Mergeability is the verdict on whether a pull request can be merged now
+	Mergeability struct {
+		// Mergeable is true if nothing blocks merging the pull request
+		Mergeable bool
+
+		// Blockers the reasons the pull request cannot be merged yet
+		Blockers []MergeBlocker
+
+		// FailingContexts the status contexts which have failed
+		FailingContexts []string
+
+		// PendingContexts the status contexts which have not completed yet
+		PendingContexts []string
+
+		// MissingContexts the required status contexts which have not been reported
+		MissingContexts []string
+
+		// Approvals the number of reviewers who currently approve the pull request
+		Approvals int
+
+		// RequiredApprovals the number of approvals required to merge
+		RequiredApprovals int
+
+		// State the raw mergeability reported by the provider, such as the
+		// mergeable_state on GitHub or the detailed_merge_status on GitLab
+		State string
+
+		// Messages any explanations given by the provider for blocking the merge
+		Messages []string
+	}
+
+	// MergeRules are the branch protection rules which must be satisfied to merge
+	MergeRules struct {
+		// RequiredContexts the status contexts which must succeed
+		RequiredContexts []string
+
+		// RequiredApprovals the number of approving reviews required
+		RequiredApprovals int
+	}
+
 	// PullRequestService provides access to pull request resources.
 	PullRequestService interface {
 		// Find returns the repository pull request by number.
@@ -136,6 +248,9 @@ type (
 		// ListChanges returns the pull request changeset.
 		ListChanges(context.Context, string, int, ListOptions) ([]*Change, *Response, error)
 
+		// ListCommits returns the commits of a pull request.
+		ListCommits(ctx context.Context, repo string, number int, opts ListOptions) ([]*Commit, *Response, error)
+
 		// ListComments returns the pull request comment list.
 		ListComments(context.Context, string, int, ListOptions) ([]*Comment, *Response, error)
 
@@ -148,6 +263,24 @@ type (
 		// Merge merges the repository pull request.
 		Merge(context.Context, string, int, *PullRequestMergeOptions) (*Response, error)
 
+		// EnableAutoMerge merges the pull request automatically once its checks pass
+		EnableAutoMerge(ctx context.Context, repo string, number int, options *PullRequestMergeOptions) (*Response, error)
+
+		// DisableAutoMerge cancels the automatic merge of the pull request
+		DisableAutoMerge(ctx context.Context, repo string, number int) (*Response, error)
+
+		// UpdateBranch brings the pull request up to date with its base branch
+		// using the given method: "merge" or "rebase". An empty method uses the
+		// default of the provider, and a method the provider has no api for
+		// returns ErrNotSupported.
+		UpdateBranch(ctx context.Context, repo string, number int, method string) (*Response, error)
+
+		// MarkReady marks a draft pull request as ready for review
+		MarkReady(ctx context.Context, repo string, number int) (*Response, error)
+
+		// ConvertToDraft converts the pull request back to a draft
+		ConvertToDraft(ctx context.Context, repo string, number int) (*Response, error)
+
 		// Close closes the repository pull request.
 		Close(context.Context, string, int) (*Response, error)
 
@@ -175,7 +308,11 @@ type (
 		// UnassignIssue removes the assignment of ne or more users on an issue
 		UnassignIssue(ctx context.Context, repo string, number int, logins []string) (*Response, error)
 
-		// Create creates a new pull request in a repo.
+		// Create creates a new pull request in a repo. Labels, assignees,
+		// milestones or reviewers the provider cannot set return
+		// ErrNotSupported before creating, except for labels which drivers
+		// keep as label comments. If setting them fails after the pull
+		// request is created it is returned along with the error.
 		Create(context.Context, string, *PullRequestInput) (*PullRequest, *Response, error)
 
 		// RequestReview adds one or more users as a reviewer on a pull request.
@@ -189,6 +326,10 @@ type (
 
 		// ClearMilestone removes the milestone from a pull request
 		ClearMilestone(ctx context.Context, repo string, prID int) (*Response, error)
+
+		// FindMergeability returns whether the pull request can be merged now
+		// along with the reasons it cannot
+		FindMergeability(ctx context.Context, repo string, number int) (*Mergeability, *Response, error)
 	}
 )
 
@@ -202,6 +343,34 @@ const (
 	MergeableStateUnknown MergeableState = ""
 )
 
+// MergeBlocker values.
+const (
+	// MergeBlockerClosed the pull request is closed or already merged
+	MergeBlockerClosed MergeBlocker = "closed"
+	// MergeBlockerDraft the pull request is a draft
+	MergeBlockerDraft MergeBlocker = "draft"
+	// MergeBlockerConflicts the pull request has merge conflicts
+	MergeBlockerConflicts MergeBlocker = "conflicts"
+	// MergeBlockerFailingChecks a status context has failed
+	MergeBlockerFailingChecks MergeBlocker = "failing_checks"
+	// MergeBlockerPendingChecks a status context has not completed yet
+	MergeBlockerPendingChecks MergeBlocker = "pending_checks"
+	// MergeBlockerMissingChecks a required status context has not been reported
+	MergeBlockerMissingChecks MergeBlocker = "missing_checks"
+	// MergeBlockerInsufficientApprovals the pull request needs more approvals
+	MergeBlockerInsufficientApprovals MergeBlocker = "insufficient_approvals"
+	// MergeBlockerChangesRequested a reviewer has requested changes
+	MergeBlockerChangesRequested MergeBlocker = "changes_requested"
+	// MergeBlockerBehindBase the head branch must be updated with the base branch
+	MergeBlockerBehindBase MergeBlocker = "behind_base"
+	// MergeBlockerUnresolvedDiscussions the pull request has unresolved discussions
+	MergeBlockerUnresolvedDiscussions MergeBlocker = "unresolved_discussions"
+	// MergeBlockerBlocked the provider blocks the merge for another reason
+	MergeBlockerBlocked MergeBlocker = "blocked"
+	// MergeBlockerUnknown the provider is still calculating the mergeability
+	MergeBlockerUnknown MergeBlocker = "unknown"
+)
+
 // Repository returns the base repository where the PR will merge to
 func (pr *PullRequest) Repository() Repository {
 	return pr.Base.Repo
@@ -110,6 +110,11 @@ type (
 		Desc   string
 		Target string
 		Link   string
+
+		// Created and Updated are the times the status was
+		// reported, if the provider includes them.
+		Created time.Time
+		Updated time.Time
 	}
 
 	// StatusInput provides the input fields required for
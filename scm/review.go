@@ -6,6 +6,7 @@ package scm
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
@@ -33,6 +34,32 @@ type (
 		Author  User
 		Created time.Time
 		Updated time.Time
+
+		// StartLine the first line of a multi-line comment
+		StartLine int
+
+		// Side the side of the diff the comment applies to,
+		// either ReviewSideLeft or ReviewSideRight
+		Side string
+
+		// ThreadID the id of the thread the comment belongs to
+		ThreadID string
+
+		// InReplyTo the id of the comment this comment replies to
+		InReplyTo int
+	}
+
+	// ReviewThread represents a thread of review comments on
+	// the same lines of a pull request.
+	ReviewThread struct {
+		ID        string
+		Path      string
+		Line      int
+		StartLine int
+		Side      string
+		Resolved  bool
+		Outdated  bool
+		Comments  []*ReviewComment
 	}
 
 	// ReviewHook represents a review web hook
@@ -52,6 +79,34 @@ type (
 		Body string
 		Path string
 		Line int
+
+		// StartLine the first line of a multi-line comment ending at Line
+		StartLine int
+
+		// Side the side of the diff Line refers to, either ReviewSideLeft
+		// for the old version of the file or ReviewSideRight for the new one
+		Side string
+
+		// StartSide the side of the diff StartLine refers to which
+		// defaults to Side
+		StartSide string
+
+		// OldLine the line in the old version of the file when Line is
+		// an unchanged context line, as some providers need both
+		OldLine int
+
+		// StartOldLine the line in the old version of the file when
+		// StartLine is an unchanged context line
+		StartOldLine int
+
+		// Sha the commit the comment applies to which defaults to
+		// the head of the pull request. GitLab always comments on the
+		// latest version of the diff and ignores it
+		Sha string
+
+		// ThreadID the id of an existing thread to reply to, in which
+		// case only Body is used
+		ThreadID string
 	}
 
 	// ReviewSubmitInput provides the input fields required for submitting a pending review.
@@ -93,6 +148,19 @@ type (
 
 		// Dismiss dismisses a review
 		Dismiss(context.Context, string, int, int, string) (*Review, *Response, error)
+
+		// CreateComment creates an inline review comment on a pull request
+		// or replies to an existing thread
+		CreateComment(ctx context.Context, repo string, number int, input *ReviewCommentInput) (*ReviewComment, *Response, error)
+
+		// ListThreads returns the review threads on a pull request
+		ListThreads(ctx context.Context, repo string, number int, opts ListOptions) ([]*ReviewThread, *Response, error)
+
+		// ResolveThread marks a review thread as resolved
+		ResolveThread(ctx context.Context, repo string, number int, threadID string) (*Response, error)
+
+		// UnresolveThread marks a review thread as unresolved
+		UnresolveThread(ctx context.Context, repo string, number int, threadID string) (*Response, error)
 	}
 )
 
@@ -108,3 +176,20 @@ const (
 	// ReviewStatePending is used for reviews that are awaiting response
 	ReviewStatePending string = "PENDING"
 )
+
+const (
+	// ReviewSideLeft is used for comments on the old version of a file
+	ReviewSideLeft string = "LEFT"
+	// ReviewSideRight is used for comments on the new version of a file
+	ReviewSideRight string = "RIGHT"
+)
+
+// Suggestion returns the body of a review comment suggesting the
+// given code replaces the lines being commented on
+func Suggestion(body, code string) string {
+	suggestion := "```suggestion\n" + strings.TrimSuffix(code, "\n") + "\n```"
+	if body == "" {
+		return suggestion
+	}
+	return body + "\n\n" + suggestion
+}
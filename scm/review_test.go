@@ -0,0 +1,17 @@
+package scm
+
+import "testing"
+
+func TestSuggestion(t *testing.T) {
+	tests := []struct {
+		body, code, want string
+	}{
+		{"", "return nil\n", "```suggestion\nreturn nil\n```"},
+		{"Simplify this", "return nil", "Simplify this\n\n```suggestion\nreturn nil\n```"},
+	}
+	for _, test := range tests {
+		if got := Suggestion(test.body, test.code); got != test.want {
+			t.Errorf("Want suggestion %q, got %q", test.want, got)
+		}
+	}
+}
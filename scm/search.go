@@ -0,0 +1,170 @@
+package scm
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+type (
+	// SearchQuery is a typed query used to search across repositories.
+	// Only the fields which are set are used to filter the results.
+	SearchQuery struct {
+		// Terms are the free text terms to search for
+		Terms string
+
+		// Repo limits the search to the given repository e.g. "owner/name"
+		Repo string
+
+		// Org limits the search to the repositories of the given organisation
+		Org string
+
+		// State filters issues and pull requests by their state:
+		// "open", "closed" or, for pull requests, "merged"
+		State string
+
+		// Labels filters issues and pull requests which have all of the labels
+		Labels []string
+
+		// Author filters by the login of the author
+		Author string
+
+		// UpdatedAfter filters out results last updated before the time
+		UpdatedAfter time.Time
+
+		// UpdatedBefore filters out results last updated after the time
+		UpdatedBefore time.Time
+
+		// Sort the field to sort the results by which is provider specific
+		Sort      string
+		Ascending bool
+
+		Page int
+		Size int
+	}
+
+	// RepositorySearchResults the repositories matching a search query.
+	RepositorySearchResults struct {
+		Total      int
+		Incomplete bool
+		Items      []*Repository
+	}
+
+	// IssueSearchResults the issues matching a search query.
+	IssueSearchResults struct {
+		Total      int
+		Incomplete bool
+		Items      []*SearchIssue
+	}
+
+	// PullRequestSearchResults the pull requests matching a search query.
+	PullRequestSearchResults struct {
+		Total      int
+		Incomplete bool
+		Items      []*SearchPullRequest
+	}
+
+	// SearchPullRequest a pull request found by a search along with its repository.
+	SearchPullRequest struct {
+		PullRequest
+		Repository Repository
+	}
+
+	// CodeSearchResults the files matching a search query.
+	CodeSearchResults struct {
+		Total      int
+		Incomplete bool
+		Items      []*CodeResult
+	}
+
+	// CodeResult a file matching a code search.
+	CodeResult struct {
+		Name       string
+		Path       string
+		Sha        string
+		Ref        string
+		Link       string
+		Fragment   string
+		Repository Repository
+	}
+
+	// CommitSearchResults the commits matching a search query.
+	CommitSearchResults struct {
+		Total      int
+		Incomplete bool
+		Items      []*SearchCommit
+	}
+
+	// SearchCommit a commit found by a search along with its repository.
+	SearchCommit struct {
+		Commit
+		Repository Repository
+	}
+
+	// SearchService provides typed searches across repositories. The
+	// Total of the results is the number reported by the provider, and
+	// they are Incomplete if the provider timed out or the driver had to
+	// filter the page for qualifiers the provider does not support.
+	SearchService interface {
+		// Repositories returns the repositories matching the query.
+		Repositories(context.Context, SearchQuery) (*RepositorySearchResults, *Response, error)
+
+		// Issues returns the issues matching the query.
+		Issues(context.Context, SearchQuery) (*IssueSearchResults, *Response, error)
+
+		// PullRequests returns the pull requests matching the query.
+		PullRequests(context.Context, SearchQuery) (*PullRequestSearchResults, *Response, error)
+
+		// Code returns the files whose content matches the query.
+		Code(context.Context, SearchQuery) (*CodeSearchResults, *Response, error)
+
+		// Commits returns the commits matching the query.
+		Commits(context.Context, SearchQuery) (*CommitSearchResults, *Response, error)
+	}
+)
+
+// String renders the query using the GitHub search syntax, e.g.
+// "fix repo:octocat/hello-world state:open label:bug updated:>=2020-01-02T15:04:05Z"
+func (q *SearchQuery) String() string {
+	var terms []string
+	if q.Terms != "" {
+		terms = append(terms, q.Terms)
+	}
+	if q.Repo != "" {
+		terms = append(terms, "repo:"+q.Repo)
+	}
+	if q.Org != "" {
+		terms = append(terms, "org:"+q.Org)
+	}
+	switch q.State {
+	case "":
+	case "merged":
+		terms = append(terms, "is:merged")
+	default:
+		terms = append(terms, "state:"+q.State)
+	}
+	for _, l := range q.Labels {
+		terms = append(terms, "label:"+quoteSearchTerm(l))
+	}
+	if q.Author != "" {
+		terms = append(terms, "author:"+q.Author)
+	}
+	after, before := !q.UpdatedAfter.IsZero(), !q.UpdatedBefore.IsZero()
+	switch {
+	case after && before:
+		terms = append(terms, "updated:"+q.UpdatedAfter.UTC().Format(SearchTimeFormat)+".."+q.UpdatedBefore.UTC().Format(SearchTimeFormat))
+	case after:
+		terms = append(terms, "updated:>="+q.UpdatedAfter.UTC().Format(SearchTimeFormat))
+	case before:
+		terms = append(terms, "updated:<="+q.UpdatedBefore.UTC().Format(SearchTimeFormat))
+	}
+	return strings.Join(terms, " ")
+}
+
+// quoteSearchTerm quotes the term if it contains whitespace
+func quoteSearchTerm(s string) string {
+	if strings.ContainsAny(s, " \t") {
+		return `"` + s + `"`
+	}
+	return s
+}
@@ -0,0 +1,31 @@
+package scm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSearchQueryString(t *testing.T) {
+	after := time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)
+	before := time.Date(2020, 2, 3, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		query SearchQuery
+		want  string
+	}{
+		{SearchQuery{}, ""},
+		{SearchQuery{Terms: "fix"}, "fix"},
+		{
+			SearchQuery{Terms: "fix", Repo: "octocat/hello-world", State: "open", Labels: []string{"bug", "needs review"}, Author: "octocat"},
+			`fix repo:octocat/hello-world state:open label:bug label:"needs review" author:octocat`,
+		},
+		{SearchQuery{Org: "octocat", State: "merged"}, "org:octocat is:merged"},
+		{SearchQuery{UpdatedAfter: after}, "updated:>=2020-01-02T15:04:05Z"},
+		{SearchQuery{UpdatedBefore: before}, "updated:<=2020-02-03T00:00:00Z"},
+		{SearchQuery{UpdatedAfter: after, UpdatedBefore: before}, "updated:2020-01-02T15:04:05Z..2020-02-03T00:00:00Z"},
+	}
+	for _, test := range tests {
+		if got := test.query.String(); got != test.want {
+			t.Errorf("Want query %q, got %q", test.want, got)
+		}
+	}
+}
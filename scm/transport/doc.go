@@ -1,4 +1,5 @@
 // Package transport provides facilities for setting up
 // authenticated http.RoundTripper given credentials and
-// base RoundTripper.
+// base RoundTripper, along with a Recorder for recording
+// and replaying interactions with a git provider.
 package transport
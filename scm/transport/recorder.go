@@ -0,0 +1,372 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode of a Recorder
+type Mode int
+
+const (
+	// ModeReplay replays the interactions of the cassette without
+	// making any real requests
+	ModeReplay Mode = iota
+
+	// ModeRecord makes real requests using the base transport and
+	// records the interactions into the cassette
+	ModeRecord
+)
+
+// Redacted is the value which replaces secrets in a cassette
+const Redacted = "REDACTED"
+
+// sensitiveHeaders are headers which are always redacted
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Private-Token",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"X-Gitea-Otp",
+	"X-Gitlab-Token",
+	"X-Hub-Signature",
+	"X-Hub-Signature-256",
+}
+
+// sensitiveParams are query parameters which are always redacted
+var sensitiveParams = []string{
+	"access_token",
+	"client_secret",
+	"private_token",
+	"token",
+}
+
+// Cassette the interactions recorded by a Recorder
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction a recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+
+	replayed bool
+}
+
+// RecordedRequest a recorded http request
+type RecordedRequest struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 bool        `json:"body_base64,omitempty"`
+}
+
+// RecordedResponse a recorded http response
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 bool        `json:"body_base64,omitempty"`
+}
+
+// LoadCassette loads the cassette from the given file
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cassette{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %v", path, err)
+	}
+	return c, nil
+}
+
+// Save saves the cassette to the given file
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Recorder is an http.RoundTripper that records the
+// requests made with a base RoundTripper into a cassette
+// file, or replays a previously recorded cassette without
+// making any requests. Requests are matched on their
+// method, path, query and body so that a cassette can be
+// recorded against one server and replayed against any
+// other base URL.
+//
+// Sensitive headers, query parameters and any configured
+// Secrets are redacted before an interaction is recorded.
+type Recorder struct {
+	Base http.RoundTripper
+
+	// Mode whether to record or replay interactions
+	Mode Mode
+
+	// Cassette the path of the cassette file
+	Cassette string
+
+	// Secrets values, such as tokens or passwords, which are
+	// redacted wherever they appear in a recorded interaction
+	Secrets []string
+
+	mu       sync.Mutex
+	cassette *Cassette
+}
+
+// RoundTrip records or replays the request depending on the
+// mode of the recorder.
+func (t *Recorder) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	req := t.recordRequest(r, body)
+	if t.Mode == ModeRecord {
+		return t.record(r, req, body)
+	}
+	return t.replay(r, req)
+}
+
+// Save writes the recorded interactions to the cassette file.
+// It should be called once all requests have been recorded.
+func (t *Recorder) Save() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.Mode != ModeRecord {
+		return nil
+	}
+	if err := t.load(); err != nil {
+		return err
+	}
+	return t.cassette.Save(t.Cassette)
+}
+
+// load lazily loads the cassette, starting a new one when
+// recording
+func (t *Recorder) load() error {
+	if t.cassette != nil {
+		return nil
+	}
+	if t.Mode == ModeRecord {
+		t.cassette = &Cassette{}
+		return nil
+	}
+	c, err := LoadCassette(t.Cassette)
+	if err != nil {
+		return err
+	}
+	t.cassette = c
+	return nil
+}
+
+func (t *Recorder) record(r *http.Request, req RecordedRequest, body []byte) (*http.Response, error) {
+	r2 := cloneRequest(r)
+	if body != nil {
+		r2.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	res, err := t.base().RoundTrip(r2)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+	recorded := RecordedResponse{
+		StatusCode: res.StatusCode,
+		Header:     t.redactHeader(res.Header),
+	}
+	recorded.Body, recorded.BodyBase64 = t.encodeBody(data)
+	t.cassette.Interactions = append(t.cassette.Interactions, &Interaction{
+		Request:  req,
+		Response: recorded,
+	})
+	return res, nil
+}
+
+// replay returns the response of the first interaction
+// matching the request which has not been replayed yet,
+// falling back to the last matching interaction so that
+// repeated requests can be replayed any number of times.
+func (t *Recorder) replay(r *http.Request, req RecordedRequest) (*http.Response, error) {
+	var found *Interaction
+	for _, i := range t.cassette.Interactions {
+		if !matches(&i.Request, &req) {
+			continue
+		}
+		found = i
+		if !i.replayed {
+			break
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no interaction in cassette %s matches %s %s", t.Cassette, req.Method, req.URL)
+	}
+	found.replayed = true
+
+	data, err := decodeBody(found.Response.Body, found.Response.BodyBase64)
+	if err != nil {
+		return nil, err
+	}
+	header := http.Header{}
+	for k, v := range found.Response.Header {
+		header[k] = append([]string(nil), v...)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", found.Response.StatusCode, http.StatusText(found.Response.StatusCode)),
+		StatusCode:    found.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       r,
+	}, nil
+}
+
+// recordRequest returns the redacted form of the request
+func (t *Recorder) recordRequest(r *http.Request, body []byte) RecordedRequest {
+	u := *r.URL
+	query := u.Query()
+	for _, param := range sensitiveParams {
+		if _, ok := query[param]; ok {
+			query.Set(param, Redacted)
+		}
+	}
+	u.RawQuery = query.Encode()
+	u.User = nil
+	req := RecordedRequest{
+		Method: r.Method,
+		URL:    t.redact(u.String()),
+		Header: t.redactHeader(r.Header),
+	}
+	req.Body, req.BodyBase64 = t.encodeBody(body)
+	return req
+}
+
+// redact replaces the secrets in the text
+func (t *Recorder) redact(text string) string {
+	for _, secret := range t.Secrets {
+		if secret != "" {
+			text = strings.Replace(text, secret, Redacted, -1)
+		}
+	}
+	return text
+}
+
+// redactHeader returns a copy of the header with sensitive
+// headers and secrets redacted
+func (t *Recorder) redactHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	out := http.Header{}
+	for k, values := range header {
+		for _, v := range values {
+			out.Add(k, t.redact(v))
+		}
+	}
+	for _, k := range sensitiveHeaders {
+		if out.Get(k) != "" {
+			out.Set(k, Redacted)
+		}
+	}
+	return out
+}
+
+// encodeBody returns the redacted body, base64 encoding any
+// binary content
+func (t *Recorder) encodeBody(body []byte) (string, bool) {
+	if !utf8.Valid(body) {
+		return base64.StdEncoding.EncodeToString(body), true
+	}
+	return t.redact(string(body)), false
+}
+
+// base returns the base transport. If no base transport
+// is configured, the default transport is returned.
+func (t *Recorder) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// matches returns true if the recorded request has the same
+// method, path, query and body as the request
+func matches(recorded, req *RecordedRequest) bool {
+	if recorded.Method != req.Method {
+		return false
+	}
+	u1, err1 := url.Parse(recorded.URL)
+	u2, err2 := url.Parse(req.URL)
+	if err1 != nil || err2 != nil {
+		return recorded.URL == req.URL
+	}
+	if u1.EscapedPath() != u2.EscapedPath() || u1.Query().Encode() != u2.Query().Encode() {
+		return false
+	}
+	return canonicalBody(recorded.Body) == canonicalBody(req.Body)
+}
+
+// canonicalBody returns the body with JSON normalised so that
+// the order of fields does not matter
+func canonicalBody(body string) string {
+	var v interface{}
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return strings.TrimSpace(body)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
+
+// readBody reads and closes the body of the request
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	defer r.Body.Close()
+	return ioutil.ReadAll(r.Body)
+}
+
+func decodeBody(body string, isBase64 bool) ([]byte, error) {
+	if isBase64 {
+		return base64.StdEncoding.DecodeString(body)
+	}
+	return []byte(body), nil
+}
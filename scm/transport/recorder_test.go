@@ -0,0 +1,89 @@
+package transport
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Private-Token") != "5d41402abc4b" {
+			w.WriteHeader(401)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"method":"` + r.Method + `","body":` + string(body) + `}`))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "test-recorder-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cassette := filepath.Join(dir, "cassette.json")
+	recorder := &Recorder{
+		Base:     &PrivateToken{Token: "5d41402abc4b"},
+		Mode:     ModeRecord,
+		Cassette: cassette,
+		Secrets:  []string{"5d41402abc4b"},
+	}
+	client := &http.Client{Transport: recorder}
+	res, err := client.Post(server.URL+"/api/v4/projects?b=2&a=1", "application/json", strings.NewReader(`{"name":"foo","path":"bar"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "5d41402abc4b") {
+		t.Errorf("Want the token redacted from the cassette, got %s", data)
+	}
+
+	// lets replay against a different host, with the query and body fields reordered
+	replayer := &Recorder{Mode: ModeReplay, Cassette: cassette}
+	client = &http.Client{Transport: replayer}
+	res, err = client.Post("https://gitlab.example.com/api/v4/projects?a=1&b=2", "application/json", strings.NewReader(`{"path":"bar","name":"foo"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+	if got, want := res.StatusCode, 200; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+	if got, want := string(body), `{"method":"POST","body":{"name":"foo","path":"bar"}}`; got != want {
+		t.Errorf("Want body %s, got %s", want, got)
+	}
+	if calls != 1 {
+		t.Errorf("Want 1 request to the server, got %d", calls)
+	}
+
+	_, err = client.Post("https://gitlab.example.com/api/v4/projects?a=1&b=2", "application/json", strings.NewReader(`{"name":"other"}`))
+	if err == nil {
+		t.Errorf("Want an error replaying an unrecorded request")
+	}
+}
+
+func TestRecorder_MissingCassette(t *testing.T) {
+	client := &http.Client{Transport: &Recorder{Cassette: filepath.Join("testdata", "missing.json")}}
+	_, err := client.Get("https://gitlab.com/api/v4/user")
+	if err == nil {
+		t.Errorf("Want an error replaying a missing cassette")
+	}
+}
@@ -366,7 +366,9 @@ type (
 		RepositoryHook             *RepositoryHook             `json:",omitempty"`
 		PullRequestHook            *PullRequestHook            `json:",omitempty"`
 		PullRequestCommentHook     *PullRequestCommentHook     `json:",omitempty"`
+		ReviewHook                 *ReviewHook                 `json:",omitempty"`
 		ReviewCommentHook          *ReviewCommentHook          `json:",omitempty"`
+		StatusHook                 *StatusHook                 `json:",omitempty"`
 		WatchHook                  *WatchHook                  `json:",omitempty"`
 		StarHook                   *StarHook                   `json:",omitempty"`
 	}
@@ -707,6 +709,9 @@ func (h *WebhookWrapper) ToWebhook() (Webhook, error) {
 	if h.LabelHook != nil {
 		return h.LabelHook, nil
 	}
+	if h.ReleaseHook != nil {
+		return h.ReleaseHook, nil
+	}
 	if h.RepositoryHook != nil {
 		return h.RepositoryHook, nil
 	}
@@ -716,9 +721,15 @@ func (h *WebhookWrapper) ToWebhook() (Webhook, error) {
 	if h.PullRequestCommentHook != nil {
 		return h.PullRequestCommentHook, nil
 	}
+	if h.ReviewHook != nil {
+		return h.ReviewHook, nil
+	}
 	if h.ReviewCommentHook != nil {
 		return h.ReviewCommentHook, nil
 	}
+	if h.StatusHook != nil {
+		return h.StatusHook, nil
+	}
 	if h.WatchHook != nil {
 		return h.WatchHook, nil
 	}
@@ -727,3 +738,59 @@ func (h *WebhookWrapper) ToWebhook() (Webhook, error) {
 	}
 	return nil, fmt.Errorf("unsupported webhook")
 }
+
+// NewWebhookWrapper wraps the webhook so that it can be serialised
+func NewWebhookWrapper(hook Webhook) (*WebhookWrapper, error) {
+	w := &WebhookWrapper{}
+	switch h := hook.(type) {
+	case *PingHook:
+		w.PingHook = h
+	case *PushHook:
+		w.PushHook = h
+	case *BranchHook:
+		w.BranchHook = h
+	case *CheckRunHook:
+		w.CheckRunHook = h
+	case *CheckSuiteHook:
+		w.CheckSuiteHook = h
+	case *DeployHook:
+		w.DeployHook = h
+	case *DeploymentStatusHook:
+		w.DeploymentStatusHook = h
+	case *ForkHook:
+		w.ForkHook = h
+	case *TagHook:
+		w.TagHook = h
+	case *IssueHook:
+		w.IssueHook = h
+	case *IssueCommentHook:
+		w.IssueCommentHook = h
+	case *InstallationHook:
+		w.InstallationHook = h
+	case *InstallationRepositoryHook:
+		w.InstallationRepositoryHook = h
+	case *LabelHook:
+		w.LabelHook = h
+	case *ReleaseHook:
+		w.ReleaseHook = h
+	case *RepositoryHook:
+		w.RepositoryHook = h
+	case *PullRequestHook:
+		w.PullRequestHook = h
+	case *PullRequestCommentHook:
+		w.PullRequestCommentHook = h
+	case *ReviewHook:
+		w.ReviewHook = h
+	case *ReviewCommentHook:
+		w.ReviewCommentHook = h
+	case *StatusHook:
+		w.StatusHook = h
+	case *WatchHook:
+		w.WatchHook = h
+	case *StarHook:
+		w.StarHook = h
+	default:
+		return nil, fmt.Errorf("unsupported webhook %T", hook)
+	}
+	return w, nil
+}